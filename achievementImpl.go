@@ -0,0 +1,304 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	openapi "github.com/SpyLime/flowBackend/go"
+	bolt "go.etcd.io/bbolt"
+)
+
+// what a badge is counted from
+const (
+	statNodes    = "nodes"    // nodes the user created
+	statAccepted = "accepted" // suggestions of the user that were accepted
+	statVideo    = "video"    // the most votes any one video of the user has
+	statStreak   = "streak"   // the longest run of days the user was active
+)
+
+type badgeRule struct {
+	Id          string
+	Name        string
+	Description string
+	Stat        string
+	Threshold   int32
+}
+
+// badges are never taken back once earned
+var badgeRules = []badgeRule{
+	{Id: "firstNode", Name: "First node", Description: "created a node", Stat: statNodes, Threshold: 1},
+	{Id: "builder", Name: "Builder", Description: "created 10 nodes", Stat: statNodes, Threshold: 10},
+	{Id: "architect", Name: "Architect", Description: "created 50 nodes", Stat: statNodes, Threshold: 50},
+	{Id: "helpful", Name: "Helpful", Description: "had a suggestion accepted", Stat: statAccepted, Threshold: 1},
+	{Id: "trustedEditor", Name: "Trusted editor", Description: "had 10 suggestions accepted", Stat: statAccepted, Threshold: 10},
+	{Id: "goodFind", Name: "Good find", Description: "added a video that reached 10 votes", Stat: statVideo, Threshold: 10},
+	{Id: "greatFind", Name: "Great find", Description: "added a video that reached 50 votes", Stat: statVideo, Threshold: 50},
+	{Id: "regular", Name: "Regular", Description: "was active 7 days in a row", Stat: statStreak, Threshold: 7},
+	{Id: "dedicated", Name: "Dedicated", Description: "was active 30 days in a row", Stat: statStreak, Threshold: 30},
+}
+
+// reputationLevel returns the level for the reputation and the reputation needed for the next one, zero at the top
+func reputationLevel(reputation int32) (level string, next int32) {
+	switch {
+	case reputation >= KeyReputationDeleter:
+		return KeyLevelDeleter, 0
+	case reputation >= KeyReputationEditor:
+		return KeyLevelEditor, KeyReputationDeleter
+	case reputation >= KeyReputationContributor:
+		return KeyLevelContributor, KeyReputationEditor
+	default:
+		return KeyLevelNewcomer, KeyReputationContributor
+	}
+}
+
+// refreshAchievementsTx brings the level and badges of the user up to date and lets them know about any change,
+// active is set when the user did something themselves so it counts for their streak
+//
+// call it after anything that changed the user has been saved
+func refreshAchievementsTx(tx *bolt.Tx, clock Clock, userId string, active bool) (err error) {
+	usersBucket := tx.Bucket([]byte(KeyUsers))
+	if usersBucket == nil {
+		return fmt.Errorf("can't find users bucket")
+	}
+
+	// votes can still reach creators that were deleted, they have nothing to earn
+	if usersBucket.Get([]byte(userId)) == nil {
+		return
+	}
+
+	_, user, err := getUserAndBucketRx(tx, userId)
+	if err != nil {
+		return
+	}
+
+	if active {
+		updateStreak(clock, &user)
+	}
+
+	level, next := reputationLevel(user.Reputation)
+	if level != user.Level {
+		// users from before levels start at theirs without being told
+		if user.Level != "" || level != KeyLevelNewcomer {
+			err = addNotificationTx(tx, clock, userId, openapi.Notification{
+				Type:    KeyNotificationLevel,
+				Message: levelMessage(user.Level, level),
+				Level:   level,
+			})
+			if err != nil {
+				return
+			}
+		}
+
+		user.Level = level
+	}
+	user.NextLevelReputation = next
+
+	stats, err := achievementStatsRx(tx, user)
+	if err != nil {
+		return
+	}
+
+	earned := make(map[string]bool)
+	for _, badge := range user.Badges {
+		earned[badge.Id] = true
+	}
+
+	for _, rule := range badgeRules {
+		if earned[rule.Id] || stats[rule.Stat] < rule.Threshold {
+			continue
+		}
+
+		user.Badges = append(user.Badges, openapi.Badge{
+			Id:          rule.Id,
+			Name:        rule.Name,
+			Description: rule.Description,
+			EarnedAt:    clock.Now(),
+		})
+
+		err = addNotificationTx(tx, clock, userId, openapi.Notification{
+			Type:    KeyNotificationBadge,
+			Message: fmt.Sprintf("you earned the %s badge, you %s", rule.Name, rule.Description),
+			Badge:   rule.Id,
+		})
+		if err != nil {
+			return
+		}
+	}
+
+	marshal, err := json.Marshal(user)
+	if err != nil {
+		return
+	}
+
+	err = usersBucket.Put([]byte(userId), marshal)
+
+	return
+}
+
+// days are counted in UTC so everyone's day ends at the same time
+func updateStreak(clock Clock, user *openapi.User) {
+	now := clock.Now()
+	today := now.UTC().Truncate(24 * time.Hour)
+	lastDay := user.LastActive.UTC().Truncate(24 * time.Hour)
+
+	switch {
+	case user.LastActive.IsZero():
+		user.Streak = 1
+	case today.Equal(lastDay):
+		// already counted
+	case today.Equal(lastDay.Add(24 * time.Hour)):
+		user.Streak++
+	default:
+		user.Streak = 1
+	}
+
+	if user.Streak > user.BestStreak {
+		user.BestStreak = user.Streak
+	}
+	user.LastActive = now
+}
+
+func levelMessage(from, to string) string {
+	if levelReputation(to) < levelReputation(from) {
+		return fmt.Sprintf("your reputation dropped you to the %s level", to)
+	}
+
+	return fmt.Sprintf("you reached the %s level", to)
+}
+
+// the least reputation a level needs
+func levelReputation(level string) int32 {
+	switch level {
+	case KeyLevelDeleter:
+		return KeyReputationDeleter
+	case KeyLevelEditor:
+		return KeyReputationEditor
+	case KeyLevelContributor:
+		return KeyReputationContributor
+	default:
+		return 0
+	}
+}
+
+// achievementStatsRx reads what the badges are given for from the user and the counts kept as their reputation changes
+func achievementStatsRx(tx *bolt.Tx, user openapi.User) (stats map[string]int32, err error) {
+	stats = map[string]int32{
+		statNodes:  int32(len(user.Created)),
+		statStreak: user.BestStreak,
+	}
+
+	achievementsBucket := tx.Bucket([]byte(KeyAchievements))
+	if achievementsBucket == nil {
+		return
+	}
+
+	userBucket := achievementsBucket.Bucket([]byte(user.Id))
+	if userBucket == nil {
+		return
+	}
+
+	for _, stat := range []string{statAccepted, statVideo} {
+		var count int32
+		if data := userBucket.Get([]byte(stat)); data != nil {
+			err = json.Unmarshal(data, &count)
+			if err != nil {
+				return
+			}
+		}
+		stats[stat] = count
+	}
+
+	return
+}
+
+// countAchievementEventTx counts a change of reputation towards the badges of the user.
+// Every video keeps its votes so the best one is known without going through the history,
+// votes voided later don't lower the best as the badges it gave are kept anyway
+func countAchievementEventTx(tx *bolt.Tx, event openapi.ReputationEvent) (err error) {
+	video := event.Link != "" && (event.Source == KeyVoteVideo || event.Source == KeySourceVoid)
+	if event.Source != KeySourceSuggestion && !video {
+		return
+	}
+
+	achievementsBucket, err := tx.CreateBucketIfNotExists([]byte(KeyAchievements))
+	if err != nil {
+		return
+	}
+
+	userBucket, err := achievementsBucket.CreateBucketIfNotExists([]byte(event.UserId))
+	if err != nil {
+		return
+	}
+
+	if !video {
+		_, err = addAchievementCountTx(userBucket, statAccepted, 1)
+		return
+	}
+
+	votes, err := addAchievementCountTx(userBucket, statVideo+" "+event.Link, event.Delta)
+	if err != nil {
+		return
+	}
+
+	var best int32
+	_ = json.Unmarshal(userBucket.Get([]byte(statVideo)), &best)
+	if votes <= best {
+		return
+	}
+
+	marshal, err := json.Marshal(votes)
+	if err != nil {
+		return
+	}
+
+	return userBucket.Put([]byte(statVideo), marshal)
+}
+
+func addAchievementCountTx(userBucket *bolt.Bucket, key string, change int32) (count int32, err error) {
+	_ = json.Unmarshal(userBucket.Get([]byte(key)), &count)
+	count += change
+
+	marshal, err := json.Marshal(count)
+	if err != nil {
+		return
+	}
+
+	err = userBucket.Put([]byte(key), marshal)
+
+	return
+}
+
+// the counts go with the user, their badges are on the user
+func deleteAchievementsTx(tx *bolt.Tx, userId string) (err error) {
+	achievementsBucket := tx.Bucket([]byte(KeyAchievements))
+	if achievementsBucket == nil || achievementsBucket.Bucket([]byte(userId)) == nil {
+		return
+	}
+
+	return achievementsBucket.DeleteBucket([]byte(userId))
+}
+
+// countAchievementsTx counts the reputation history from before the counts were kept
+func countAchievementsTx(tx *bolt.Tx) (err error) {
+	historyBucket := tx.Bucket([]byte(KeyReputationHistory))
+	if historyBucket == nil {
+		return
+	}
+
+	return historyBucket.ForEach(func(userId, v []byte) error {
+		if v != nil {
+			return nil
+		}
+
+		return historyBucket.Bucket(userId).ForEach(func(_, data []byte) error {
+			var event openapi.ReputationEvent
+			err := json.Unmarshal(data, &event)
+			if err != nil {
+				return err
+			}
+
+			return countAchievementEventTx(tx, event)
+		})
+	})
+}
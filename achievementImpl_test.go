@@ -0,0 +1,182 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	openapi "github.com/SpyLime/flowBackend/go"
+	"github.com/go-pkgz/lgr"
+	"github.com/stretchr/testify/require"
+	bolt "go.etcd.io/bbolt"
+)
+
+func TestAchievementsImpl(t *testing.T) {
+
+	lgr.Printf("INFO TestAchievementsImpl")
+	t.Log("INFO TestAchievementsImpl")
+	clock := TestClock{}
+	db, dbTearDown := OpenTestDB("AchievementsImpl")
+	defer dbTearDown()
+
+	users, topics, nodesAndEdges, err := CreateTestData(db, &clock, 2, 1, 1)
+	require.Nil(t, err)
+
+	info, err := getTopicInfo(db, topics[0])
+	require.Nil(t, err)
+
+	creator, member := users[0], users[1]
+	if member == info.CreatedBy {
+		creator, member = member, creator
+	}
+
+	// making the topic and a node earns the first badge
+	user, err := getUser(db, creator)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(user.Badges))
+	require.Equal(t, "firstNode", user.Badges[0].Id)
+	require.Equal(t, int32(1), user.Streak)
+
+	notifications, err := getNotifications(db, creator)
+	require.Nil(t, err)
+	found := false
+	for _, notification := range notifications {
+		if notification.Badge == "firstNode" {
+			found = true
+		}
+	}
+	require.True(t, found)
+
+	err = UpdateUserRoleAndReputation(db, member, false, 0)
+	require.Nil(t, err)
+
+	// crossing a threshold either way is a notification
+	_, err = adjustReputation(db, &clock, member, creator, openapi.ReputationAdjustment{Delta: KeyReputationContributor, Reason: "good maps"})
+	require.Nil(t, err)
+
+	user, err = getUser(db, member)
+	require.Nil(t, err)
+	require.Equal(t, KeyLevelContributor, user.Level)
+	require.Equal(t, int32(KeyReputationEditor), user.NextLevelReputation)
+
+	clock.Tick()
+	_, err = adjustReputation(db, &clock, member, creator, openapi.ReputationAdjustment{Delta: -20, Reason: "spam"})
+	require.Nil(t, err)
+
+	notifications, err = getNotifications(db, member)
+	require.Nil(t, err)
+	require.Equal(t, 2, len(notifications))
+	require.Equal(t, KeyLevelNewcomer, notifications[0].Level)
+	require.Equal(t, "your reputation dropped you to the newcomer level", notifications[0].Message)
+	require.Equal(t, KeyNotificationLevel, notifications[1].Type)
+	require.Equal(t, KeyLevelContributor, notifications[1].Level)
+
+	// a week of activity, a missed day starts the streak over
+	for i := 0; i < 7; i++ {
+		clock.TickOne(24 * time.Hour)
+		err = db.Update(func(tx *bolt.Tx) error {
+			return refreshAchievementsTx(tx, &clock, member, true)
+		})
+		require.Nil(t, err)
+	}
+
+	clock.TickOne(48 * time.Hour)
+	err = db.Update(func(tx *bolt.Tx) error {
+		return refreshAchievementsTx(tx, &clock, member, true)
+	})
+	require.Nil(t, err)
+
+	user, err = getUser(db, member)
+	require.Nil(t, err)
+	require.Equal(t, int32(1), user.Streak)
+	require.Equal(t, int32(7), user.BestStreak)
+	require.Equal(t, "regular", user.Badges[0].Id)
+
+	// a video with enough votes, voided votes don't count
+	link := "https://www.youtube.com/watch?v=dQw4w9WgXcQ"
+	videoVote := func(voterId string) {
+		clock.Tick()
+		err := db.Update(func(tx *bolt.Tx) error {
+			return voteReputationTx(tx, &clock, openapi.VoteRecord{VoterId: voterId, CreatorId: member, Topic: topics[0], NodeId: nodesAndEdges[0].SourceId, Link: link, Kind: KeyVoteVideo, Value: 1})
+		})
+		require.Nil(t, err)
+	}
+
+	videoVote("voter0")
+
+	patterns, err := getVotePatterns(db, topics[0])
+	require.Nil(t, err)
+	require.Equal(t, 0, len(patterns))
+
+	var records []openapi.VoteRecord
+	err = db.View(func(tx *bolt.Tx) error {
+		records, err = getVoteRecordsRx(tx, topics[0], false)
+		return err
+	})
+	require.Nil(t, err)
+
+	_, err = voidVotes(db, &clock, creator, openapi.VoteVoid{VoteIds: []string{records[len(records)-1].Id}, Reason: "ring"})
+	require.Nil(t, err)
+
+	for i := 1; i < 10; i++ {
+		clock.TickOne(time.Hour)
+		videoVote(fmt.Sprintf("voter%d", i))
+	}
+
+	user, err = getUser(db, member)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(user.Badges))
+
+	videoVote("voter10")
+
+	user, err = getUser(db, member)
+	require.Nil(t, err)
+	require.Equal(t, 2, len(user.Badges))
+	require.Equal(t, "goodFind", user.Badges[1].Id)
+
+	// badges are only earned once
+	err = db.Update(func(tx *bolt.Tx) error {
+		return refreshAchievementsTx(tx, &clock, member, true)
+	})
+	require.Nil(t, err)
+
+	user, err = getUser(db, member)
+	require.Nil(t, err)
+	require.Equal(t, 2, len(user.Badges))
+
+	// the counts from before they were kept come from the history
+	err = db.Update(func(tx *bolt.Tx) error {
+		err := tx.DeleteBucket([]byte(KeyAchievements))
+		if err != nil {
+			return err
+		}
+
+		err = countAchievementsTx(tx)
+		if err != nil {
+			return err
+		}
+
+		stats, err := achievementStatsRx(tx, user)
+		require.Equal(t, int32(10), stats[statVideo])
+		require.Equal(t, int32(0), stats[statAccepted])
+		return err
+	})
+	require.Nil(t, err)
+
+	err = markNotificationsRead(db, member)
+	require.Nil(t, err)
+
+	notifications, err = getNotifications(db, member)
+	require.Nil(t, err)
+	require.Equal(t, 4, len(notifications))
+	for _, notification := range notifications {
+		require.True(t, notification.Read)
+	}
+
+	err = deleteUser(db, member)
+	require.Nil(t, err)
+
+	notifications, err = getNotifications(db, member)
+	require.Nil(t, err)
+	require.Equal(t, 0, len(notifications))
+}
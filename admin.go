@@ -0,0 +1,174 @@
+package main
+
+import (
+	"context"
+	"errors"
+
+	openapi "github.com/SpyLime/flowBackend/go"
+	"github.com/go-pkgz/auth/token"
+	bolt "go.etcd.io/bbolt"
+)
+
+// AdminAPIServiceImpl is a service that implements the logic for the AdminAPIServicer
+// This service should implement the business logic for every endpoint for the AdminAPI API.
+// Include any external packages or services that will be required by this service.
+type AdminAPIServiceImpl struct {
+	db     *bolt.DB
+	clock  Clock
+	policy Policy
+}
+
+// NewAdminAPIService creates a default api service
+func NewAdminAPIServiceImpl(db *bolt.DB, clock Clock, policy Policy) openapi.AdminAPIServicer {
+	return &AdminAPIServiceImpl{
+		db:     db,
+		clock:  clock,
+		policy: policy,
+	}
+}
+
+// GetSanction - get the sanction the user is under
+func (s *AdminAPIServiceImpl) GetSanction(ctx context.Context, userId string) (openapi.ImplResponse, error) {
+	err := s.checkAdmin(ctx, KeyActionSanction)
+	if err != nil {
+		return openapi.Response(401, nil), err
+	}
+
+	response, active, err := getSanction(s.db, s.clock, userId)
+	if err != nil {
+		return openapi.Response(400, nil), err
+	}
+
+	if !active {
+		return openapi.Response(404, nil), errors.New("user is not under a sanction")
+	}
+
+	return openapi.Response(200, response), nil
+}
+
+// UpdateSanction - suspend or ban a user, it replaces the sanction they are under
+func (s *AdminAPIServiceImpl) UpdateSanction(ctx context.Context, userId string, sanction openapi.Sanction) (openapi.ImplResponse, error) {
+	err := s.checkAdmin(ctx, KeyActionSanction)
+	if err != nil {
+		return openapi.Response(401, nil), err
+	}
+
+	user := ctx.Value(userInfoKey).(token.User)
+	if userId == user.ID {
+		return openapi.Response(400, nil), errors.New("admins can't sanction themselves")
+	}
+
+	target, err := getUser(s.db, userId)
+	if err != nil {
+		return openapi.Response(400, nil), err
+	}
+
+	if target.Role == KeyAdmin {
+		return openapi.Response(400, nil), errors.New("admins can't be sanctioned")
+	}
+
+	sanction.IssuedBy = user.ID
+
+	response, err := putSanction(s.db, s.clock, userId, sanction)
+	if err != nil {
+		return openapi.Response(400, nil), err
+	}
+
+	return openapi.Response(200, response), nil
+}
+
+// DeleteSanction - lift the sanction the user is under
+func (s *AdminAPIServiceImpl) DeleteSanction(ctx context.Context, userId string) (openapi.ImplResponse, error) {
+	err := s.checkAdmin(ctx, KeyActionSanction)
+	if err != nil {
+		return openapi.Response(401, nil), err
+	}
+
+	user := ctx.Value(userInfoKey).(token.User)
+
+	err = liftSanction(s.db, s.clock, userId, user.ID)
+	if err != nil {
+		return openapi.Response(404, nil), err
+	}
+
+	return openapi.Response(204, nil), nil
+}
+
+// GetAudit - get the audit history, oldest first
+func (s *AdminAPIServiceImpl) GetAudit(ctx context.Context, userId string) (openapi.ImplResponse, error) {
+	err := s.checkAdmin(ctx, KeyActionViewAudit)
+	if err != nil {
+		return openapi.Response(401, nil), err
+	}
+
+	response, err := getAuditEntries(s.db, userId)
+	if err != nil {
+		return openapi.Response(400, nil), err
+	}
+
+	return openapi.Response(200, response), nil
+}
+
+// UpdateUserReputation - add to or take from the reputation of a user
+func (s *AdminAPIServiceImpl) UpdateUserReputation(ctx context.Context, userId string, reputationAdjustment openapi.ReputationAdjustment) (openapi.ImplResponse, error) {
+	err := s.checkAdmin(ctx, KeyActionAdjustReputation)
+	if err != nil {
+		return openapi.Response(401, nil), err
+	}
+
+	user := ctx.Value(userInfoKey).(token.User)
+
+	response, err := adjustReputation(s.db, s.clock, userId, user.ID, reputationAdjustment)
+	if err != nil {
+		return openapi.Response(400, nil), err
+	}
+
+	return openapi.Response(200, response), nil
+}
+
+// GetVotePatterns - find votes that look like vote rings or sockpuppets, with the votes as evidence
+func (s *AdminAPIServiceImpl) GetVotePatterns(ctx context.Context, topicId string) (openapi.ImplResponse, error) {
+	err := s.checkAdmin(ctx, KeyActionVotePatterns)
+	if err != nil {
+		return openapi.Response(401, nil), err
+	}
+
+	response, err := getVotePatterns(s.db, topicId)
+	if err != nil {
+		return openapi.Response(400, nil), err
+	}
+
+	return openapi.Response(200, response), nil
+}
+
+// VoidVotes - take back the reputation the votes gave
+func (s *AdminAPIServiceImpl) VoidVotes(ctx context.Context, voteVoid openapi.VoteVoid) (openapi.ImplResponse, error) {
+	err := s.checkAdmin(ctx, KeyActionVoidVotes)
+	if err != nil {
+		return openapi.Response(401, nil), err
+	}
+
+	user := ctx.Value(userInfoKey).(token.User)
+
+	response, err := voidVotes(s.db, s.clock, user.ID, voteVoid)
+	if err != nil {
+		return openapi.Response(400, nil), err
+	}
+
+	return openapi.Response(200, response), nil
+}
+
+// every admin endpoint has its own action so the policy can hand one out without the others
+func (s *AdminAPIServiceImpl) checkAdmin(ctx context.Context, action string) error {
+	user, ok := ctx.Value(userInfoKey).(token.User)
+	if !ok {
+		return errors.New("unauthorized: user not found in context")
+	}
+
+	userDetails, err := getUser(s.db, user.ID)
+	if err != nil {
+		return err
+	}
+
+	return s.policy.check(s.db, s.clock, action, userDetails, PolicyTarget{})
+}
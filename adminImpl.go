@@ -0,0 +1,296 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	openapi "github.com/SpyLime/flowBackend/go"
+	bolt "go.etcd.io/bbolt"
+)
+
+// suspend or ban a user, it replaces the sanction they are already under
+func putSanction(db *bolt.DB, clock Clock, userId string, request openapi.Sanction) (response openapi.Sanction, err error) {
+	err = db.Update(func(tx *bolt.Tx) error {
+		response, err = putSanctionTx(tx, clock, userId, request)
+		return err
+	})
+
+	return
+}
+
+func putSanctionTx(tx *bolt.Tx, clock Clock, userId string, request openapi.Sanction) (response openapi.Sanction, err error) {
+	if request.Type != KeySanctionSuspend && request.Type != KeySanctionBan {
+		return response, fmt.Errorf("invalid sanction %s", request.Type)
+	}
+
+	if request.Reason == "" {
+		return response, fmt.Errorf("a sanction needs a reason")
+	}
+
+	if !request.Until.IsZero() && !request.Until.After(clock.Now()) {
+		return response, fmt.Errorf("the sanction would already be over")
+	}
+
+	usersBucket, user, err := getUserAndBucketRx(tx, userId)
+	if err != nil {
+		return
+	}
+
+	// a sanction that replaces another keeps what the user was before either of them
+	previous, active, err := getSanctionRx(tx, userId)
+	if err != nil {
+		return
+	}
+
+	sanctionsBucket, err := tx.CreateBucketIfNotExists([]byte(KeySanctions))
+	if err != nil {
+		return
+	}
+
+	response = request
+	response.UserId = userId
+	response.WasFlagged = user.IsFlagged
+	if active {
+		response.WasFlagged = previous.WasFlagged
+	}
+	response.CreatedAt = clock.Now()
+
+	marshal, err := json.Marshal(response)
+	if err != nil {
+		return
+	}
+
+	err = sanctionsBucket.Put([]byte(userId), marshal)
+	if err != nil {
+		return
+	}
+
+	// the flag shows on the profile that the user is under a sanction
+	user.IsFlagged = true
+
+	marshal, err = json.Marshal(user)
+	if err != nil {
+		return
+	}
+
+	err = usersBucket.Put([]byte(userId), marshal)
+	if err != nil {
+		return
+	}
+
+	err = addAuditEntryTx(tx, clock, openapi.AuditEntry{
+		Action:  request.Type,
+		UserId:  userId,
+		ActorId: request.IssuedBy,
+		Reason:  request.Reason,
+		Until:   request.Until,
+	})
+
+	return
+}
+
+// getSanction returns the sanction the user is under, an expired one is lifted first
+func getSanction(db *bolt.DB, clock Clock, userId string) (response openapi.Sanction, active bool, err error) {
+	// this runs on every request so it only writes when the sanction has expired
+	err = db.View(func(tx *bolt.Tx) error {
+		response, active, err = getSanctionRx(tx, userId)
+		return err
+	})
+	if err != nil || !active || response.Until.IsZero() || response.Until.After(clock.Now()) {
+		return
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		response, active, err = getActiveSanctionTx(tx, clock, userId)
+		return err
+	})
+
+	return
+}
+
+// getActiveSanctionTx lifts the sanction when it has expired and records it as done by the server
+func getActiveSanctionTx(tx *bolt.Tx, clock Clock, userId string) (response openapi.Sanction, active bool, err error) {
+	response, active, err = getSanctionRx(tx, userId)
+	if err != nil || !active {
+		return
+	}
+
+	if response.Until.IsZero() || response.Until.After(clock.Now()) {
+		return
+	}
+
+	err = liftSanctionTx(tx, clock, userId, "", KeyAuditExpire)
+
+	return openapi.Sanction{}, false, err
+}
+
+func getSanctionRx(tx *bolt.Tx, userId string) (response openapi.Sanction, active bool, err error) {
+	sanctionsBucket := tx.Bucket([]byte(KeySanctions))
+	if sanctionsBucket == nil {
+		return
+	}
+
+	sanctionData := sanctionsBucket.Get([]byte(userId))
+	if sanctionData == nil {
+		return
+	}
+
+	err = json.Unmarshal(sanctionData, &response)
+	if err != nil {
+		return
+	}
+
+	return response, true, nil
+}
+
+// lift the sanction before it expires
+func liftSanction(db *bolt.DB, clock Clock, userId, actorId string) (err error) {
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, active, err := getSanctionRx(tx, userId)
+		if err != nil {
+			return err
+		}
+
+		if !active {
+			return fmt.Errorf("user is not under a sanction")
+		}
+
+		return liftSanctionTx(tx, clock, userId, actorId, KeyAuditLift)
+	})
+
+	return
+}
+
+// the user is flagged again if they were before the sanction
+func liftSanctionTx(tx *bolt.Tx, clock Clock, userId, actorId, action string) (err error) {
+	sanctionsBucket := tx.Bucket([]byte(KeySanctions))
+	if sanctionsBucket == nil {
+		return fmt.Errorf("can't find sanctions bucket")
+	}
+
+	sanction, _, err := getSanctionRx(tx, userId)
+	if err != nil {
+		return
+	}
+
+	err = sanctionsBucket.Delete([]byte(userId))
+	if err != nil {
+		return
+	}
+
+	// the user might have been deleted while under the sanction
+	usersBucket, user, err := getUserAndBucketRx(tx, userId)
+	if err == nil {
+		user.IsFlagged = sanction.WasFlagged
+
+		marshal, err := json.Marshal(user)
+		if err != nil {
+			return err
+		}
+
+		err = usersBucket.Put([]byte(userId), marshal)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = addAuditEntryTx(tx, clock, openapi.AuditEntry{
+		Action:  action,
+		UserId:  userId,
+		ActorId: actorId,
+	})
+
+	return
+}
+
+// checkSanction returns why the user can't make the request, suspended users can only read
+func checkSanction(db *bolt.DB, clock Clock, userId string, readOnly bool) (err error) {
+	if userId == "" {
+		return nil
+	}
+
+	sanction, active, err := getSanction(db, clock, userId)
+	if err != nil || !active {
+		return err
+	}
+
+	if sanction.Type == KeySanctionSuspend && readOnly {
+		return nil
+	}
+
+	return sanctionError(sanction)
+}
+
+func sanctionError(sanction openapi.Sanction) error {
+	status := "banned"
+	if sanction.Type == KeySanctionSuspend {
+		status = "suspended"
+	}
+
+	if sanction.Until.IsZero() {
+		return fmt.Errorf("user is %s permanently: %s", status, sanction.Reason)
+	}
+
+	return fmt.Errorf("user is %s until %s: %s", status, sanction.Until.Format("2006-01-02 15:04 MST"), sanction.Reason)
+}
+
+func addAuditEntryTx(tx *bolt.Tx, clock Clock, entry openapi.AuditEntry) (err error) {
+	auditBucket, err := tx.CreateBucketIfNotExists([]byte(KeyAudit))
+	if err != nil {
+		return
+	}
+
+	// the sequence keeps the entries in the order they happened
+	sequence, err := auditBucket.NextSequence()
+	if err != nil {
+		return
+	}
+
+	entry.Id = strconv.FormatUint(sequence, 10)
+	entry.CreatedAt = clock.Now()
+
+	marshal, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	err = auditBucket.Put([]byte(fmt.Sprintf("%020d", sequence)), marshal)
+
+	return
+}
+
+// getAuditEntries returns the audit history of a user or everyone when userId is empty, oldest first
+func getAuditEntries(db *bolt.DB, userId string) (response []openapi.AuditEntry, err error) {
+	err = db.View(func(tx *bolt.Tx) error {
+		response, err = getAuditEntriesRx(tx, userId)
+		return err
+	})
+
+	return
+}
+
+func getAuditEntriesRx(tx *bolt.Tx, userId string) (response []openapi.AuditEntry, err error) {
+	response = make([]openapi.AuditEntry, 0)
+
+	auditBucket := tx.Bucket([]byte(KeyAudit))
+	if auditBucket == nil {
+		return
+	}
+
+	err = auditBucket.ForEach(func(k, v []byte) error {
+		var entry openapi.AuditEntry
+		err := json.Unmarshal(v, &entry)
+		if err != nil {
+			return err
+		}
+
+		if userId == "" || entry.UserId == userId {
+			response = append(response, entry)
+		}
+
+		return nil
+	})
+
+	return
+}
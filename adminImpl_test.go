@@ -0,0 +1,99 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	openapi "github.com/SpyLime/flowBackend/go"
+	"github.com/go-pkgz/lgr"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSanctionImpl(t *testing.T) {
+
+	lgr.Printf("INFO TestSanctionImpl")
+	t.Log("INFO TestSanctionImpl")
+	clock := TestClock{}
+	db, dbTearDown := OpenTestDB("SanctionImpl")
+	defer dbTearDown()
+
+	users, _, _, err := CreateTestData(db, &clock, 3, 0, 0)
+	require.Nil(t, err)
+
+	_, err = putSanction(db, &clock, users[0], openapi.Sanction{Type: "mute", Reason: "spam"})
+	require.NotNil(t, err)
+
+	_, err = putSanction(db, &clock, users[0], openapi.Sanction{Type: KeySanctionSuspend})
+	require.NotNil(t, err)
+
+	_, err = putSanction(db, &clock, users[0], openapi.Sanction{Type: KeySanctionSuspend, Reason: "spam", Until: clock.Now()})
+	require.NotNil(t, err)
+
+	_, err = putSanction(db, &clock, "nobody", openapi.Sanction{Type: KeySanctionSuspend, Reason: "spam"})
+	require.NotNil(t, err)
+
+	// a suspended user can still read
+	sanction, err := putSanction(db, &clock, users[0], openapi.Sanction{Type: KeySanctionSuspend, Reason: "spam", Until: clock.Now().Add(time.Hour), IssuedBy: users[2]})
+	require.Nil(t, err)
+	require.Equal(t, users[0], sanction.UserId)
+
+	require.Nil(t, checkSanction(db, &clock, users[0], true))
+	require.NotNil(t, checkSanction(db, &clock, users[0], false))
+	require.Nil(t, checkSanction(db, &clock, users[1], false))
+
+	user, err := getUser(db, users[0])
+	require.Nil(t, err)
+	require.True(t, user.IsFlagged)
+
+	// it is lifted by the clock
+	clock.TickOne(time.Hour)
+	require.Nil(t, checkSanction(db, &clock, users[0], false))
+
+	_, active, err := getSanction(db, &clock, users[0])
+	require.Nil(t, err)
+	require.False(t, active)
+
+	user, err = getUser(db, users[0])
+	require.Nil(t, err)
+	require.False(t, user.IsFlagged)
+
+	// a permanent ban stops reading as well, the flag the user had before stays once it is lifted
+	err = updateUser(db, &clock, openapi.User{Id: users[1], IsFlagged: true})
+	require.Nil(t, err)
+
+	_, err = putSanction(db, &clock, users[1], openapi.Sanction{Type: KeySanctionSuspend, Reason: "abuse", IssuedBy: users[2]})
+	require.Nil(t, err)
+
+	sanction, err = putSanction(db, &clock, users[1], openapi.Sanction{Type: KeySanctionBan, Reason: "abuse", IssuedBy: users[2]})
+	require.Nil(t, err)
+	require.True(t, sanction.WasFlagged)
+
+	clock.TickOne(24 * 365 * time.Hour)
+	require.NotNil(t, checkSanction(db, &clock, users[1], true))
+
+	err = liftSanction(db, &clock, users[1], users[2])
+	require.Nil(t, err)
+	require.Nil(t, checkSanction(db, &clock, users[1], false))
+
+	user, err = getUser(db, users[1])
+	require.Nil(t, err)
+	require.True(t, user.IsFlagged)
+
+	err = liftSanction(db, &clock, users[1], users[2])
+	require.NotNil(t, err)
+
+	entries, err := getAuditEntries(db, "")
+	require.Nil(t, err)
+	require.Equal(t, 5, len(entries))
+	require.Equal(t, KeySanctionSuspend, entries[0].Action)
+	require.Equal(t, users[2], entries[0].ActorId)
+	require.Equal(t, KeyAuditExpire, entries[1].Action)
+	require.Empty(t, entries[1].ActorId)
+	require.Equal(t, KeySanctionSuspend, entries[2].Action)
+	require.Equal(t, KeySanctionBan, entries[3].Action)
+	require.Equal(t, KeyAuditLift, entries[4].Action)
+
+	entries, err = getAuditEntries(db, users[1])
+	require.Nil(t, err)
+	require.Equal(t, 3, len(entries))
+}
@@ -0,0 +1,236 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	openapi "github.com/SpyLime/flowBackend/go"
+	"github.com/go-pkgz/auth/token"
+	"github.com/stretchr/testify/require"
+	bolt "go.etcd.io/bbolt"
+)
+
+func TestSanction(t *testing.T) {
+	clock := TestClock{}
+	db, tearDown := FullStartTestServerClock("Sanction", 8088, "", &clock)
+	defer tearDown()
+
+	users, topics, nodesAndEdges, err := CreateTestData(db, &clock, 3, 1, 1)
+	require.Nil(t, err)
+
+	admin, target, other := users[0], users[1], users[2]
+	err = UpdateUserRoleAndReputation(db, target, false, 1000)
+	require.Nil(t, err)
+	err = UpdateUserRoleAndReputation(db, other, false, 1000)
+	require.Nil(t, err)
+
+	client := &http.Client{}
+	sanctionURL := "http://127.0.0.1:8088/api/v1/admin/user/" + url.PathEscape(target) + "/sanction"
+
+	// only admins can sanction
+	SetTestLoginUser(other)
+
+	marshal, err := json.Marshal(openapi.Sanction{Type: KeySanctionSuspend, Reason: "spam", Until: clock.Now().Add(time.Hour)})
+	require.Nil(t, err)
+
+	req, _ := http.NewRequest(http.MethodPut, sanctionURL, bytes.NewBuffer(marshal))
+
+	resp, err := client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 401, resp.StatusCode)
+
+	SetTestLoginUser(admin)
+
+	req, _ = http.NewRequest(http.MethodPut, sanctionURL, bytes.NewBuffer(marshal))
+
+	resp, err = client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 200, resp.StatusCode)
+
+	var sanction openapi.Sanction
+	err = json.NewDecoder(resp.Body).Decode(&sanction)
+	require.Nil(t, err)
+	require.Equal(t, admin, sanction.IssuedBy)
+
+	// the suspended user can read but not write
+	SetTestLoginUser(target)
+
+	req, _ = http.NewRequest(http.MethodGet, "http://127.0.0.1:8088/api/v1/map/"+topics[0], nil)
+
+	resp, err = client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 200, resp.StatusCode)
+
+	marshal, err = json.Marshal(openapi.NodeData{Id: nodesAndEdges[0].SourceId, Topic: topics[0]})
+	require.Nil(t, err)
+
+	req, _ = http.NewRequest(http.MethodPut, "http://127.0.0.1:8088/api/v1/node/battleVote", bytes.NewBuffer(marshal))
+
+	resp, err = client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 403, resp.StatusCode)
+
+	// it is lifted once it expires
+	clock.TickOne(time.Hour)
+
+	SetTestLoginUser(admin)
+
+	req, _ = http.NewRequest(http.MethodGet, sanctionURL, nil)
+
+	resp, err = client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 404, resp.StatusCode)
+
+	// admins can lift a ban early
+	marshal, err = json.Marshal(openapi.Sanction{Type: KeySanctionBan, Reason: "abuse"})
+	require.Nil(t, err)
+
+	req, _ = http.NewRequest(http.MethodPut, sanctionURL, bytes.NewBuffer(marshal))
+
+	resp, err = client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 200, resp.StatusCode)
+
+	req, _ = http.NewRequest(http.MethodDelete, sanctionURL, nil)
+
+	resp, err = client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 204, resp.StatusCode)
+
+	req, _ = http.NewRequest(http.MethodGet, "http://127.0.0.1:8088/api/v1/admin/audit?userId="+url.QueryEscape(target), nil)
+
+	resp, err = client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 200, resp.StatusCode)
+
+	var entries []openapi.AuditEntry
+	err = json.NewDecoder(resp.Body).Decode(&entries)
+	require.Nil(t, err)
+	require.Equal(t, 4, len(entries))
+	require.Equal(t, KeyAuditExpire, entries[1].Action)
+	require.Equal(t, admin, entries[3].ActorId)
+
+	// admins can't be sanctioned
+	req, _ = http.NewRequest(http.MethodPut, "http://127.0.0.1:8088/api/v1/admin/user/"+url.PathEscape(admin)+"/sanction", bytes.NewBuffer(marshal))
+
+	resp, err = client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 400, resp.StatusCode)
+}
+
+func TestVotePatterns(t *testing.T) {
+	clock := TestClock{}
+	db, tearDown := FullStartTestServerClock("VotePatterns", 8088, "", &clock)
+	defer tearDown()
+
+	users, topics, nodesAndEdges, err := CreateTestData(db, &clock, 3, 1, 1)
+	require.Nil(t, err)
+
+	admin, ringA, ringB := users[0], users[1], users[2]
+	err = UpdateUserRoleAndReputation(db, ringA, false, 0)
+	require.Nil(t, err)
+	err = UpdateUserRoleAndReputation(db, ringB, false, 0)
+	require.Nil(t, err)
+
+	for i := 0; i < KeyRingVotes; i++ {
+		clock.TickOne(time.Hour)
+		err = db.Update(func(tx *bolt.Tx) error {
+			err := voteReputationTx(tx, &clock, openapi.VoteRecord{VoterId: ringA, CreatorId: ringB, Topic: topics[0], NodeId: nodesAndEdges[0].SourceId, Kind: KeyVoteBattle, Value: 1})
+			if err != nil {
+				return err
+			}
+
+			return voteReputationTx(tx, &clock, openapi.VoteRecord{VoterId: ringB, CreatorId: ringA, Topic: topics[0], NodeId: nodesAndEdges[1].SourceId, Kind: KeyVoteBattle, Value: 1})
+		})
+		require.Nil(t, err)
+	}
+
+	client := &http.Client{}
+
+	// only admins see the report
+	SetTestLoginUser(ringA)
+
+	req, _ := http.NewRequest(http.MethodGet, "http://127.0.0.1:8088/api/v1/admin/votePatterns?topicId="+url.QueryEscape(topics[0]), nil)
+
+	resp, err := client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 401, resp.StatusCode)
+
+	SetTestLoginUser(admin)
+
+	resp, err = client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 200, resp.StatusCode)
+
+	var patterns []openapi.VotePattern
+	err = json.NewDecoder(resp.Body).Decode(&patterns)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(patterns))
+	require.Equal(t, KeyPatternMutual, patterns[0].Kind)
+
+	var voteIds []string
+	for _, record := range patterns[0].Evidence {
+		voteIds = append(voteIds, record.Id)
+	}
+
+	marshal, err := json.Marshal(openapi.VoteVoid{VoteIds: voteIds, Reason: "vote ring"})
+	require.Nil(t, err)
+
+	req, _ = http.NewRequest(http.MethodPost, "http://127.0.0.1:8088/api/v1/admin/votePatterns/void", bytes.NewBuffer(marshal))
+
+	resp, err = client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 200, resp.StatusCode)
+
+	user, err := getUser(db, ringB)
+	require.Nil(t, err)
+	require.Equal(t, int32(0), user.Reputation)
+}
+
+func TestAdminActions(t *testing.T) {
+	clock := TestClock{}
+	db, dbTearDown := OpenTestDB("AdminActions")
+	defer dbTearDown()
+
+	users, topics, _, err := CreateTestData(db, &clock, 1, 1, 0)
+	require.Nil(t, err)
+
+	err = UpdateUserRoleAndReputation(db, users[0], false, KeyReputationEditor)
+	require.Nil(t, err)
+
+	// handing out the report leaves voiding with the admins
+	policy := DefaultPolicy()
+	policy[KeyActionVotePatterns] = PolicyRule{Reputation: KeyReputationEditor}
+	service := NewAdminAPIServiceImpl(db, &clock, policy)
+	ctx := context.WithValue(context.Background(), userInfoKey, token.User{ID: users[0]})
+
+	response, err := service.GetVotePatterns(ctx, topics[0])
+	require.Nil(t, err)
+	require.Equal(t, 200, response.Code)
+
+	response, _ = service.VoidVotes(ctx, openapi.VoteVoid{VoteIds: []string{"1"}, Reason: "ring"})
+	require.Equal(t, 401, response.Code)
+
+	response, _ = service.GetAudit(ctx, users[0])
+	require.Equal(t, 401, response.Code)
+
+	response, _ = service.UpdateUserReputation(ctx, users[0], openapi.ReputationAdjustment{Delta: 100, Reason: "self"})
+	require.Equal(t, 401, response.Code)
+}
@@ -2,27 +2,37 @@ package main
 
 import (
 	"context"
+	"net/url"
 
 	openapi "github.com/SpyLime/flowBackend/go"
 	bolt "go.etcd.io/bbolt"
 )
 
 type AllAPIServiceImpl struct {
-	db    *bolt.DB
-	clock Clock
+	db       *bolt.DB
+	clock    Clock
+	metadata *MetadataService
 }
 
-func NewAllAPIServiceImpl(db *bolt.DB, clock Clock) openapi.AllAPIServicer {
+func NewAllAPIServiceImpl(db *bolt.DB, clock Clock, metadata *MetadataService) openapi.AllAPIServicer {
 	return &AllAPIServiceImpl{
-		db:    db,
-		clock: clock,
+		db:       db,
+		clock:    clock,
+		metadata: metadata,
 	}
 }
 
+// ClipImage returns the title of the clip, it is only fetched again once the kept one is stale
 func (s *AllAPIServiceImpl) ClipImage(ctx context.Context, clipUrl string) (openapi.ImplResponse, error) {
-	info, err := fetchClipTitle(clipUrl)
+	link, err := url.PathUnescape(clipUrl)
 	if err != nil {
 		return openapi.Response(400, nil), err
 	}
-	return openapi.Response(200, info), nil
+
+	metadata, err := s.metadata.Lookup(ctx, link)
+	if err != nil {
+		return openapi.Response(400, nil), err
+	}
+
+	return openapi.Response(200, metadata.Title), nil
 }
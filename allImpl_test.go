@@ -1,25 +0,0 @@
-package main
-
-import (
-	"testing"
-)
-
-// only run locally
-func TestFetchClipTitle(t *testing.T) {
-	// clipURL := "https://youtube.com/clip/UgkxbG8R2t72qb0l7FWcaBW9r-hU3kGPf-ms"
-
-	// title, err := fetchClipTitle(clipURL)
-	// if err != nil {
-	// 	t.Fatalf("unexpected error: %v", err)
-	// }
-
-	// // Simple validation: title should not be empty and should contain a known part of the clip ID
-	// if title == "" {
-	// 	t.Fatalf("got empty title, expected non-empty")
-	// }
-	// if !strings.Contains(title, "clip") && !strings.Contains(title, "Clip") {
-	// 	t.Logf("Note: title does not contain 'clip'; actual title: %q", title)
-	// }
-
-	// t.Logf("Clip title: %s", title)
-}
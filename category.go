@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"errors"
+
+	openapi "github.com/SpyLime/flowBackend/go"
+	"github.com/go-pkgz/auth/token"
+	bolt "go.etcd.io/bbolt"
+)
+
+// CategoryAPIServiceImpl is a service that implements the logic for the CategoryAPIServicer
+// This service should implement the business logic for every endpoint for the CategoryAPI API.
+// Include any external packages or services that will be required by this service.
+type CategoryAPIServiceImpl struct {
+	db    *bolt.DB
+	clock Clock
+}
+
+// NewCategoryAPIService creates a default api service
+func NewCategoryAPIServiceImpl(db *bolt.DB, clock Clock) openapi.CategoryAPIServicer {
+	return &CategoryAPIServiceImpl{
+		db:    db,
+		clock: clock,
+	}
+}
+
+// GetCategories - get category tree
+func (s *CategoryAPIServiceImpl) GetCategories(ctx context.Context) (openapi.ImplResponse, error) {
+	response, err := getCategories(s.db)
+	if err != nil {
+		return openapi.Response(404, nil), err
+	}
+
+	return openapi.Response(200, response), nil
+}
+
+// AddCategory - add a category
+func (s *CategoryAPIServiceImpl) AddCategory(ctx context.Context, category openapi.Category) (openapi.ImplResponse, error) {
+	err := s.checkCategoryManager(ctx)
+	if err != nil {
+		return openapi.Response(401, nil), err
+	}
+
+	response, err := postCategory(s.db, category)
+	if err != nil {
+		return openapi.Response(400, nil), err
+	}
+
+	return openapi.Response(200, response), nil
+}
+
+// UpdateCategory - rename or move a category
+func (s *CategoryAPIServiceImpl) UpdateCategory(ctx context.Context, category openapi.Category) (openapi.ImplResponse, error) {
+	err := s.checkCategoryManager(ctx)
+	if err != nil {
+		return openapi.Response(401, nil), err
+	}
+
+	response, err := updateCategory(s.db, category)
+	if err != nil {
+		return openapi.Response(400, nil), err
+	}
+
+	return openapi.Response(200, response), nil
+}
+
+// DeleteCategory - delete a category, its topics are kept
+func (s *CategoryAPIServiceImpl) DeleteCategory(ctx context.Context, categoryId string) (openapi.ImplResponse, error) {
+	err := s.checkCategoryManager(ctx)
+	if err != nil {
+		return openapi.Response(401, nil), err
+	}
+
+	err = deleteCategory(s.db, categoryId)
+	if err != nil {
+		return openapi.Response(400, nil), err
+	}
+
+	return openapi.Response(204, nil), nil
+}
+
+// GetCategoryTopics - get the topics in a category
+func (s *CategoryAPIServiceImpl) GetCategoryTopics(ctx context.Context, categoryId string, recursive bool) (openapi.ImplResponse, error) {
+	// nobody is logged in when there is no user, they only see public topics
+	user, _ := ctx.Value(userInfoKey).(token.User)
+
+	response, err := getCategoryTopics(s.db, categoryId, recursive, user.ID)
+	if err != nil {
+		return openapi.Response(404, nil), err
+	}
+
+	return openapi.Response(200, response), nil
+}
+
+// only admins and deleters can manage categories
+func (s *CategoryAPIServiceImpl) checkCategoryManager(ctx context.Context) error {
+	user, ok := ctx.Value(userInfoKey).(token.User)
+	if !ok {
+		return errors.New("unauthorized: user not found in context")
+	}
+
+	userDetails, err := getUser(s.db, user.ID)
+	if err != nil {
+		return err
+	}
+
+	if userDetails.Role != KeyAdmin && userDetails.Reputation < KeyReputationDeleter {
+		return errors.New("unauthorized: user is not an admin or has low reputation(Deleter)")
+	}
+
+	return nil
+}
@@ -0,0 +1,331 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	openapi "github.com/SpyLime/flowBackend/go"
+	bolt "go.etcd.io/bbolt"
+)
+
+func getCategories(db *bolt.DB) (response []openapi.Category, err error) {
+	err = db.View(func(tx *bolt.Tx) error {
+		response, err = getCategoriesRx(tx)
+		return err
+	})
+
+	return
+}
+
+// returns the categories as a tree, the roots are the categories without a parent
+func getCategoriesRx(tx *bolt.Tx) (response []openapi.Category, err error) {
+	categories, err := getAllCategoriesRx(tx)
+	if err != nil {
+		return
+	}
+
+	children := make(map[string][]openapi.Category)
+	for _, category := range categories {
+		children[category.ParentId] = append(children[category.ParentId], category)
+	}
+
+	response = buildCategoryTree(children, "")
+
+	return
+}
+
+func buildCategoryTree(children map[string][]openapi.Category, parentId string) []openapi.Category {
+	branch := make([]openapi.Category, 0)
+	for _, category := range children[parentId] {
+		category.Children = buildCategoryTree(children, category.Id)
+		if len(category.Children) == 0 {
+			category.Children = nil
+		}
+		branch = append(branch, category)
+	}
+
+	sort.Slice(branch, func(i, j int) bool {
+		return branch[i].Title < branch[j].Title
+	})
+
+	return branch
+}
+
+// returns every category without building the tree
+func getAllCategoriesRx(tx *bolt.Tx) (response []openapi.Category, err error) {
+	categoriesBucket := tx.Bucket([]byte(KeyCategories))
+	if categoriesBucket == nil {
+		return
+	}
+
+	c := categoriesBucket.Cursor()
+	for k, v := c.First(); k != nil; k, v = c.Next() {
+		var category openapi.Category
+		err = json.Unmarshal(v, &category)
+		if err != nil {
+			return
+		}
+
+		category.Id = string(k)
+		response = append(response, category)
+	}
+
+	return
+}
+
+func getCategoryRx(tx *bolt.Tx, categoryId string) (category openapi.Category, err error) {
+	categoriesBucket := tx.Bucket([]byte(KeyCategories))
+	if categoriesBucket == nil {
+		return category, fmt.Errorf("can't find categories bucket")
+	}
+
+	categoryData := categoriesBucket.Get([]byte(categoryId))
+	if categoryData == nil {
+		return category, fmt.Errorf("can't find category %s", categoryId)
+	}
+
+	err = json.Unmarshal(categoryData, &category)
+	category.Id = categoryId
+
+	return
+}
+
+// returns an error if any of the categories do not exist
+func categoriesExistRx(tx *bolt.Tx, categoryIds []string) (err error) {
+	for _, categoryId := range categoryIds {
+		_, err = getCategoryRx(tx, categoryId)
+		if err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+func postCategory(db *bolt.DB, category openapi.Category) (response openapi.Category, err error) {
+	err = db.Update(func(tx *bolt.Tx) error {
+		response, err = postCategoryTx(tx, category)
+		return err
+	})
+
+	return
+}
+
+// supply a title and optionally the id of the parent category
+func postCategoryTx(tx *bolt.Tx, category openapi.Category) (response openapi.Category, err error) {
+	category.Title = strings.TrimSpace(category.Title)
+	if category.Title == "" {
+		return response, fmt.Errorf("a category needs a title")
+	}
+
+	categoriesBucket, err := tx.CreateBucketIfNotExists([]byte(KeyCategories))
+	if err != nil {
+		return
+	}
+
+	if category.ParentId != "" {
+		_, err = getCategoryRx(tx, category.ParentId)
+		if err != nil {
+			return
+		}
+	}
+
+	id := RandomString(8)
+	for categoriesBucket.Get([]byte(id)) != nil {
+		id = RandomString(8)
+	}
+
+	response = openapi.Category{
+		Id:       id,
+		Title:    category.Title,
+		ParentId: category.ParentId,
+	}
+
+	err = putCategoryTx(categoriesBucket, response)
+
+	return
+}
+
+func putCategoryTx(categoriesBucket *bolt.Bucket, category openapi.Category) (err error) {
+	id := category.Id
+	category.Id = ""
+	category.Children = nil
+
+	marshal, err := json.Marshal(category)
+	if err != nil {
+		return
+	}
+
+	return categoriesBucket.Put([]byte(id), marshal)
+}
+
+func updateCategory(db *bolt.DB, category openapi.Category) (response openapi.Category, err error) {
+	err = db.Update(func(tx *bolt.Tx) error {
+		response, err = updateCategoryTx(tx, category)
+		return err
+	})
+
+	return
+}
+
+// changes the title and parent of a category
+func updateCategoryTx(tx *bolt.Tx, category openapi.Category) (response openapi.Category, err error) {
+	category.Title = strings.TrimSpace(category.Title)
+	if category.Title == "" {
+		return response, fmt.Errorf("a category needs a title")
+	}
+
+	response, err = getCategoryRx(tx, category.Id)
+	if err != nil {
+		return
+	}
+
+	// walk up from the new parent to make sure the category is not moved under itself
+	for parentId := category.ParentId; parentId != ""; {
+		if parentId == category.Id {
+			return response, fmt.Errorf("a category can't be moved under itself")
+		}
+
+		parent, err := getCategoryRx(tx, parentId)
+		if err != nil {
+			return response, err
+		}
+
+		parentId = parent.ParentId
+	}
+
+	response.Title = category.Title
+	response.ParentId = category.ParentId
+
+	err = putCategoryTx(tx.Bucket([]byte(KeyCategories)), response)
+
+	return
+}
+
+func deleteCategory(db *bolt.DB, categoryId string) (err error) {
+	err = db.Update(func(tx *bolt.Tx) error {
+		err = deleteCategoryTx(tx, categoryId)
+		return err
+	})
+
+	return
+}
+
+// deletes the category but never its topics
+//
+// children of the category move up to its parent and topics are unassigned from it
+func deleteCategoryTx(tx *bolt.Tx, categoryId string) (err error) {
+	category, err := getCategoryRx(tx, categoryId)
+	if err != nil {
+		return
+	}
+
+	categoriesBucket := tx.Bucket([]byte(KeyCategories))
+
+	categories, err := getAllCategoriesRx(tx)
+	if err != nil {
+		return
+	}
+
+	for _, child := range categories {
+		if child.ParentId != categoryId {
+			continue
+		}
+
+		child.ParentId = category.ParentId
+		err = putCategoryTx(categoriesBucket, child)
+		if err != nil {
+			return
+		}
+	}
+
+	topicsBucket := tx.Bucket([]byte(KeyTopics))
+	if topicsBucket != nil {
+		c := topicsBucket.Cursor()
+		for k, _ := c.First(); k != nil; k, _ = c.Next() {
+			info, err := getTopicInfoRx(tx, string(k))
+			if err != nil {
+				return err
+			}
+
+			if !contains(info.Categories, categoryId) {
+				continue
+			}
+
+			remaining := make([]string, 0, len(info.Categories))
+			for _, id := range info.Categories {
+				if id != categoryId {
+					remaining = append(remaining, id)
+				}
+			}
+			info.Categories = remaining
+
+			err = putTopicInfoTx(tx, info)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return categoriesBucket.Delete([]byte(categoryId))
+}
+
+func getCategoryTopics(db *bolt.DB, categoryId string, recursive bool, viewerId string) (response []openapi.GetTopics200ResponseInner, err error) {
+	err = db.View(func(tx *bolt.Tx) error {
+		response, err = getCategoryTopicsRx(tx, categoryId, recursive, viewerId)
+		return err
+	})
+
+	return
+}
+
+// returns the topics assigned to a category
+//
+// when recursive is true topics assigned to any category below it are included
+//
+// topics the viewer can't see are left out
+func getCategoryTopicsRx(tx *bolt.Tx, categoryId string, recursive bool, viewerId string) (response []openapi.GetTopics200ResponseInner, err error) {
+	response = []openapi.GetTopics200ResponseInner{}
+
+	_, err = getCategoryRx(tx, categoryId)
+	if err != nil {
+		return
+	}
+
+	wanted := map[string]bool{categoryId: true}
+	if recursive {
+		categories, err := getAllCategoriesRx(tx)
+		if err != nil {
+			return response, err
+		}
+
+		// keep sweeping until no new descendants are found
+		for found := true; found; {
+			found = false
+			for _, category := range categories {
+				if wanted[category.ParentId] && !wanted[category.Id] {
+					wanted[category.Id] = true
+					found = true
+				}
+			}
+		}
+	}
+
+	topics, err := getVisibleTopicsRx(tx, viewerId)
+	if err != nil {
+		return
+	}
+
+	for _, topic := range topics {
+		for _, id := range topic.Categories {
+			if wanted[id] {
+				response = append(response, topic)
+				break
+			}
+		}
+	}
+
+	return
+}
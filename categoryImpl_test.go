@@ -0,0 +1,170 @@
+package main
+
+import (
+	"testing"
+
+	openapi "github.com/SpyLime/flowBackend/go"
+	"github.com/go-pkgz/lgr"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostGetCategories(t *testing.T) {
+
+	lgr.Printf("INFO TestPostGetCategories")
+	t.Log("INFO TestPostGetCategories")
+	db, dbTearDown := OpenTestDB("PostGetCategories")
+	defer dbTearDown()
+
+	programming, err := postCategory(db, openapi.Category{Title: "Programming"})
+	require.Nil(t, err)
+	require.NotEmpty(t, programming.Id)
+
+	golang, err := postCategory(db, openapi.Category{Title: "Go", ParentId: programming.Id})
+	require.Nil(t, err)
+
+	_, err = postCategory(db, openapi.Category{Title: "Concurrency", ParentId: golang.Id})
+	require.Nil(t, err)
+
+	_, err = postCategory(db, openapi.Category{Title: "Art"})
+	require.Nil(t, err)
+
+	_, err = postCategory(db, openapi.Category{Title: "Orphan", ParentId: "missing"})
+	require.NotNil(t, err)
+
+	_, err = postCategory(db, openapi.Category{Title: "  "})
+	require.NotNil(t, err)
+
+	tree, err := getCategories(db)
+	require.Nil(t, err)
+
+	require.Equal(t, 2, len(tree))
+	require.Equal(t, "Art", tree[0].Title)
+	require.Equal(t, "Programming", tree[1].Title)
+	require.Equal(t, "Go", tree[1].Children[0].Title)
+	require.Equal(t, "Concurrency", tree[1].Children[0].Children[0].Title)
+}
+
+func TestUpdateCategoryImpl(t *testing.T) {
+
+	lgr.Printf("INFO TestUpdateCategoryImpl")
+	t.Log("INFO TestUpdateCategoryImpl")
+	db, dbTearDown := OpenTestDB("UpdateCategoryImpl")
+	defer dbTearDown()
+
+	parent, err := postCategory(db, openapi.Category{Title: "Parent"})
+	require.Nil(t, err)
+
+	child, err := postCategory(db, openapi.Category{Title: "Child", ParentId: parent.Id})
+	require.Nil(t, err)
+
+	// a category can't be moved below one of its own children
+	_, err = updateCategory(db, openapi.Category{Id: parent.Id, Title: "Parent", ParentId: child.Id})
+	require.NotNil(t, err)
+
+	_, err = updateCategory(db, openapi.Category{Id: parent.Id, Title: "Parent", ParentId: parent.Id})
+	require.NotNil(t, err)
+
+	_, err = updateCategory(db, openapi.Category{Id: child.Id, Title: " ", ParentId: parent.Id})
+	require.NotNil(t, err)
+
+	updated, err := updateCategory(db, openapi.Category{Id: child.Id, Title: " Renamed "})
+	require.Nil(t, err)
+	require.Equal(t, "Renamed", updated.Title)
+
+	tree, err := getCategories(db)
+	require.Nil(t, err)
+	require.Equal(t, 2, len(tree))
+}
+
+func TestDeleteCategoryImpl(t *testing.T) {
+
+	lgr.Printf("INFO TestDeleteCategoryImpl")
+	t.Log("INFO TestDeleteCategoryImpl")
+	clock := TestClock{}
+	db, dbTearDown := OpenTestDB("DeleteCategoryImpl")
+	defer dbTearDown()
+
+	users, topics, _, err := CreateTestData(db, &clock, 1, 1, 0)
+	require.Nil(t, err)
+
+	root, err := postCategory(db, openapi.Category{Title: "Root"})
+	require.Nil(t, err)
+
+	middle, err := postCategory(db, openapi.Category{Title: "Middle", ParentId: root.Id})
+	require.Nil(t, err)
+
+	leaf, err := postCategory(db, openapi.Category{Title: "Leaf", ParentId: middle.Id})
+	require.Nil(t, err)
+
+	err = updateTopic(db, openapi.Topic{Title: topics[0], Categories: []string{middle.Id}})
+	require.Nil(t, err)
+
+	user, err := getUser(db, users[0])
+	require.Nil(t, err)
+
+	_, err = postTopic(db, &clock, openapi.Topic{Title: "leafTopic", Categories: []string{leaf.Id}}, user)
+	require.Nil(t, err)
+
+	err = deleteCategory(db, middle.Id)
+	require.Nil(t, err)
+
+	// the leaf moves up to the root and no topics are lost
+	tree, err := getCategories(db)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(tree))
+	require.Equal(t, leaf.Id, tree[0].Children[0].Id)
+
+	allTopics, err := getTopics(db)
+	require.Nil(t, err)
+	require.Equal(t, 2, len(allTopics))
+
+	for _, topic := range allTopics {
+		require.NotContains(t, topic.Categories, middle.Id)
+	}
+
+	rootTopics, err := getCategoryTopics(db, root.Id, true, "")
+	require.Nil(t, err)
+	require.Equal(t, 1, len(rootTopics))
+	require.Equal(t, "leafTopic", rootTopics[0].Title)
+}
+
+func TestGetCategoryTopicsImpl(t *testing.T) {
+
+	lgr.Printf("INFO TestGetCategoryTopicsImpl")
+	t.Log("INFO TestGetCategoryTopicsImpl")
+	clock := TestClock{}
+	db, dbTearDown := OpenTestDB("GetCategoryTopicsImpl")
+	defer dbTearDown()
+
+	_, topics, _, err := CreateTestData(db, &clock, 1, 3, 0)
+	require.Nil(t, err)
+
+	parent, err := postCategory(db, openapi.Category{Title: "Parent"})
+	require.Nil(t, err)
+
+	child, err := postCategory(db, openapi.Category{Title: "Child", ParentId: parent.Id})
+	require.Nil(t, err)
+
+	err = updateTopic(db, openapi.Topic{Title: topics[0], Categories: []string{parent.Id}})
+	require.Nil(t, err)
+
+	err = updateTopic(db, openapi.Topic{Title: topics[1], Categories: []string{child.Id, parent.Id}})
+	require.Nil(t, err)
+
+	err = updateTopic(db, openapi.Topic{Title: topics[2], Categories: []string{child.Id}})
+	require.Nil(t, err)
+
+	err = updateTopic(db, openapi.Topic{Title: topics[2], Categories: []string{"missing"}})
+	require.NotNil(t, err)
+
+	direct, err := getCategoryTopics(db, parent.Id, false, "")
+	require.Nil(t, err)
+	require.Equal(t, 2, len(direct))
+
+	recursive, err := getCategoryTopics(db, parent.Id, true, "")
+	require.Nil(t, err)
+	require.Equal(t, 3, len(recursive))
+
+	_, err = getCategoryTopics(db, "missing", false, "")
+	require.NotNil(t, err)
+}
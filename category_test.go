@@ -0,0 +1,112 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	openapi "github.com/SpyLime/flowBackend/go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddGetCategories(t *testing.T) {
+	clock := TestClock{}
+	db, tearDown := FullStartTestServer("AddGetCategories", 8088, "")
+	defer tearDown()
+
+	users, _, _, err := CreateTestData(db, &clock, 1, 0, 0)
+	require.Nil(t, err)
+
+	err = UpdateUserRoleAndReputation(db, users[0], false, 0)
+	require.Nil(t, err)
+	SetTestLoginUser(users[0])
+
+	client := &http.Client{}
+
+	marshal, err := json.Marshal(openapi.Category{Title: "Programming"})
+	require.Nil(t, err)
+
+	req, _ := http.NewRequest(http.MethodPost, "http://127.0.0.1:8088/api/v1/category", bytes.NewBuffer(marshal))
+
+	resp, err := client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 401, resp.StatusCode)
+
+	err = UpdateUserRoleAndReputation(db, users[0], false, KeyReputationDeleter)
+	require.Nil(t, err)
+
+	req, _ = http.NewRequest(http.MethodPost, "http://127.0.0.1:8088/api/v1/category", bytes.NewBuffer(marshal))
+
+	resp, err = client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 200, resp.StatusCode)
+
+	var category openapi.Category
+	decoder := json.NewDecoder(resp.Body)
+	err = decoder.Decode(&category)
+	require.Nil(t, err)
+	require.NotEmpty(t, category.Id)
+
+	req, _ = http.NewRequest(http.MethodGet, "http://127.0.0.1:8088/api/v1/category", nil)
+
+	resp, err = client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 200, resp.StatusCode)
+
+	var data []openapi.Category
+	decoder = json.NewDecoder(resp.Body)
+	_ = decoder.Decode(&data)
+
+	require.Equal(t, 1, len(data))
+	require.Equal(t, "Programming", data[0].Title)
+}
+
+func TestDeleteCategory(t *testing.T) {
+	clock := TestClock{}
+	db, tearDown := FullStartTestServer("DeleteCategory", 8088, "")
+	defer tearDown()
+
+	users, topics, _, err := CreateTestData(db, &clock, 1, 1, 0)
+	require.Nil(t, err)
+
+	err = UpdateUserRoleAndReputation(db, users[0], true, 0)
+	require.Nil(t, err)
+	SetTestLoginUser(users[0])
+
+	category, err := postCategory(db, openapi.Category{Title: "Programming"})
+	require.Nil(t, err)
+
+	err = updateTopic(db, openapi.Topic{Title: topics[0], Categories: []string{category.Id}})
+	require.Nil(t, err)
+
+	client := &http.Client{}
+
+	req, _ := http.NewRequest(http.MethodGet, "http://127.0.0.1:8088/api/v1/category/"+category.Id+"/topics?recursive=true", nil)
+
+	resp, err := client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 200, resp.StatusCode)
+
+	var data []openapi.Topic
+	decoder := json.NewDecoder(resp.Body)
+	_ = decoder.Decode(&data)
+	require.Equal(t, 1, len(data))
+	require.Equal(t, topics[0], data[0].Title)
+
+	req, _ = http.NewRequest(http.MethodDelete, "http://127.0.0.1:8088/api/v1/category/"+category.Id, nil)
+
+	resp, err = client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 204, resp.StatusCode)
+
+	remaining, err := getTopics(db)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(remaining))
+	require.Empty(t, remaining[0].Categories)
+}
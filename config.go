@@ -2,6 +2,7 @@ package main
 
 import (
 	"os"
+	"time"
 
 	"github.com/go-pkgz/lgr"
 	"gopkg.in/yaml.v3"
@@ -9,17 +10,136 @@ import (
 
 // ServerConfig represents the server configuration
 type ServerConfig struct {
-	AdminPassword string          `yaml:"adminpassword"`
-	SecureCookies bool            `yaml:"securecookies"`
-	EnableXSRF    bool            `yaml:"enablexsrf"`
-	SecretKey     string          `yaml:"secretkey"`
-	ServerPort    int             `yaml:"serverport"`
-	SeedPassword  string          `yaml:"seedpassword"`
-	EmailSMTP     string          `yaml:"emailsmtp"`
-	PasswordSMTP  string          `yaml:"passwordsmtp"`
-	Server        bool            `yaml:"server"` // server is true if the server is running on the server
-	Production    bool            `yaml:"production"`
-	Providers     ProvidersConfig `yaml:"providers"`
+	AdminPassword string           `yaml:"adminpassword"`
+	SecureCookies bool             `yaml:"securecookies"`
+	EnableXSRF    bool             `yaml:"enablexsrf"`
+	SecretKey     string           `yaml:"secretkey"`
+	ServerPort    int              `yaml:"serverport"`
+	SeedPassword  string           `yaml:"seedpassword"`
+	EmailSMTP     string           `yaml:"emailsmtp"`
+	PasswordSMTP  string           `yaml:"passwordsmtp"`
+	Server        bool             `yaml:"server"` // server is true if the server is running on the server
+	Production    bool             `yaml:"production"`
+	Providers     ProvidersConfig  `yaml:"providers"`
+	Policy        Policy           `yaml:"policy"` // actions listed here replace their default rule
+	Moderation    ModerationConfig `yaml:"moderation"`
+	RateLimit     RateLimitConfig  `yaml:"ratelimit"`
+	Metadata      MetadataConfig   `yaml:"metadata"`
+	Outbound      OutboundConfig   `yaml:"outbound"`
+	LinkHealth    LinkHealthConfig `yaml:"linkhealth"`
+	Watch         WatchConfig      `yaml:"watch"`
+	PeerTube      PeerTubeConfig   `yaml:"peertube"`
+}
+
+// ModerationConfig sets when content is hidden automatically, a zero threshold turns it off
+type ModerationConfig struct {
+	HideReporters  int   `yaml:"hidereporters"`  // people reporting the same node or video
+	HideNodeVotes  int32 `yaml:"hidenodevotes"`  // battle tested score at or below this
+	HideVideoVotes int32 `yaml:"hidevideovotes"` // video votes at or below this
+}
+
+// DefaultModerationConfig is used when flcfg.yml has no moderation section
+func DefaultModerationConfig() ModerationConfig {
+	return ModerationConfig{
+		HideReporters:  3,
+		HideNodeVotes:  -10,
+		HideVideoVotes: -5,
+	}
+}
+
+// RateLimitConfig sets how fast users can vote, edit and create content, classes listed here replace their default
+type RateLimitConfig struct {
+	TrustProxy bool                 `yaml:"trustproxy"` // take the ip the proxy adds last to X-Forwarded-For
+	Classes    map[string]RateLimit `yaml:"classes"`
+}
+
+// RateLimit is a token bucket per user and per ip, one token comes back every interval and a zero size turns it off
+type RateLimit struct {
+	Every time.Duration `yaml:"every"`
+	User  int           `yaml:"user"`
+	IP    int           `yaml:"ip"` // several students share the ip of their school so keep it above user
+}
+
+// DefaultRateLimitConfig is used for every class flcfg.yml doesn't mention
+func DefaultRateLimitConfig() RateLimitConfig {
+	return RateLimitConfig{
+		Classes: map[string]RateLimit{
+			KeyRateVote:   {Every: 2 * time.Second, User: 30, IP: 200},
+			KeyRateEdit:   {Every: 20 * time.Second, User: 15, IP: 100},
+			KeyRateCreate: {Every: time.Minute, User: 10, IP: 60},
+		},
+	}
+}
+
+// MetadataConfig sets how the title, channel, duration and thumbnail of resources are fetched
+type MetadataConfig struct {
+	Disabled bool          `yaml:"disabled"` // only metadata that is already kept is used
+	TTL      time.Duration `yaml:"ttl"`      // metadata is fetched again once it is this old
+}
+
+// DefaultMetadataConfig is used when flcfg.yml has no metadata section
+func DefaultMetadataConfig() MetadataConfig {
+	return MetadataConfig{
+		TTL: 7 * 24 * time.Hour,
+	}
+}
+
+// OutboundConfig sets what the server fetches from other sites for its users
+type OutboundConfig struct {
+	AllowHosts   []string      `yaml:"allowhosts"`   // a host also allows its subdomains, * allows every public host. Links elsewhere are never checked
+	Timeout      time.Duration `yaml:"timeout"`      // for each request including reading the answer
+	MaxBytes     int64         `yaml:"maxbytes"`     // of an answer that is read, the head of a page is enough
+	MaxRedirects int           `yaml:"maxredirects"` // every redirect is checked like the first request
+}
+
+// DefaultOutboundConfig only reaches YouTube, add the sites teachers link to in flcfg.yml
+func DefaultOutboundConfig() OutboundConfig {
+	return OutboundConfig{
+		AllowHosts:   []string{"youtube.com", "youtu.be", "youtube-nocookie.com"},
+		Timeout:      5 * time.Second,
+		MaxBytes:     1 << 20,
+		MaxRedirects: 5,
+	}
+}
+
+// LinkHealthConfig sets how often the links of nodes are checked and what happens to dead ones
+type LinkHealthConfig struct {
+	Disabled  bool          `yaml:"disabled"`
+	Interval  time.Duration `yaml:"interval"`  // a link is checked again once its last check is this old, the default when not positive
+	HideAfter time.Duration `yaml:"hideafter"` // links dead for this long are hidden, 0 never hides them
+}
+
+// DefaultLinkHealthConfig is used when flcfg.yml has no linkhealth section
+func DefaultLinkHealthConfig() LinkHealthConfig {
+	return LinkHealthConfig{
+		Interval: 24 * time.Hour,
+	}
+}
+
+// WatchConfig sets when a video counts as finished and a node as complete, both are percents
+type WatchConfig struct {
+	FinishAt   float32 `yaml:"finishat"`   // of a video watched
+	CompleteAt float32 `yaml:"completeat"` // of the videos of a node finished
+}
+
+// DefaultWatchConfig is used when flcfg.yml has no watch section
+func DefaultWatchConfig() WatchConfig {
+	return WatchConfig{
+		FinishAt:   90,
+		CompleteAt: 80,
+	}
+}
+
+// PeerTubeConfig lists the PeerTube instances whose links are read as videos, /w/ links on other hosts stay articles
+type PeerTubeConfig struct {
+	Hosts []string `yaml:"hosts"`
+}
+
+// DefaultPeerTubeConfig knows a few public instances, add the one your school runs in flcfg.yml
+func DefaultPeerTubeConfig() PeerTubeConfig {
+	return PeerTubeConfig{
+		Hosts: []string{"framatube.org", "tilvids.com", "video.blender.org"},
+	}
 }
 
 // LoadConfig loads the server configuration from the YAML file
@@ -32,6 +152,14 @@ func LoadConfig() ServerConfig {
 		EmailSMTP:     "qq@qq.com",
 		PasswordSMTP:  "123qwe",
 		Production:    false,
+		Policy:        DefaultPolicy(),
+		Moderation:    DefaultModerationConfig(),
+		RateLimit:     DefaultRateLimitConfig(),
+		Metadata:      DefaultMetadataConfig(),
+		Outbound:      DefaultOutboundConfig(),
+		LinkHealth:    DefaultLinkHealthConfig(),
+		Watch:         DefaultWatchConfig(),
+		PeerTube:      DefaultPeerTubeConfig(),
 	}
 
 	yamlFile, err := os.ReadFile("./flcfg.yml")
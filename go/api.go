@@ -18,12 +18,43 @@ import (
 
 
 
+// AdminAPIRouter defines the required methods for binding the api requests to a responses for the AdminAPI
+// The AdminAPIRouter implementation should parse necessary information from the http request,
+// pass the data to a AdminAPIServicer to perform the required actions, then write the service results to the http response.
+type AdminAPIRouter interface { 
+	GetSanction(http.ResponseWriter, *http.Request)
+	UpdateSanction(http.ResponseWriter, *http.Request)
+	DeleteSanction(http.ResponseWriter, *http.Request)
+	GetAudit(http.ResponseWriter, *http.Request)
+	GetVotePatterns(http.ResponseWriter, *http.Request)
+	VoidVotes(http.ResponseWriter, *http.Request)
+	UpdateUserReputation(http.ResponseWriter, *http.Request)
+}
 // AllAPIRouter defines the required methods for binding the api requests to a responses for the AllAPI
 // The AllAPIRouter implementation should parse necessary information from the http request,
 // pass the data to a AllAPIServicer to perform the required actions, then write the service results to the http response.
 type AllAPIRouter interface { 
 	ClipImage(http.ResponseWriter, *http.Request)
 }
+// CategoryAPIRouter defines the required methods for binding the api requests to a responses for the CategoryAPI
+// The CategoryAPIRouter implementation should parse necessary information from the http request,
+// pass the data to a CategoryAPIServicer to perform the required actions, then write the service results to the http response.
+type CategoryAPIRouter interface { 
+	GetCategories(http.ResponseWriter, *http.Request)
+	AddCategory(http.ResponseWriter, *http.Request)
+	UpdateCategory(http.ResponseWriter, *http.Request)
+	DeleteCategory(http.ResponseWriter, *http.Request)
+	GetCategoryTopics(http.ResponseWriter, *http.Request)
+}
+// GroupAPIRouter defines the required methods for binding the api requests to a responses for the GroupAPI
+// The GroupAPIRouter implementation should parse necessary information from the http request,
+// pass the data to a GroupAPIServicer to perform the required actions, then write the service results to the http response.
+type GroupAPIRouter interface { 
+	GetGroups(http.ResponseWriter, *http.Request)
+	AddGroup(http.ResponseWriter, *http.Request)
+	UpdateGroup(http.ResponseWriter, *http.Request)
+	DeleteGroup(http.ResponseWriter, *http.Request)
+}
 // MapAPIRouter defines the required methods for binding the api requests to a responses for the MapAPI
 // The MapAPIRouter implementation should parse necessary information from the http request,
 // pass the data to a MapAPIServicer to perform the required actions, then write the service results to the http response.
@@ -32,6 +63,16 @@ type MapAPIRouter interface {
 	AddEdge(http.ResponseWriter, *http.Request)
 	DeleteEdge(http.ResponseWriter, *http.Request)
 }
+// ModerationAPIRouter defines the required methods for binding the api requests to a responses for the ModerationAPI
+// The ModerationAPIRouter implementation should parse necessary information from the http request,
+// pass the data to a ModerationAPIServicer to perform the required actions, then write the service results to the http response.
+type ModerationAPIRouter interface { 
+	GetModerationQueue(http.ResponseWriter, *http.Request)
+	ReportNode(http.ResponseWriter, *http.Request)
+	AssignModerationCase(http.ResponseWriter, *http.Request)
+	ResolveModerationCase(http.ResponseWriter, *http.Request)
+	DismissModerationCase(http.ResponseWriter, *http.Request)
+}
 // NodeAPIRouter defines the required methods for binding the api requests to a responses for the NodeAPI
 // The NodeAPIRouter implementation should parse necessary information from the http request,
 // pass the data to a NodeAPIServicer to perform the required actions, then write the service results to the http response.
@@ -48,6 +89,29 @@ type NodeAPIRouter interface {
 	UpdateNodeFreshVote(http.ResponseWriter, *http.Request)
 	UpdateNodeFlag(http.ResponseWriter, *http.Request)
 }
+// OrganizationAPIRouter defines the required methods for binding the api requests to a responses for the OrganizationAPI
+// The OrganizationAPIRouter implementation should parse necessary information from the http request,
+// pass the data to a OrganizationAPIServicer to perform the required actions, then write the service results to the http response.
+type OrganizationAPIRouter interface { 
+	GetOrganizations(http.ResponseWriter, *http.Request)
+	UpdateOrganizationMember(http.ResponseWriter, *http.Request)
+	DeleteOrganizationMember(http.ResponseWriter, *http.Request)
+}
+// PermissionAPIRouter defines the required methods for binding the api requests to a responses for the PermissionAPI
+// The PermissionAPIRouter implementation should parse necessary information from the http request,
+// pass the data to a PermissionAPIServicer to perform the required actions, then write the service results to the http response.
+type PermissionAPIRouter interface { 
+	GetPermissions(http.ResponseWriter, *http.Request)
+}
+// SuggestionAPIRouter defines the required methods for binding the api requests to a responses for the SuggestionAPI
+// The SuggestionAPIRouter implementation should parse necessary information from the http request,
+// pass the data to a SuggestionAPIServicer to perform the required actions, then write the service results to the http response.
+type SuggestionAPIRouter interface { 
+	GetSuggestions(http.ResponseWriter, *http.Request)
+	AddSuggestion(http.ResponseWriter, *http.Request)
+	AcceptSuggestion(http.ResponseWriter, *http.Request)
+	RejectSuggestion(http.ResponseWriter, *http.Request)
+}
 // TopicAPIRouter defines the required methods for binding the api requests to a responses for the TopicAPI
 // The TopicAPIRouter implementation should parse necessary information from the http request,
 // pass the data to a TopicAPIServicer to perform the required actions, then write the service results to the http response.
@@ -56,6 +120,14 @@ type TopicAPIRouter interface {
 	UpdateTopic(http.ResponseWriter, *http.Request)
 	AddTopic(http.ResponseWriter, *http.Request)
 	DeleteTopic(http.ResponseWriter, *http.Request)
+	GetTopicRoles(http.ResponseWriter, *http.Request)
+	UpdateTopicRole(http.ResponseWriter, *http.Request)
+	DeleteTopicRole(http.ResponseWriter, *http.Request)
+	UpdateTopicLock(http.ResponseWriter, *http.Request)
+	DeleteTopicLock(http.ResponseWriter, *http.Request)
+	GetTopicDeadLinks(http.ResponseWriter, *http.Request)
+	GetTopicSharedVideos(http.ResponseWriter, *http.Request)
+	ImportTopicPlaylist(http.ResponseWriter, *http.Request)
 }
 // UserAPIRouter defines the required methods for binding the api requests to a responses for the UserAPI
 // The UserAPIRouter implementation should parse necessary information from the http request,
@@ -65,6 +137,27 @@ type UserAPIRouter interface {
 	UpdateUser(http.ResponseWriter, *http.Request)
 	GetUserByName(http.ResponseWriter, *http.Request)
 	DeleteUser(http.ResponseWriter, *http.Request)
+	GetUserReputation(http.ResponseWriter, *http.Request)
+	GetUserNotifications(http.ResponseWriter, *http.Request)
+	UpdateUserNotificationsRead(http.ResponseWriter, *http.Request)
+	GetUserWatches(http.ResponseWriter, *http.Request)
+	UpdateUserWatch(http.ResponseWriter, *http.Request)
+	GetLeaderboard(http.ResponseWriter, *http.Request)
+}
+
+
+// AdminAPIServicer defines the api actions for the AdminAPI service
+// This interface intended to stay up to date with the openapi yaml used to generate it,
+// while the service implementation can be ignored with the .openapi-generator-ignore file
+// and updated with the logic required for the API.
+type AdminAPIServicer interface { 
+	GetSanction(context.Context, string) (ImplResponse, error)
+	UpdateSanction(context.Context, string, Sanction) (ImplResponse, error)
+	DeleteSanction(context.Context, string) (ImplResponse, error)
+	GetAudit(context.Context, string) (ImplResponse, error)
+	GetVotePatterns(context.Context, string) (ImplResponse, error)
+	VoidVotes(context.Context, VoteVoid) (ImplResponse, error)
+	UpdateUserReputation(context.Context, string, ReputationAdjustment) (ImplResponse, error)
 }
 
 
@@ -77,6 +170,31 @@ type AllAPIServicer interface {
 }
 
 
+// CategoryAPIServicer defines the api actions for the CategoryAPI service
+// This interface intended to stay up to date with the openapi yaml used to generate it,
+// while the service implementation can be ignored with the .openapi-generator-ignore file
+// and updated with the logic required for the API.
+type CategoryAPIServicer interface { 
+	GetCategories(context.Context) (ImplResponse, error)
+	AddCategory(context.Context, Category) (ImplResponse, error)
+	UpdateCategory(context.Context, Category) (ImplResponse, error)
+	DeleteCategory(context.Context, string) (ImplResponse, error)
+	GetCategoryTopics(context.Context, string, bool) (ImplResponse, error)
+}
+
+
+// GroupAPIServicer defines the api actions for the GroupAPI service
+// This interface intended to stay up to date with the openapi yaml used to generate it,
+// while the service implementation can be ignored with the .openapi-generator-ignore file
+// and updated with the logic required for the API.
+type GroupAPIServicer interface { 
+	GetGroups(context.Context) (ImplResponse, error)
+	AddGroup(context.Context, Group) (ImplResponse, error)
+	UpdateGroup(context.Context, Group) (ImplResponse, error)
+	DeleteGroup(context.Context, string) (ImplResponse, error)
+}
+
+
 // MapAPIServicer defines the api actions for the MapAPI service
 // This interface intended to stay up to date with the openapi yaml used to generate it,
 // while the service implementation can be ignored with the .openapi-generator-ignore file
@@ -88,12 +206,25 @@ type MapAPIServicer interface {
 }
 
 
+// ModerationAPIServicer defines the api actions for the ModerationAPI service
+// This interface intended to stay up to date with the openapi yaml used to generate it,
+// while the service implementation can be ignored with the .openapi-generator-ignore file
+// and updated with the logic required for the API.
+type ModerationAPIServicer interface { 
+	GetModerationQueue(context.Context, string) (ImplResponse, error)
+	ReportNode(context.Context, FlagReport) (ImplResponse, error)
+	AssignModerationCase(context.Context, string, ModerationAction) (ImplResponse, error)
+	ResolveModerationCase(context.Context, string, ModerationAction) (ImplResponse, error)
+	DismissModerationCase(context.Context, string, ModerationAction) (ImplResponse, error)
+}
+
+
 // NodeAPIServicer defines the api actions for the NodeAPI service
 // This interface intended to stay up to date with the openapi yaml used to generate it,
 // while the service implementation can be ignored with the .openapi-generator-ignore file
 // and updated with the logic required for the API.
 type NodeAPIServicer interface { 
-	GetNode(context.Context, string, string) (ImplResponse, error)
+	GetNode(context.Context, string, string, string) (ImplResponse, error)
 	AddNode(context.Context, NodeData) (ImplResponse, error)
 	DeleteNode(context.Context, string, string) (ImplResponse, error)
 	GetNodeNextBattleTested(context.Context, string, string) (ImplResponse, error)
@@ -107,6 +238,38 @@ type NodeAPIServicer interface {
 }
 
 
+// OrganizationAPIServicer defines the api actions for the OrganizationAPI service
+// This interface intended to stay up to date with the openapi yaml used to generate it,
+// while the service implementation can be ignored with the .openapi-generator-ignore file
+// and updated with the logic required for the API.
+type OrganizationAPIServicer interface { 
+	GetOrganizations(context.Context) (ImplResponse, error)
+	UpdateOrganizationMember(context.Context, string, OrganizationMember) (ImplResponse, error)
+	DeleteOrganizationMember(context.Context, string, string) (ImplResponse, error)
+}
+
+
+// PermissionAPIServicer defines the api actions for the PermissionAPI service
+// This interface intended to stay up to date with the openapi yaml used to generate it,
+// while the service implementation can be ignored with the .openapi-generator-ignore file
+// and updated with the logic required for the API.
+type PermissionAPIServicer interface { 
+	GetPermissions(context.Context, string, string, string) (ImplResponse, error)
+}
+
+
+// SuggestionAPIServicer defines the api actions for the SuggestionAPI service
+// This interface intended to stay up to date with the openapi yaml used to generate it,
+// while the service implementation can be ignored with the .openapi-generator-ignore file
+// and updated with the logic required for the API.
+type SuggestionAPIServicer interface { 
+	GetSuggestions(context.Context, string, string) (ImplResponse, error)
+	AddSuggestion(context.Context, Suggestion) (ImplResponse, error)
+	AcceptSuggestion(context.Context, string, SuggestionReview) (ImplResponse, error)
+	RejectSuggestion(context.Context, string, SuggestionReview) (ImplResponse, error)
+}
+
+
 // TopicAPIServicer defines the api actions for the TopicAPI service
 // This interface intended to stay up to date with the openapi yaml used to generate it,
 // while the service implementation can be ignored with the .openapi-generator-ignore file
@@ -116,6 +279,14 @@ type TopicAPIServicer interface {
 	UpdateTopic(context.Context, Topic) (ImplResponse, error)
 	AddTopic(context.Context, Topic) (ImplResponse, error)
 	DeleteTopic(context.Context, string) (ImplResponse, error)
+	GetTopicRoles(context.Context, string) (ImplResponse, error)
+	UpdateTopicRole(context.Context, string, TopicRole) (ImplResponse, error)
+	DeleteTopicRole(context.Context, string, string) (ImplResponse, error)
+	UpdateTopicLock(context.Context, string, Lock) (ImplResponse, error)
+	DeleteTopicLock(context.Context, string, string) (ImplResponse, error)
+	GetTopicDeadLinks(context.Context, string) (ImplResponse, error)
+	GetTopicSharedVideos(context.Context, string, int32) (ImplResponse, error)
+	ImportTopicPlaylist(context.Context, string, PlaylistImport) (ImplResponse, error)
 }
 
 
@@ -128,4 +299,10 @@ type UserAPIServicer interface {
 	UpdateUser(context.Context, User) (ImplResponse, error)
 	GetUserByName(context.Context, string) (ImplResponse, error)
 	DeleteUser(context.Context, string) (ImplResponse, error)
+	GetUserReputation(context.Context, string) (ImplResponse, error)
+	GetUserNotifications(context.Context, string) (ImplResponse, error)
+	UpdateUserNotificationsRead(context.Context, string) (ImplResponse, error)
+	GetUserWatches(context.Context, string) (ImplResponse, error)
+	UpdateUserWatch(context.Context, string, WatchProgress) (ImplResponse, error)
+	GetLeaderboard(context.Context, string, string, int32) (ImplResponse, error)
 }
@@ -133,7 +133,14 @@ func (c *NodeAPIController) GetNode(w http.ResponseWriter, r *http.Request) {
 		c.errorHandler(w, r, &RequiredError{Field: "tid"}, nil)
 		return
 	}
-	result, err := c.service.GetNode(r.Context(), nodeIdParam, tidParam)
+	var rankingParam string
+	if query.Has("ranking") {
+		param := query.Get("ranking")
+
+		rankingParam = param
+	} else {
+	}
+	result, err := c.service.GetNode(r.Context(), nodeIdParam, tidParam, rankingParam)
 	// If an error occurred, encode the error with the status code
 	if err != nil {
 		c.errorHandler(w, r, err, &result)
@@ -29,13 +29,16 @@ func NewNodeAPIService() *NodeAPIService {
 }
 
 // GetNode - get wiki node
-func (s *NodeAPIService) GetNode(ctx context.Context, nodeId string, tid string) (ImplResponse, error) {
+func (s *NodeAPIService) GetNode(ctx context.Context, nodeId string, tid string, ranking string) (ImplResponse, error) {
 	// TODO - update GetNode with the required logic for this service method.
 	// Add api_node_service.go to the .openapi-generator-ignore to avoid overwriting this service implementation when updating open api generation.
 
 	// TODO: Uncomment the next line to return response Response(200, NodeData{}) or use other options such as http.Ok ...
 	// return Response(200, NodeData{}), nil
 
+	// TODO: Uncomment the next line to return response Response(400, {}) or use other options such as http.Ok ...
+	// return Response(400, nil),nil
+
 	// TODO: Uncomment the next line to return response Response(404, {}) or use other options such as http.Ok ...
 	// return Response(404, nil),nil
 
@@ -149,8 +152,8 @@ func (s *NodeAPIService) UpdateNodeVideoEdit(ctx context.Context, nodeData NodeD
 	// TODO - update UpdateNodeVideoEdit with the required logic for this service method.
 	// Add api_node_service.go to the .openapi-generator-ignore to avoid overwriting this service implementation when updating open api generation.
 
-	// TODO: Uncomment the next line to return response Response(200, {}) or use other options such as http.Ok ...
-	// return Response(200, nil),nil
+	// TODO: Uncomment the next line to return response Response(200, []NodeReference{}) or use other options such as http.Ok ...
+	// return Response(200, []NodeReference{}), nil
 
 	// TODO: Uncomment the next line to return response Response(400, {}) or use other options such as http.Ok ...
 	// return Response(400, nil),nil
@@ -72,6 +72,46 @@ func (c *TopicAPIController) Routes() Routes {
 			"/api/v1/topic/{topicId}",
 			c.DeleteTopic,
 		},
+		"GetTopicRoles": Route{
+			strings.ToUpper("Get"),
+			"/api/v1/topic/{topicId}/role",
+			c.GetTopicRoles,
+		},
+		"UpdateTopicRole": Route{
+			strings.ToUpper("Put"),
+			"/api/v1/topic/{topicId}/role",
+			c.UpdateTopicRole,
+		},
+		"DeleteTopicRole": Route{
+			strings.ToUpper("Delete"),
+			"/api/v1/topic/{topicId}/role/{userId}",
+			c.DeleteTopicRole,
+		},
+		"UpdateTopicLock": Route{
+			strings.ToUpper("Put"),
+			"/api/v1/topic/{topicId}/lock",
+			c.UpdateTopicLock,
+		},
+		"DeleteTopicLock": Route{
+			strings.ToUpper("Delete"),
+			"/api/v1/topic/{topicId}/lock",
+			c.DeleteTopicLock,
+		},
+		"GetTopicDeadLinks": Route{
+			strings.ToUpper("Get"),
+			"/api/v1/topic/{topicId}/deadLinks",
+			c.GetTopicDeadLinks,
+		},
+		"GetTopicSharedVideos": Route{
+			strings.ToUpper("Get"),
+			"/api/v1/topic/{topicId}/sharedVideos",
+			c.GetTopicSharedVideos,
+		},
+		"ImportTopicPlaylist": Route{
+			strings.ToUpper("Post"),
+			"/api/v1/topic/{topicId}/import",
+			c.ImportTopicPlaylist,
+		},
 	}
 }
 
@@ -158,3 +198,228 @@ func (c *TopicAPIController) DeleteTopic(w http.ResponseWriter, r *http.Request)
 	// If no error, encode the body and the result code
 	_ = EncodeJSONResponse(result.Body, &result.Code, w)
 }
+
+// GetTopicRoles - get the roles people have in a topic
+func (c *TopicAPIController) GetTopicRoles(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	topicIdParam := params["topicId"]
+	if topicIdParam == "" {
+		c.errorHandler(w, r, &RequiredError{"topicId"}, nil)
+		return
+	}
+	result, err := c.service.GetTopicRoles(r.Context(), topicIdParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+}
+
+// UpdateTopicRole - give someone a role in a topic or change it
+func (c *TopicAPIController) UpdateTopicRole(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	topicIdParam := params["topicId"]
+	if topicIdParam == "" {
+		c.errorHandler(w, r, &RequiredError{"topicId"}, nil)
+		return
+	}
+	topicRoleParam := TopicRole{}
+	d := json.NewDecoder(r.Body)
+	d.DisallowUnknownFields()
+	if err := d.Decode(&topicRoleParam); err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	if err := AssertTopicRoleRequired(topicRoleParam); err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	if err := AssertTopicRoleConstraints(topicRoleParam); err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	result, err := c.service.UpdateTopicRole(r.Context(), topicIdParam, topicRoleParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+}
+
+// DeleteTopicRole - remove someone's role in a topic
+func (c *TopicAPIController) DeleteTopicRole(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	topicIdParam := params["topicId"]
+	if topicIdParam == "" {
+		c.errorHandler(w, r, &RequiredError{"topicId"}, nil)
+		return
+	}
+	userIdParam := params["userId"]
+	if userIdParam == "" {
+		c.errorHandler(w, r, &RequiredError{"userId"}, nil)
+		return
+	}
+	result, err := c.service.DeleteTopicRole(r.Context(), topicIdParam, userIdParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+}
+
+// UpdateTopicLock - lock the topic or one of its nodes
+func (c *TopicAPIController) UpdateTopicLock(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	topicIdParam := params["topicId"]
+	if topicIdParam == "" {
+		c.errorHandler(w, r, &RequiredError{"topicId"}, nil)
+		return
+	}
+	lockParam := Lock{}
+	d := json.NewDecoder(r.Body)
+	d.DisallowUnknownFields()
+	if err := d.Decode(&lockParam); err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	if err := AssertLockRequired(lockParam); err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	if err := AssertLockConstraints(lockParam); err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	result, err := c.service.UpdateTopicLock(r.Context(), topicIdParam, lockParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+}
+
+// DeleteTopicLock - unlock the topic or one of its nodes
+func (c *TopicAPIController) DeleteTopicLock(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	query, err := parseQuery(r.URL.RawQuery)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	topicIdParam := params["topicId"]
+	if topicIdParam == "" {
+		c.errorHandler(w, r, &RequiredError{"topicId"}, nil)
+		return
+	}
+	var nodeIdParam string
+	if query.Has("nodeId") {
+		param := query.Get("nodeId")
+
+		nodeIdParam = param
+	} else {
+	}
+	result, err := c.service.DeleteTopicLock(r.Context(), topicIdParam, nodeIdParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+}
+
+// GetTopicDeadLinks - get the dead links of a topic
+func (c *TopicAPIController) GetTopicDeadLinks(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	topicIdParam := params["topicId"]
+	if topicIdParam == "" {
+		c.errorHandler(w, r, &RequiredError{"topicId"}, nil)
+		return
+	}
+	result, err := c.service.GetTopicDeadLinks(r.Context(), topicIdParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+}
+
+// GetTopicSharedVideos - get the videos attached to many nodes of a topic
+func (c *TopicAPIController) GetTopicSharedVideos(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	query, err := parseQuery(r.URL.RawQuery)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	topicIdParam := params["topicId"]
+	if topicIdParam == "" {
+		c.errorHandler(w, r, &RequiredError{"topicId"}, nil)
+		return
+	}
+	var minNodesParam int32
+	if query.Has("minNodes") {
+		param, err := parseNumericParameter[int32](
+			query.Get("minNodes"),
+			WithParse[int32](parseInt32),
+		)
+		if err != nil {
+			c.errorHandler(w, r, &ParsingError{Param: "minNodes", Err: err}, nil)
+			return
+		}
+
+		minNodesParam = param
+	} else {
+	}
+	result, err := c.service.GetTopicSharedVideos(r.Context(), topicIdParam, minNodesParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+}
+
+// ImportTopicPlaylist - import a playlist as a chain of nodes
+func (c *TopicAPIController) ImportTopicPlaylist(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	topicIdParam := params["topicId"]
+	if topicIdParam == "" {
+		c.errorHandler(w, r, &RequiredError{"topicId"}, nil)
+		return
+	}
+	playlistImportParam := PlaylistImport{}
+	d := json.NewDecoder(r.Body)
+	d.DisallowUnknownFields()
+	if err := d.Decode(&playlistImportParam); err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	if err := AssertPlaylistImportRequired(playlistImportParam); err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	if err := AssertPlaylistImportConstraints(playlistImportParam); err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	result, err := c.service.ImportTopicPlaylist(r.Context(), topicIdParam, playlistImportParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+}
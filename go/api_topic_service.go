@@ -95,3 +95,142 @@ func (s *TopicAPIService) DeleteTopic(ctx context.Context, topicId string) (Impl
 
 	return Response(http.StatusNotImplemented, nil), errors.New("DeleteTopic method not implemented")
 }
+
+// GetTopicRoles - get the roles people have in a topic
+func (s *TopicAPIService) GetTopicRoles(ctx context.Context, topicId string) (ImplResponse, error) {
+	// TODO - update GetTopicRoles with the required logic for this service method.
+	// Add api_topic_service.go to the .openapi-generator-ignore to avoid overwriting this service implementation when updating open api generation.
+
+	// TODO: Uncomment the next line to return response Response(200, []TopicRole{}) or use other options such as http.Ok ...
+	// return Response(200, []TopicRole{}), nil
+
+	// TODO: Uncomment the next line to return response Response(404, {}) or use other options such as http.Ok ...
+	// return Response(404, nil),nil
+
+	return Response(http.StatusNotImplemented, nil), errors.New("GetTopicRoles method not implemented")
+}
+
+// UpdateTopicRole - give someone a role in a topic or change it
+func (s *TopicAPIService) UpdateTopicRole(ctx context.Context, topicId string, topicRole TopicRole) (ImplResponse, error) {
+	// TODO - update UpdateTopicRole with the required logic for this service method.
+	// Add api_topic_service.go to the .openapi-generator-ignore to avoid overwriting this service implementation when updating open api generation.
+
+	// TODO: Uncomment the next line to return response Response(200, []TopicRole{}) or use other options such as http.Ok ...
+	// return Response(200, []TopicRole{}), nil
+
+	// TODO: Uncomment the next line to return response Response(400, {}) or use other options such as http.Ok ...
+	// return Response(400, nil),nil
+
+	// TODO: Uncomment the next line to return response Response(401, {}) or use other options such as http.Ok ...
+	// return Response(401, nil),nil
+
+	return Response(http.StatusNotImplemented, nil), errors.New("UpdateTopicRole method not implemented")
+}
+
+// DeleteTopicRole - remove someone's role in a topic
+func (s *TopicAPIService) DeleteTopicRole(ctx context.Context, topicId string, userId string) (ImplResponse, error) {
+	// TODO - update DeleteTopicRole with the required logic for this service method.
+	// Add api_topic_service.go to the .openapi-generator-ignore to avoid overwriting this service implementation when updating open api generation.
+
+	// TODO: Uncomment the next line to return response Response(204, {}) or use other options such as http.Ok ...
+	// return Response(204, nil),nil
+
+	// TODO: Uncomment the next line to return response Response(400, {}) or use other options such as http.Ok ...
+	// return Response(400, nil),nil
+
+	// TODO: Uncomment the next line to return response Response(401, {}) or use other options such as http.Ok ...
+	// return Response(401, nil),nil
+
+	return Response(http.StatusNotImplemented, nil), errors.New("DeleteTopicRole method not implemented")
+}
+
+// UpdateTopicLock - lock the topic or one of its nodes
+func (s *TopicAPIService) UpdateTopicLock(ctx context.Context, topicId string, lock Lock) (ImplResponse, error) {
+	// TODO - update UpdateTopicLock with the required logic for this service method.
+	// Add api_topic_service.go to the .openapi-generator-ignore to avoid overwriting this service implementation when updating open api generation.
+
+	// TODO: Uncomment the next line to return response Response(200, Lock{}) or use other options such as http.Ok ...
+	// return Response(200, Lock{}), nil
+
+	// TODO: Uncomment the next line to return response Response(400, {}) or use other options such as http.Ok ...
+	// return Response(400, nil),nil
+
+	// TODO: Uncomment the next line to return response Response(401, {}) or use other options such as http.Ok ...
+	// return Response(401, nil),nil
+
+	// TODO: Uncomment the next line to return response Response(404, {}) or use other options such as http.Ok ...
+	// return Response(404, nil),nil
+
+	return Response(http.StatusNotImplemented, nil), errors.New("UpdateTopicLock method not implemented")
+}
+
+// DeleteTopicLock - unlock the topic or one of its nodes
+func (s *TopicAPIService) DeleteTopicLock(ctx context.Context, topicId string, nodeId string) (ImplResponse, error) {
+	// TODO - update DeleteTopicLock with the required logic for this service method.
+	// Add api_topic_service.go to the .openapi-generator-ignore to avoid overwriting this service implementation when updating open api generation.
+
+	// TODO: Uncomment the next line to return response Response(204, {}) or use other options such as http.Ok ...
+	// return Response(204, nil),nil
+
+	// TODO: Uncomment the next line to return response Response(400, {}) or use other options such as http.Ok ...
+	// return Response(400, nil),nil
+
+	// TODO: Uncomment the next line to return response Response(401, {}) or use other options such as http.Ok ...
+	// return Response(401, nil),nil
+
+	// TODO: Uncomment the next line to return response Response(404, {}) or use other options such as http.Ok ...
+	// return Response(404, nil),nil
+
+	return Response(http.StatusNotImplemented, nil), errors.New("DeleteTopicLock method not implemented")
+}
+
+// GetTopicDeadLinks - get the dead links of a topic
+func (s *TopicAPIService) GetTopicDeadLinks(ctx context.Context, topicId string) (ImplResponse, error) {
+	// TODO - update GetTopicDeadLinks with the required logic for this service method.
+	// Add api_topic_service.go to the .openapi-generator-ignore to avoid overwriting this service implementation when updating open api generation.
+
+	// TODO: Uncomment the next line to return response Response(200, []DeadLink{}) or use other options such as http.Ok ...
+	// return Response(200, []DeadLink{}), nil
+
+	// TODO: Uncomment the next line to return response Response(404, {}) or use other options such as http.Ok ...
+	// return Response(404, nil),nil
+
+	return Response(http.StatusNotImplemented, nil), errors.New("GetTopicDeadLinks method not implemented")
+}
+
+// GetTopicSharedVideos - get the videos attached to many nodes of a topic
+func (s *TopicAPIService) GetTopicSharedVideos(ctx context.Context, topicId string, minNodes int32) (ImplResponse, error) {
+	// TODO - update GetTopicSharedVideos with the required logic for this service method.
+	// Add api_topic_service.go to the .openapi-generator-ignore to avoid overwriting this service implementation when updating open api generation.
+
+	// TODO: Uncomment the next line to return response Response(200, []SharedVideo{}) or use other options such as http.Ok ...
+	// return Response(200, []SharedVideo{}), nil
+
+	// TODO: Uncomment the next line to return response Response(404, {}) or use other options such as http.Ok ...
+	// return Response(404, nil),nil
+
+	return Response(http.StatusNotImplemented, nil), errors.New("GetTopicSharedVideos method not implemented")
+}
+
+// ImportTopicPlaylist - import a playlist as a chain of nodes
+func (s *TopicAPIService) ImportTopicPlaylist(ctx context.Context, topicId string, playlistImport PlaylistImport) (ImplResponse, error) {
+	// TODO - update ImportTopicPlaylist with the required logic for this service method.
+	// Add api_topic_service.go to the .openapi-generator-ignore to avoid overwriting this service implementation when updating open api generation.
+
+	// TODO: Uncomment the next line to return response Response(200, PlaylistImportResult{}) or use other options such as http.Ok ...
+	// return Response(200, PlaylistImportResult{}), nil
+
+	// TODO: Uncomment the next line to return response Response(400, {}) or use other options such as http.Ok ...
+	// return Response(400, nil),nil
+
+	// TODO: Uncomment the next line to return response Response(401, {}) or use other options such as http.Ok ...
+	// return Response(401, nil),nil
+
+	// TODO: Uncomment the next line to return response Response(404, {}) or use other options such as http.Ok ...
+	// return Response(404, nil),nil
+
+	// TODO: Uncomment the next line to return response Response(423, {}) or use other options such as http.Ok ...
+	// return Response(423, nil),nil
+
+	return Response(http.StatusNotImplemented, nil), errors.New("ImportTopicPlaylist method not implemented")
+}
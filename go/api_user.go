@@ -72,6 +72,36 @@ func (c *UserAPIController) Routes() Routes {
 			"/api/v1/user/{userId}",
 			c.DeleteUser,
 		},
+		"GetUserReputation": Route{
+			strings.ToUpper("Get"),
+			"/api/v1/user/{userId}/reputation",
+			c.GetUserReputation,
+		},
+		"GetUserNotifications": Route{
+			strings.ToUpper("Get"),
+			"/api/v1/user/{userId}/notifications",
+			c.GetUserNotifications,
+		},
+		"UpdateUserNotificationsRead": Route{
+			strings.ToUpper("Put"),
+			"/api/v1/user/{userId}/notifications/read",
+			c.UpdateUserNotificationsRead,
+		},
+		"GetUserWatches": Route{
+			strings.ToUpper("Get"),
+			"/api/v1/user/{userId}/watches",
+			c.GetUserWatches,
+		},
+		"UpdateUserWatch": Route{
+			strings.ToUpper("Put"),
+			"/api/v1/user/{userId}/watches",
+			c.UpdateUserWatch,
+		},
+		"GetLeaderboard": Route{
+			strings.ToUpper("Get"),
+			"/api/v1/leaderboard",
+			c.GetLeaderboard,
+		},
 	}
 }
 
@@ -149,3 +179,153 @@ func (c *UserAPIController) DeleteUser(w http.ResponseWriter, r *http.Request) {
 	// If no error, encode the body and the result code
 	_ = EncodeJSONResponse(result.Body, &result.Code, w)
 }
+
+// GetUserReputation - get why the reputation of the user changed, newest first
+func (c *UserAPIController) GetUserReputation(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	userIdParam := params["userId"]
+	if userIdParam == "" {
+		c.errorHandler(w, r, &RequiredError{"userId"}, nil)
+		return
+	}
+	result, err := c.service.GetUserReputation(r.Context(), userIdParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+}
+
+// GetUserNotifications - get the notifications of the user, newest first
+func (c *UserAPIController) GetUserNotifications(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	userIdParam := params["userId"]
+	if userIdParam == "" {
+		c.errorHandler(w, r, &RequiredError{"userId"}, nil)
+		return
+	}
+	result, err := c.service.GetUserNotifications(r.Context(), userIdParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+}
+
+// UpdateUserNotificationsRead - mark every notification of the user as read
+func (c *UserAPIController) UpdateUserNotificationsRead(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	userIdParam := params["userId"]
+	if userIdParam == "" {
+		c.errorHandler(w, r, &RequiredError{"userId"}, nil)
+		return
+	}
+	result, err := c.service.UpdateUserNotificationsRead(r.Context(), userIdParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+}
+
+// GetUserWatches - get the watch progress of the user
+func (c *UserAPIController) GetUserWatches(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	userIdParam := params["userId"]
+	if userIdParam == "" {
+		c.errorHandler(w, r, &RequiredError{"userId"}, nil)
+		return
+	}
+	result, err := c.service.GetUserWatches(r.Context(), userIdParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+}
+
+// UpdateUserWatch - record the watch progress of a video
+func (c *UserAPIController) UpdateUserWatch(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	userIdParam := params["userId"]
+	if userIdParam == "" {
+		c.errorHandler(w, r, &RequiredError{"userId"}, nil)
+		return
+	}
+	watchProgressParam := WatchProgress{}
+	d := json.NewDecoder(r.Body)
+	d.DisallowUnknownFields()
+	if err := d.Decode(&watchProgressParam); err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	if err := AssertWatchProgressRequired(watchProgressParam); err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	if err := AssertWatchProgressConstraints(watchProgressParam); err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	result, err := c.service.UpdateUserWatch(r.Context(), userIdParam, watchProgressParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+}
+
+// GetLeaderboard - get the top contributors globally or in a topic over a time window
+func (c *UserAPIController) GetLeaderboard(w http.ResponseWriter, r *http.Request) {
+	query, err := parseQuery(r.URL.RawQuery)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	var topicIdParam string
+	if query.Has("topicId") {
+		param := query.Get("topicId")
+
+		topicIdParam = param
+	} else {
+	}
+	var windowParam string
+	if query.Has("window") {
+		param := query.Get("window")
+
+		windowParam = param
+	} else {
+	}
+	var limitParam int32
+	if query.Has("limit") {
+		param, err := parseNumericParameter[int32](
+			query.Get("limit"),
+			WithParse[int32](parseInt32),
+		)
+		if err != nil {
+			c.errorHandler(w, r, &ParsingError{Param: "limit", Err: err}, nil)
+			return
+		}
+
+		limitParam = param
+	} else {
+	}
+	result, err := c.service.GetLeaderboard(r.Context(), topicIdParam, windowParam, limitParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+}
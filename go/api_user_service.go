@@ -89,3 +89,96 @@ func (s *UserAPIService) DeleteUser(ctx context.Context, userId string) (ImplRes
 
 	return Response(http.StatusNotImplemented, nil), errors.New("DeleteUser method not implemented")
 }
+
+// GetUserReputation - get why the reputation of the user changed, newest first
+func (s *UserAPIService) GetUserReputation(ctx context.Context, userId string) (ImplResponse, error) {
+	// TODO - update GetUserReputation with the required logic for this service method.
+	// Add api_user_service.go to the .openapi-generator-ignore to avoid overwriting this service implementation when updating open api generation.
+
+	// TODO: Uncomment the next line to return response Response(200, ReputationHistory{}) or use other options such as http.Ok ...
+	// return Response(200, ReputationHistory{}), nil
+
+	// TODO: Uncomment the next line to return response Response(401, {}) or use other options such as http.Ok ...
+	// return Response(401, nil),nil
+
+	// TODO: Uncomment the next line to return response Response(404, {}) or use other options such as http.Ok ...
+	// return Response(404, nil),nil
+
+	return Response(http.StatusNotImplemented, nil), errors.New("GetUserReputation method not implemented")
+}
+
+// GetUserNotifications - get the notifications of the user, newest first
+func (s *UserAPIService) GetUserNotifications(ctx context.Context, userId string) (ImplResponse, error) {
+	// TODO - update GetUserNotifications with the required logic for this service method.
+	// Add api_user_service.go to the .openapi-generator-ignore to avoid overwriting this service implementation when updating open api generation.
+
+	// TODO: Uncomment the next line to return response Response(200, []Notification{}) or use other options such as http.Ok ...
+	// return Response(200, []Notification{}), nil
+
+	// TODO: Uncomment the next line to return response Response(401, {}) or use other options such as http.Ok ...
+	// return Response(401, nil),nil
+
+	return Response(http.StatusNotImplemented, nil), errors.New("GetUserNotifications method not implemented")
+}
+
+// UpdateUserNotificationsRead - mark every notification of the user as read
+func (s *UserAPIService) UpdateUserNotificationsRead(ctx context.Context, userId string) (ImplResponse, error) {
+	// TODO - update UpdateUserNotificationsRead with the required logic for this service method.
+	// Add api_user_service.go to the .openapi-generator-ignore to avoid overwriting this service implementation when updating open api generation.
+
+	// TODO: Uncomment the next line to return response Response(204, {}) or use other options such as http.Ok ...
+	// return Response(204, nil),nil
+
+	// TODO: Uncomment the next line to return response Response(401, {}) or use other options such as http.Ok ...
+	// return Response(401, nil),nil
+
+	return Response(http.StatusNotImplemented, nil), errors.New("UpdateUserNotificationsRead method not implemented")
+}
+
+// GetUserWatches - get the watch progress of the user
+func (s *UserAPIService) GetUserWatches(ctx context.Context, userId string) (ImplResponse, error) {
+	// TODO - update GetUserWatches with the required logic for this service method.
+	// Add api_user_service.go to the .openapi-generator-ignore to avoid overwriting this service implementation when updating open api generation.
+
+	// TODO: Uncomment the next line to return response Response(200, []WatchProgress{}) or use other options such as http.Ok ...
+	// return Response(200, []WatchProgress{}), nil
+
+	// TODO: Uncomment the next line to return response Response(401, {}) or use other options such as http.Ok ...
+	// return Response(401, nil),nil
+
+	return Response(http.StatusNotImplemented, nil), errors.New("GetUserWatches method not implemented")
+}
+
+// UpdateUserWatch - record the watch progress of a video
+func (s *UserAPIService) UpdateUserWatch(ctx context.Context, userId string, watchProgress WatchProgress) (ImplResponse, error) {
+	// TODO - update UpdateUserWatch with the required logic for this service method.
+	// Add api_user_service.go to the .openapi-generator-ignore to avoid overwriting this service implementation when updating open api generation.
+
+	// TODO: Uncomment the next line to return response Response(200, WatchProgress{}) or use other options such as http.Ok ...
+	// return Response(200, WatchProgress{}), nil
+
+	// TODO: Uncomment the next line to return response Response(400, {}) or use other options such as http.Ok ...
+	// return Response(400, nil),nil
+
+	// TODO: Uncomment the next line to return response Response(401, {}) or use other options such as http.Ok ...
+	// return Response(401, nil),nil
+
+	return Response(http.StatusNotImplemented, nil), errors.New("UpdateUserWatch method not implemented")
+}
+
+// GetLeaderboard - get the top contributors globally or in a topic over a time window
+func (s *UserAPIService) GetLeaderboard(ctx context.Context, topicId string, window string, limit int32) (ImplResponse, error) {
+	// TODO - update GetLeaderboard with the required logic for this service method.
+	// Add api_user_service.go to the .openapi-generator-ignore to avoid overwriting this service implementation when updating open api generation.
+
+	// TODO: Uncomment the next line to return response Response(200, Leaderboard{}) or use other options such as http.Ok ...
+	// return Response(200, Leaderboard{}), nil
+
+	// TODO: Uncomment the next line to return response Response(400, {}) or use other options such as http.Ok ...
+	// return Response(400, nil),nil
+
+	// TODO: Uncomment the next line to return response Response(404, {}) or use other options such as http.Ok ...
+	// return Response(404, nil),nil
+
+	return Response(http.StatusNotImplemented, nil), errors.New("GetLeaderboard method not implemented")
+}
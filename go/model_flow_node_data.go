@@ -23,14 +23,29 @@ type FlowNodeData struct {
 	Fresh int32 `json:"fresh,omitempty"`
 
 	Speed int32 `json:"speed,omitempty"`
+
+
+	// set when the content passed a flag or vote threshold, only moderators and its author still see it
+	IsHidden bool `json:"isHidden,omitempty"`
+
+	HiddenReason string `json:"hiddenReason,omitempty"`
+
+	// how many of the videos of the node the current user finished
+	Completion Completion `json:"completion,omitempty"`
 }
 
 // AssertFlowNodeDataRequired checks if the required fields are not zero-ed
 func AssertFlowNodeDataRequired(obj FlowNodeData) error {
+	if err := AssertCompletionRequired(obj.Completion); err != nil {
+		return err
+	}
 	return nil
 }
 
 // AssertFlowNodeDataConstraints checks if the values respects the defined constraints
 func AssertFlowNodeDataConstraints(obj FlowNodeData) error {
+	if err := AssertCompletionConstraints(obj.Completion); err != nil {
+		return err
+	}
 	return nil
 }
@@ -17,6 +17,10 @@ package openapi
 type GetTopics200ResponseInner struct {
 
 	Title string `json:"title"`
+
+	Categories []string `json:"categories,omitempty"`
+
+	Visibility string `json:"visibility,omitempty"`
 }
 
 // AssertGetTopics200ResponseInnerRequired checks if the required fields are not zero-ed
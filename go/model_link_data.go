@@ -20,13 +20,76 @@ import (
 
 type LinkData struct {
 
+	// the canonical link of the resource, https://www.youtube.com/watch?v= and its id for YouTube videos with &t= and &end= for a segment
 	Link string `json:"link,omitempty"`
 
+	// video, article, pdf, exercise or book
+	Type string `json:"type,omitempty"`
+
+	Title string `json:"title,omitempty"`
+
+	// the channel or author of the resource, filled in from its metadata
+	Channel string `json:"channel,omitempty"`
+
+	Thumbnail string `json:"thumbnail,omitempty"`
+
+	// length of the resource in seconds
+	Duration int32 `json:"duration,omitempty"`
+
+	// youtube, vimeo or peertube when the id of the video is read from the link
+	Provider string `json:"provider,omitempty"`
+
+	// a link a player can embed, it only plays the segment
+	EmbedUrl string `json:"embedUrl,omitempty"`
+
+	// the id of the video at its provider
+	VideoId string `json:"videoId,omitempty"`
+
+	// seconds into the video to start at
+	Start int32 `json:"start,omitempty"`
+
+	// seconds into the video to stop at, 0 plays to the end
+	End int32 `json:"end,omitempty"`
+
+	// the YouTube playlist the video was linked from
+	Playlist string `json:"playlist,omitempty"`
+
+	// up votes minus down votes
 	Votes int32 `json:"votes,omitempty"`
 
+	Up int32 `json:"up,omitempty"`
+
+	Down int32 `json:"down,omitempty"`
+
+	// what the resources of a node are sorted by, it depends on the ranking asked for
+	Score float64 `json:"score,omitempty"`
+
 	AddedBy UserIdentifier `json:"addedBy,omitempty"`
 
 	DateAdded time.Time `json:"dateAdded,omitempty"`
+
+	// ok, removed, private, regionBlocked, unreachable or unchecked when the link was last checked
+	Status string `json:"status,omitempty"`
+
+	CheckedAt time.Time `json:"checkedAt,omitempty"`
+
+	// when the link was first found removed, private or region blocked, cleared once it works again
+	DeadSince time.Time `json:"deadSince,omitempty"`
+
+	// how much of the video the current user watched in percent
+	Progress float32 `json:"progress,omitempty"`
+
+	// seconds into the video the current user last got to
+	LastPosition int32 `json:"lastPosition,omitempty"`
+
+	// set once the current user finished the video
+	Finished bool `json:"finished,omitempty"`
+
+
+	// set when the content passed a flag or vote threshold, only moderators and its author still see it
+	IsHidden bool `json:"isHidden,omitempty"`
+
+	HiddenReason string `json:"hiddenReason,omitempty"`
 }
 
 // AssertLinkDataRequired checks if the required fields are not zero-ed
@@ -36,11 +36,29 @@ type NodeData struct {
 
 	IsFlagged bool `json:"isFlagged,omitempty"`
 
+	// set when the content passed a flag or vote threshold, only moderators and its author still see it
+	IsHidden bool `json:"isHidden,omitempty"`
+
+	HiddenReason string `json:"hiddenReason,omitempty"`
+
+	// the YouTube videos of resources, kept for clients from before resources
 	YoutubeLinks []LinkData `json:"youtubeLinks,omitempty"`
 
+	// videos, articles, pdfs, exercises and books that teach the node
+	Resources []LinkData `json:"resources,omitempty"`
+
+	// nodes of the topic that share videos with this one, the most shared first
+	RelatedNodes []NodeReference `json:"relatedNodes,omitempty"`
+
+	// how many of the videos of the node the current user finished
+	Completion Completion `json:"completion,omitempty"`
+
 	CreatedBy UserIdentifier `json:"createdBy,omitempty"`
 
 	EditedBy []UserIdentifier `json:"editedBy,omitempty"`
+
+	// set while the node is locked, only admins and maintainers can change it
+	Lock Lock `json:"lock,omitempty"`
 }
 
 // AssertNodeDataRequired checks if the required fields are not zero-ed
@@ -50,6 +68,19 @@ func AssertNodeDataRequired(obj NodeData) error {
 			return err
 		}
 	}
+	for _, el := range obj.Resources {
+		if err := AssertLinkDataRequired(el); err != nil {
+			return err
+		}
+	}
+	for _, el := range obj.RelatedNodes {
+		if err := AssertNodeReferenceRequired(el); err != nil {
+			return err
+		}
+	}
+	if err := AssertCompletionRequired(obj.Completion); err != nil {
+		return err
+	}
 	if err := AssertUserIdentifierRequired(obj.CreatedBy); err != nil {
 		return err
 	}
@@ -58,6 +89,9 @@ func AssertNodeDataRequired(obj NodeData) error {
 			return err
 		}
 	}
+	if err := AssertLockRequired(obj.Lock); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -68,6 +102,19 @@ func AssertNodeDataConstraints(obj NodeData) error {
 			return err
 		}
 	}
+	for _, el := range obj.Resources {
+		if err := AssertLinkDataConstraints(el); err != nil {
+			return err
+		}
+	}
+	for _, el := range obj.RelatedNodes {
+		if err := AssertNodeReferenceConstraints(el); err != nil {
+			return err
+		}
+	}
+	if err := AssertCompletionConstraints(obj.Completion); err != nil {
+		return err
+	}
 	if err := AssertUserIdentifierConstraints(obj.CreatedBy); err != nil {
 		return err
 	}
@@ -76,5 +123,8 @@ func AssertNodeDataConstraints(obj NodeData) error {
 			return err
 		}
 	}
+	if err := AssertLockConstraints(obj.Lock); err != nil {
+		return err
+	}
 	return nil
 }
@@ -17,6 +17,26 @@ package openapi
 type Topic struct {
 
 	Title string `json:"title"`
+
+	Categories []string `json:"categories,omitempty"`
+
+	// public, unlisted, private or group. An empty value is treated as public
+	Visibility string `json:"visibility,omitempty"`
+
+	AllowedUsers []string `json:"allowedUsers,omitempty"`
+
+	AllowedGroups []string `json:"allowedGroups,omitempty"`
+
+	CreatedBy string `json:"createdBy,omitempty"`
+
+	// set when the topic belongs to a school, only its members can see it
+	OrganizationId string `json:"organizationId,omitempty"`
+
+	// the creator is always an owner and does not need to be listed
+	Roles []TopicRole `json:"roles,omitempty"`
+
+	// set while the topic is locked, only admins and maintainers can change it
+	Lock Lock `json:"lock,omitempty"`
 }
 
 // AssertTopicRequired checks if the required fields are not zero-ed
@@ -30,10 +50,26 @@ func AssertTopicRequired(obj Topic) error {
 		}
 	}
 
+	for _, el := range obj.Roles {
+		if err := AssertTopicRoleRequired(el); err != nil {
+			return err
+		}
+	}
+	if err := AssertLockRequired(obj.Lock); err != nil {
+		return err
+	}
 	return nil
 }
 
 // AssertTopicConstraints checks if the values respects the defined constraints
 func AssertTopicConstraints(obj Topic) error {
+	for _, el := range obj.Roles {
+		if err := AssertTopicRoleConstraints(el); err != nil {
+			return err
+		}
+	}
+	if err := AssertLockConstraints(obj.Lock); err != nil {
+		return err
+	}
 	return nil
 }
@@ -49,6 +49,8 @@ type User struct {
 
 	IsFlagged bool `json:"isFlagged,omitempty"`
 
+	OrganizationId string `json:"organizationId,omitempty"`
+
 	BattleTestedUp []ResponseUserInfoInner `json:"battleTestedUp,omitempty"`
 
 	BattleTestedDown []ResponseUserInfoInner `json:"battleTestedDown,omitempty"`
@@ -66,6 +68,27 @@ type User struct {
 	VideoUp []string `json:"videoUp,omitempty"`
 
 	VideoDown []string `json:"videoDown,omitempty"`
+
+	// the vote of the user on each video of each node, videoUp and videoDown list their links
+	VideoVotes []VideoVote `json:"videoVotes,omitempty"`
+
+	// newcomer, contributor, editor or deleter
+	Level string `json:"level,omitempty"`
+
+	// the reputation needed for the next level, zero at the top level
+	NextLevelReputation int32 `json:"nextLevelReputation,omitempty"`
+
+	// actions the reputation of the user allows
+	Capabilities []string `json:"capabilities,omitempty"`
+
+	Badges []Badge `json:"badges,omitempty"`
+
+	// days in a row the user was active
+	Streak int32 `json:"streak,omitempty"`
+
+	BestStreak int32 `json:"bestStreak,omitempty"`
+
+	LastActive time.Time `json:"lastActive,omitempty"`
 }
 
 // AssertUserRequired checks if the required fields are not zero-ed
@@ -105,6 +128,16 @@ func AssertUserRequired(obj User) error {
 			return err
 		}
 	}
+	for _, el := range obj.VideoVotes {
+		if err := AssertVideoVoteRequired(el); err != nil {
+			return err
+		}
+	}
+	for _, el := range obj.Badges {
+		if err := AssertBadgeRequired(el); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -145,5 +178,15 @@ func AssertUserConstraints(obj User) error {
 			return err
 		}
 	}
+	for _, el := range obj.VideoVotes {
+		if err := AssertVideoVoteConstraints(el); err != nil {
+			return err
+		}
+	}
+	for _, el := range obj.Badges {
+		if err := AssertBadgeConstraints(el); err != nil {
+			return err
+		}
+	}
 	return nil
 }
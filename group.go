@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"errors"
+
+	openapi "github.com/SpyLime/flowBackend/go"
+	"github.com/go-pkgz/auth/token"
+	bolt "go.etcd.io/bbolt"
+)
+
+// GroupAPIServiceImpl is a service that implements the logic for the GroupAPIServicer
+// This service should implement the business logic for every endpoint for the GroupAPI API.
+// Include any external packages or services that will be required by this service.
+type GroupAPIServiceImpl struct {
+	db     *bolt.DB
+	clock  Clock
+	policy Policy
+}
+
+// NewGroupAPIService creates a default api service
+func NewGroupAPIServiceImpl(db *bolt.DB, clock Clock, policy Policy) openapi.GroupAPIServicer {
+	return &GroupAPIServiceImpl{
+		db:     db,
+		clock:  clock,
+		policy: policy,
+	}
+}
+
+// GetGroups - get the groups the user owns or belongs to
+func (s *GroupAPIServiceImpl) GetGroups(ctx context.Context) (openapi.ImplResponse, error) {
+	user, ok := ctx.Value(userInfoKey).(token.User)
+	if !ok {
+		return openapi.Response(401, nil), errors.New("unauthorized: user not found in context")
+	}
+
+	response, err := getGroups(s.db, user.ID)
+	if err != nil {
+		return openapi.Response(400, nil), err
+	}
+
+	return openapi.Response(200, response), nil
+}
+
+// AddGroup - add a group, who can make groups is up to the policy
+func (s *GroupAPIServiceImpl) AddGroup(ctx context.Context, group openapi.Group) (openapi.ImplResponse, error) {
+	user, ok := ctx.Value(userInfoKey).(token.User)
+	if !ok {
+		return openapi.Response(401, nil), errors.New("unauthorized: user not found in context")
+	}
+
+	userDetails, err := getUser(s.db, user.ID)
+	if err != nil {
+		return openapi.Response(401, nil), err
+	}
+
+	// admins of a school make groups for their classes
+	userDetails, err = getUserForOrganization(s.db, user.ID, userDetails.OrganizationId)
+	if err != nil {
+		return openapi.Response(401, nil), err
+	}
+
+	err = s.policy.check(s.db, s.clock, KeyActionCreateGroup, userDetails, PolicyTarget{})
+	if err != nil {
+		return openapi.Response(401, nil), err
+	}
+
+	response, err := postGroup(s.db, group, user.ID)
+	if err != nil {
+		return openapi.Response(400, nil), err
+	}
+
+	return openapi.Response(200, response), nil
+}
+
+// UpdateGroup - rename a group or change its members
+func (s *GroupAPIServiceImpl) UpdateGroup(ctx context.Context, group openapi.Group) (openapi.ImplResponse, error) {
+	err := s.checkGroupOwner(ctx, group.Id)
+	if err != nil {
+		return openapi.Response(401, nil), err
+	}
+
+	response, err := updateGroup(s.db, group)
+	if err != nil {
+		return openapi.Response(400, nil), err
+	}
+
+	return openapi.Response(200, response), nil
+}
+
+// DeleteGroup - delete a group
+func (s *GroupAPIServiceImpl) DeleteGroup(ctx context.Context, groupId string) (openapi.ImplResponse, error) {
+	err := s.checkGroupOwner(ctx, groupId)
+	if err != nil {
+		return openapi.Response(401, nil), err
+	}
+
+	err = deleteGroup(s.db, groupId)
+	if err != nil {
+		return openapi.Response(400, nil), err
+	}
+
+	return openapi.Response(204, nil), nil
+}
+
+// only the owner of a group or an admin can change it
+func (s *GroupAPIServiceImpl) checkGroupOwner(ctx context.Context, groupId string) error {
+	user, ok := ctx.Value(userInfoKey).(token.User)
+	if !ok {
+		return errors.New("unauthorized: user not found in context")
+	}
+
+	userDetails, err := getUser(s.db, user.ID)
+	if err != nil {
+		return err
+	}
+
+	group, err := getGroup(s.db, groupId)
+	if err != nil {
+		return err
+	}
+
+	if userDetails.Role != KeyAdmin && group.Owner != userDetails.Id {
+		return errors.New("unauthorized: user is not an admin or the owner of the group")
+	}
+
+	return nil
+}
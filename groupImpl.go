@@ -0,0 +1,192 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	openapi "github.com/SpyLime/flowBackend/go"
+	bolt "go.etcd.io/bbolt"
+)
+
+func getGroups(db *bolt.DB, userId string) (response []openapi.Group, err error) {
+	err = db.View(func(tx *bolt.Tx) error {
+		response, err = getGroupsRx(tx, userId)
+		return err
+	})
+
+	return
+}
+
+// returns the groups the user owns or is a member of
+func getGroupsRx(tx *bolt.Tx, userId string) (response []openapi.Group, err error) {
+	response = []openapi.Group{}
+
+	groupsBucket := tx.Bucket([]byte(KeyGroups))
+	if groupsBucket == nil {
+		return
+	}
+
+	c := groupsBucket.Cursor()
+	for k, v := c.First(); k != nil; k, v = c.Next() {
+		var group openapi.Group
+		err = json.Unmarshal(v, &group)
+		if err != nil {
+			return
+		}
+
+		if group.Owner == userId || contains(group.Members, userId) {
+			response = append(response, group)
+		}
+	}
+
+	return
+}
+
+func getGroup(db *bolt.DB, groupId string) (response openapi.Group, err error) {
+	err = db.View(func(tx *bolt.Tx) error {
+		response, err = getGroupRx(tx, groupId)
+		return err
+	})
+
+	return
+}
+
+func getGroupRx(tx *bolt.Tx, groupId string) (group openapi.Group, err error) {
+	groupsBucket := tx.Bucket([]byte(KeyGroups))
+	if groupsBucket == nil {
+		return group, fmt.Errorf("can't find groups bucket")
+	}
+
+	groupData := groupsBucket.Get([]byte(groupId))
+	if groupData == nil {
+		return group, fmt.Errorf("can't find group %s", groupId)
+	}
+
+	err = json.Unmarshal(groupData, &group)
+
+	return
+}
+
+// returns an error if any of the groups do not exist
+func groupsExistRx(tx *bolt.Tx, groupIds []string) (err error) {
+	for _, groupId := range groupIds {
+		_, err = getGroupRx(tx, groupId)
+		if err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+func postGroup(db *bolt.DB, group openapi.Group, ownerId string) (response openapi.Group, err error) {
+	err = db.Update(func(tx *bolt.Tx) error {
+		response, err = postGroupTx(tx, group, ownerId)
+		return err
+	})
+
+	return
+}
+
+func postGroupTx(tx *bolt.Tx, group openapi.Group, ownerId string) (response openapi.Group, err error) {
+	groupsBucket, err := tx.CreateBucketIfNotExists([]byte(KeyGroups))
+	if err != nil {
+		return
+	}
+
+	id := RandomString(8)
+	for groupsBucket.Get([]byte(id)) != nil {
+		id = RandomString(8)
+	}
+
+	response = openapi.Group{
+		Id:      id,
+		Name:    group.Name,
+		Owner:   ownerId,
+		Members: group.Members,
+	}
+
+	err = putGroupTx(groupsBucket, response)
+
+	return
+}
+
+func putGroupTx(groupsBucket *bolt.Bucket, group openapi.Group) (err error) {
+	marshal, err := json.Marshal(group)
+	if err != nil {
+		return
+	}
+
+	return groupsBucket.Put([]byte(group.Id), marshal)
+}
+
+func updateGroup(db *bolt.DB, group openapi.Group) (response openapi.Group, err error) {
+	err = db.Update(func(tx *bolt.Tx) error {
+		response, err = updateGroupTx(tx, group)
+		return err
+	})
+
+	return
+}
+
+// changes the name and members of a group, the owner stays the same
+func updateGroupTx(tx *bolt.Tx, group openapi.Group) (response openapi.Group, err error) {
+	response, err = getGroupRx(tx, group.Id)
+	if err != nil {
+		return
+	}
+
+	response.Name = group.Name
+	response.Members = group.Members
+
+	err = putGroupTx(tx.Bucket([]byte(KeyGroups)), response)
+
+	return
+}
+
+func deleteGroup(db *bolt.DB, groupId string) (err error) {
+	err = db.Update(func(tx *bolt.Tx) error {
+		err = deleteGroupTx(tx, groupId)
+		return err
+	})
+
+	return
+}
+
+// deletes the group and removes it from the topics it was allowed in
+func deleteGroupTx(tx *bolt.Tx, groupId string) (err error) {
+	_, err = getGroupRx(tx, groupId)
+	if err != nil {
+		return
+	}
+
+	topicsBucket := tx.Bucket([]byte(KeyTopics))
+	if topicsBucket != nil {
+		c := topicsBucket.Cursor()
+		for k, _ := c.First(); k != nil; k, _ = c.Next() {
+			info, err := getTopicInfoRx(tx, string(k))
+			if err != nil {
+				return err
+			}
+
+			if !contains(info.AllowedGroups, groupId) {
+				continue
+			}
+
+			remaining := make([]string, 0, len(info.AllowedGroups))
+			for _, id := range info.AllowedGroups {
+				if id != groupId {
+					remaining = append(remaining, id)
+				}
+			}
+			info.AllowedGroups = remaining
+
+			err = putTopicInfoTx(tx, info)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return tx.Bucket([]byte(KeyGroups)).Delete([]byte(groupId))
+}
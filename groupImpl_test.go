@@ -0,0 +1,66 @@
+package main
+
+import (
+	"testing"
+
+	openapi "github.com/SpyLime/flowBackend/go"
+	"github.com/go-pkgz/lgr"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostUpdateDeleteGroupImpl(t *testing.T) {
+
+	lgr.Printf("INFO TestPostUpdateDeleteGroupImpl")
+	t.Log("INFO TestPostUpdateDeleteGroupImpl")
+	clock := TestClock{}
+	db, dbTearDown := OpenTestDB("PostUpdateDeleteGroupImpl")
+	defer dbTearDown()
+
+	users, topics, _, err := CreateTestData(db, &clock, 3, 1, 0)
+	require.Nil(t, err)
+
+	// making groups takes the reputation of making topics
+	err = UpdateUserRoleAndReputation(db, users[1], false, 0)
+	require.Nil(t, err)
+
+	student, err := getUser(db, users[1])
+	require.Nil(t, err)
+	require.NotNil(t, DefaultPolicy().check(db, &clock, KeyActionCreateGroup, student, PolicyTarget{}))
+
+	err = UpdateUserRoleAndReputation(db, users[1], false, KeyReputationDeleter)
+	require.Nil(t, err)
+
+	student, err = getUser(db, users[1])
+	require.Nil(t, err)
+	require.Nil(t, DefaultPolicy().check(db, &clock, KeyActionCreateGroup, student, PolicyTarget{}))
+
+	group, err := postGroup(db, openapi.Group{Name: "class"}, users[0])
+	require.Nil(t, err)
+	require.Equal(t, users[0], group.Owner)
+
+	memberGroups, err := getGroups(db, users[1])
+	require.Nil(t, err)
+	require.Equal(t, 0, len(memberGroups))
+
+	updated, err := updateGroup(db, openapi.Group{Id: group.Id, Name: "renamed", Owner: users[1], Members: []string{users[1]}})
+	require.Nil(t, err)
+	require.Equal(t, "renamed", updated.Name)
+	require.Equal(t, users[0], updated.Owner)
+
+	memberGroups, err = getGroups(db, users[1])
+	require.Nil(t, err)
+	require.Equal(t, 1, len(memberGroups))
+
+	err = updateTopic(db, openapi.Topic{Title: topics[0], Visibility: KeyVisibilityGroup, AllowedGroups: []string{group.Id}})
+	require.Nil(t, err)
+
+	err = deleteGroup(db, group.Id)
+	require.Nil(t, err)
+
+	info, err := getTopicInfo(db, topics[0])
+	require.Nil(t, err)
+	require.Empty(t, info.AllowedGroups)
+
+	_, err = getGroup(db, group.Id)
+	require.NotNil(t, err)
+}
@@ -0,0 +1,407 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	openapi "github.com/SpyLime/flowBackend/go"
+	bolt "go.etcd.io/bbolt"
+)
+
+// leaderboards keep totals as contributions happen so a leaderboard never goes through the users
+//
+//	leaderboards
+//	    global
+//	        all     user -> totals
+//	        days    day -> user -> totals
+//	        videos  user -> links that got an up vote
+//	    topics
+//	        topic1  same as global
+//
+// only the days a window can reach are kept
+
+func leaderboardDay(at time.Time) string {
+	return at.UTC().Format("2006-01-02")
+}
+
+// the buckets of the global leaderboard or of a topic, made when missing
+func leaderboardScopeTx(tx *bolt.Tx, topicId string) (scopeBucket *bolt.Bucket, err error) {
+	leaderboardsBucket, err := tx.CreateBucketIfNotExists([]byte(KeyLeaderboards))
+	if err != nil {
+		return
+	}
+
+	if topicId == "" {
+		return leaderboardsBucket.CreateBucketIfNotExists([]byte(KeyLeaderboardGlobal))
+	}
+
+	topicsBucket, err := leaderboardsBucket.CreateBucketIfNotExists([]byte(KeyTopics))
+	if err != nil {
+		return
+	}
+
+	return topicsBucket.CreateBucketIfNotExists([]byte(topicId))
+}
+
+func leaderboardScopeRx(tx *bolt.Tx, topicId string) *bolt.Bucket {
+	leaderboardsBucket := tx.Bucket([]byte(KeyLeaderboards))
+	if leaderboardsBucket == nil {
+		return nil
+	}
+
+	if topicId == "" {
+		return leaderboardsBucket.Bucket([]byte(KeyLeaderboardGlobal))
+	}
+
+	topicsBucket := leaderboardsBucket.Bucket([]byte(KeyTopics))
+	if topicsBucket == nil {
+		return nil
+	}
+
+	return topicsBucket.Bucket([]byte(topicId))
+}
+
+// addContributionTx counts the contribution for the topic and, when global is set, for the global leaderboard
+func addContributionTx(tx *bolt.Tx, at time.Time, topicId, userId string, contribution openapi.LeaderboardEntry, global bool) (err error) {
+	if topicId != "" {
+		err = addScopeContributionTx(tx, at, topicId, userId, contribution)
+		if err != nil {
+			return
+		}
+	}
+
+	if global {
+		err = addScopeContributionTx(tx, at, "", userId, contribution)
+	}
+
+	return
+}
+
+func addScopeContributionTx(tx *bolt.Tx, at time.Time, topicId, userId string, contribution openapi.LeaderboardEntry) (err error) {
+	scopeBucket, err := leaderboardScopeTx(tx, topicId)
+	if err != nil {
+		return
+	}
+
+	allBucket, err := scopeBucket.CreateBucketIfNotExists([]byte(KeyLeaderboardAll))
+	if err != nil {
+		return
+	}
+
+	err = addToTotalsTx(allBucket, userId, contribution)
+	if err != nil {
+		return
+	}
+
+	daysBucket, err := scopeBucket.CreateBucketIfNotExists([]byte(KeyLeaderboardDays))
+	if err != nil {
+		return
+	}
+
+	dayBucket, err := daysBucket.CreateBucketIfNotExists([]byte(leaderboardDay(at)))
+	if err != nil {
+		return
+	}
+
+	err = addToTotalsTx(dayBucket, userId, contribution)
+	if err != nil {
+		return
+	}
+
+	return pruneLeaderboardDaysTx(daysBucket, at)
+}
+
+func addToTotalsTx(bucket *bolt.Bucket, userId string, contribution openapi.LeaderboardEntry) (err error) {
+	var totals openapi.LeaderboardEntry
+	if data := bucket.Get([]byte(userId)); data != nil {
+		err = json.Unmarshal(data, &totals)
+		if err != nil {
+			return
+		}
+	}
+
+	totals.UserId = userId
+	totals.Reputation += contribution.Reputation
+	totals.Nodes += contribution.Nodes
+	totals.Videos += contribution.Videos
+
+	marshal, err := json.Marshal(totals)
+	if err != nil {
+		return
+	}
+
+	err = bucket.Put([]byte(userId), marshal)
+
+	return
+}
+
+// days sort by their key so everything before the cutoff is at the start
+func pruneLeaderboardDaysTx(daysBucket *bolt.Bucket, at time.Time) (err error) {
+	cutoff := leaderboardDay(at.AddDate(0, 0, -KeyLeaderboardKeepDays))
+
+	old := make([][]byte, 0)
+	c := daysBucket.Cursor()
+	for k, _ := c.First(); k != nil && string(k) < cutoff; k, _ = c.Next() {
+		old = append(old, append([]byte{}, k...))
+	}
+
+	for _, day := range old {
+		err = daysBucket.DeleteBucket(day)
+		if err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// videoUpVotedTx counts a video of the user the first time it gets an up vote in the topic and globally
+func videoUpVotedTx(tx *bolt.Tx, at time.Time, topicId, userId, link string, global bool) (err error) {
+	scopes := []string{topicId}
+	if global {
+		scopes = append(scopes, "")
+	}
+
+	for _, scope := range scopes {
+		scopeBucket, err := leaderboardScopeTx(tx, scope)
+		if err != nil {
+			return err
+		}
+
+		videosBucket, err := scopeBucket.CreateBucketIfNotExists([]byte(KeyLeaderboardVideos))
+		if err != nil {
+			return err
+		}
+
+		userBucket, err := videosBucket.CreateBucketIfNotExists([]byte(userId))
+		if err != nil {
+			return err
+		}
+
+		if userBucket.Get([]byte(link)) != nil {
+			continue
+		}
+
+		err = userBucket.Put([]byte(link), []byte(leaderboardDay(at)))
+		if err != nil {
+			return err
+		}
+
+		err = addScopeContributionTx(tx, at, scope, userId, openapi.LeaderboardEntry{Videos: 1})
+		if err != nil {
+			return err
+		}
+	}
+
+	return
+}
+
+// the leaderboard of a topic goes with it, what was earned in it stays on the global one
+func deleteTopicLeaderboardTx(tx *bolt.Tx, topicId string) (err error) {
+	leaderboardsBucket := tx.Bucket([]byte(KeyLeaderboards))
+	if leaderboardsBucket == nil {
+		return
+	}
+
+	topicsBucket := leaderboardsBucket.Bucket([]byte(KeyTopics))
+	if topicsBucket == nil || topicsBucket.Bucket([]byte(topicId)) == nil {
+		return
+	}
+
+	return topicsBucket.DeleteBucket([]byte(topicId))
+}
+
+// getLeaderboard returns the top contributors of a topic or globally when topicId is empty, best first
+func getLeaderboard(db *bolt.DB, clock Clock, topicId, window string, limit int32) (response openapi.Leaderboard, err error) {
+	err = db.View(func(tx *bolt.Tx) error {
+		response, err = getLeaderboardRx(tx, clock, topicId, window, limit)
+		return err
+	})
+
+	return
+}
+
+func getLeaderboardRx(tx *bolt.Tx, clock Clock, topicId, window string, limit int32) (response openapi.Leaderboard, err error) {
+	if window == "" {
+		window = KeyWindowAll
+	}
+
+	days := 0
+	switch window {
+	case KeyWindowAll:
+	case KeyWindowMonth:
+		days = 30
+	case KeyWindowWeek:
+		days = 7
+	default:
+		return response, fmt.Errorf("invalid window %s", window)
+	}
+
+	if limit <= 0 {
+		limit = KeyLeaderboardLimit
+	}
+	if limit > KeyLeaderboardMaxLimit {
+		limit = KeyLeaderboardMaxLimit
+	}
+
+	response.Topic = topicId
+	response.Window = window
+	response.Entries = make([]openapi.LeaderboardEntry, 0)
+
+	scopeBucket := leaderboardScopeRx(tx, topicId)
+	if scopeBucket == nil {
+		return
+	}
+
+	totals := make(map[string]openapi.LeaderboardEntry)
+	if days == 0 {
+		err = sumTotalsRx(scopeBucket.Bucket([]byte(KeyLeaderboardAll)), totals)
+		if err != nil {
+			return
+		}
+	} else if daysBucket := scopeBucket.Bucket([]byte(KeyLeaderboardDays)); daysBucket != nil {
+		// today is the last day of the window
+		from := leaderboardDay(clock.Now().AddDate(0, 0, 1-days))
+
+		c := daysBucket.Cursor()
+		for k, _ := c.Seek([]byte(from)); k != nil; k, _ = c.Next() {
+			err = sumTotalsRx(daysBucket.Bucket(k), totals)
+			if err != nil {
+				return
+			}
+		}
+	}
+
+	entries := make([]openapi.LeaderboardEntry, 0, len(totals))
+	for _, entry := range totals {
+		entry.Score = entry.Reputation + entry.Nodes*KeyLeaderboardNodeScore + entry.Videos*KeyLeaderboardVideoScore
+		entries = append(entries, entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Score != entries[j].Score {
+			return entries[i].Score > entries[j].Score
+		}
+		return entries[i].UserId < entries[j].UserId
+	})
+
+	for _, entry := range entries {
+		if int32(len(response.Entries)) >= limit {
+			break
+		}
+
+		// deleted users drop off
+		user, err := getUserRx(tx, entry.UserId)
+		if err != nil {
+			continue
+		}
+
+		entry.Username = user.Username
+		entry.Rank = int32(len(response.Entries)) + 1
+		response.Entries = append(response.Entries, entry)
+	}
+
+	return
+}
+
+func sumTotalsRx(bucket *bolt.Bucket, totals map[string]openapi.LeaderboardEntry) (err error) {
+	if bucket == nil {
+		return
+	}
+
+	err = bucket.ForEach(func(k, v []byte) error {
+		var entry openapi.LeaderboardEntry
+		err := json.Unmarshal(v, &entry)
+		if err != nil {
+			return err
+		}
+
+		total := totals[entry.UserId]
+		total.UserId = entry.UserId
+		total.Reputation += entry.Reputation
+		total.Nodes += entry.Nodes
+		total.Videos += entry.Videos
+		totals[entry.UserId] = total
+
+		return nil
+	})
+
+	return
+}
+
+// buildLeaderboardsTx counts everything from before leaderboards were kept, it does nothing once they exist
+func buildLeaderboardsTx(tx *bolt.Tx) (err error) {
+	if tx.Bucket([]byte(KeyLeaderboards)) != nil {
+		return
+	}
+
+	_, err = tx.CreateBucket([]byte(KeyLeaderboards))
+	if err != nil {
+		return
+	}
+
+	// nodes are named after when they were made
+	usersBucket := tx.Bucket([]byte(KeyUsers))
+	if usersBucket != nil {
+		err = usersBucket.ForEach(func(k, v []byte) error {
+			var user openapi.User
+			err := json.Unmarshal(v, &user)
+			if err != nil {
+				return err
+			}
+
+			for _, created := range user.Created {
+				err = addContributionTx(tx, created.NodeId, created.Topic, string(k), openapi.LeaderboardEntry{Nodes: 1}, true)
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			return
+		}
+	}
+
+	historyBucket := tx.Bucket([]byte(KeyReputationHistory))
+	if historyBucket == nil {
+		return
+	}
+
+	return historyBucket.ForEach(func(userId, _ []byte) error {
+		userBucket := historyBucket.Bucket(userId)
+		if userBucket == nil {
+			return nil
+		}
+
+		return userBucket.ForEach(func(k, v []byte) error {
+			var event openapi.ReputationEvent
+			err := json.Unmarshal(v, &event)
+			if err != nil {
+				return err
+			}
+
+			return countReputationEventTx(tx, event)
+		})
+	})
+}
+
+// countReputationEventTx adds a change of reputation to the leaderboards,
+// reputation in an organization only counts for the topic as it isn't part of the reputation of the user
+func countReputationEventTx(tx *bolt.Tx, event openapi.ReputationEvent) (err error) {
+	global := event.OrganizationId == ""
+
+	err = addContributionTx(tx, event.CreatedAt, event.Topic, event.UserId, openapi.LeaderboardEntry{Reputation: event.Delta}, global)
+	if err != nil {
+		return
+	}
+
+	if event.Source == KeyVoteVideo && event.Delta > 0 && event.Link != "" && event.Topic != "" {
+		err = videoUpVotedTx(tx, event.CreatedAt, event.Topic, event.UserId, event.Link, global)
+	}
+
+	return
+}
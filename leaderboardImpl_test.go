@@ -0,0 +1,157 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	openapi "github.com/SpyLime/flowBackend/go"
+	"github.com/go-pkgz/lgr"
+	"github.com/stretchr/testify/require"
+	bolt "go.etcd.io/bbolt"
+)
+
+func TestLeaderboardImpl(t *testing.T) {
+
+	lgr.Printf("INFO TestLeaderboardImpl")
+	t.Log("INFO TestLeaderboardImpl")
+	clock := TestClock{}
+	db, dbTearDown := OpenTestDB("LeaderboardImpl")
+	defer dbTearDown()
+
+	users, topics, nodesAndEdges, err := CreateTestData(db, &clock, 3, 2, 2)
+	require.Nil(t, err)
+
+	info, err := getTopicInfo(db, topics[0])
+	require.Nil(t, err)
+
+	creator := info.CreatedBy
+	var others []string
+	for _, id := range users {
+		if id != creator {
+			others = append(others, id)
+		}
+	}
+	voter, finder := others[0], others[1]
+
+	// topics are sorted but their nodes aren't
+	root, nodeId := nodesAndEdges[0].SourceId, nodesAndEdges[1].TargetId
+	_, err = getNode(db, nodeId.Format(time.RFC3339Nano), topics[0])
+	if err != nil {
+		root, nodeId = nodesAndEdges[3].SourceId, nodesAndEdges[4].TargetId
+	}
+
+	// the creator made a root node and two nodes in each topic
+	clock.TickOne(time.Hour)
+	_, err = updateNodeBattleVote(db, &clock, openapi.NodeData{Id: nodeId, Topic: topics[0], BattleTested: 1}, voter)
+	require.Nil(t, err)
+
+	// only the first up vote counts the video
+	link := "https://www.youtube.com/watch?v=dQw4w9WgXcQ"
+	for _, voterId := range []string{voter, "ghost1", "ghost2"} {
+		clock.Tick()
+		err = db.Update(func(tx *bolt.Tx) error {
+			return voteReputationTx(tx, &clock, openapi.VoteRecord{VoterId: voterId, CreatorId: finder, Topic: topics[0], NodeId: nodeId, Link: link, Kind: KeyVoteVideo, Value: 1})
+		})
+		require.Nil(t, err)
+	}
+
+	board, err := getLeaderboard(db, &clock, topics[0], KeyWindowAll, 0)
+	require.Nil(t, err)
+	require.Equal(t, 2, len(board.Entries))
+	require.Equal(t, openapi.LeaderboardEntry{Rank: 1, UserId: finder, Username: board.Entries[0].Username, Score: 3 + KeyLeaderboardVideoScore, Reputation: 3, Videos: 1}, board.Entries[0])
+	require.Equal(t, creator, board.Entries[1].UserId)
+	require.Equal(t, int32(3), board.Entries[1].Nodes)
+	require.Equal(t, int32(1), board.Entries[1].Reputation)
+	require.NotEmpty(t, board.Entries[1].Username)
+
+	board, err = getLeaderboard(db, &clock, "", KeyWindowAll, 0)
+	require.Nil(t, err)
+	require.Equal(t, creator, board.Entries[0].UserId)
+	require.Equal(t, int32(6), board.Entries[0].Nodes)
+	require.Equal(t, int32(1+6*KeyLeaderboardNodeScore), board.Entries[0].Score)
+
+	board, err = getLeaderboard(db, &clock, "", KeyWindowAll, 1)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(board.Entries))
+
+	// only what is inside the window counts
+	clock.TickOne(10 * 24 * time.Hour)
+	_, err = postNode(db, &clock, openapi.NodeData{Id: root, Topic: topics[0], CreatedBy: openapi.UserIdentifier{Id: voter}})
+	require.Nil(t, err)
+
+	board, err = getLeaderboard(db, &clock, topics[0], KeyWindowWeek, 0)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(board.Entries))
+	require.Equal(t, voter, board.Entries[0].UserId)
+	require.Equal(t, int32(KeyLeaderboardNodeScore), board.Entries[0].Score)
+
+	board, err = getLeaderboard(db, &clock, topics[0], KeyWindowMonth, 0)
+	require.Nil(t, err)
+	require.Equal(t, 3, len(board.Entries))
+
+	_, err = getLeaderboard(db, &clock, topics[0], "1y", 0)
+	require.NotNil(t, err)
+
+	board, err = getLeaderboard(db, &clock, "missing", KeyWindowAll, 0)
+	require.Nil(t, err)
+	require.Equal(t, 0, len(board.Entries))
+
+	// days out of every window are dropped on the next contribution
+	clock.TickOne(40 * 24 * time.Hour)
+	_, err = postNode(db, &clock, openapi.NodeData{Id: root, Topic: topics[0], CreatedBy: openapi.UserIdentifier{Id: voter}})
+	require.Nil(t, err)
+
+	days := 0
+	err = db.View(func(tx *bolt.Tx) error {
+		return leaderboardScopeRx(tx, "").Bucket([]byte(KeyLeaderboardDays)).ForEach(func(k, v []byte) error {
+			days++
+			return nil
+		})
+	})
+	require.Nil(t, err)
+	require.Equal(t, 1, days)
+
+	board, err = getLeaderboard(db, &clock, topics[0], KeyWindowMonth, 0)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(board.Entries))
+
+	// a database from before leaderboards gets the same all time totals
+	before, err := getLeaderboard(db, &clock, topics[0], KeyWindowAll, 0)
+	require.Nil(t, err)
+	beforeGlobal, err := getLeaderboard(db, &clock, "", KeyWindowAll, 0)
+	require.Nil(t, err)
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		return tx.DeleteBucket([]byte(KeyLeaderboards))
+	})
+	require.Nil(t, err)
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		return buildLeaderboardsTx(tx)
+	})
+	require.Nil(t, err)
+
+	after, err := getLeaderboard(db, &clock, topics[0], KeyWindowAll, 0)
+	require.Nil(t, err)
+	require.Equal(t, before, after)
+
+	after, err = getLeaderboard(db, &clock, "", KeyWindowAll, 0)
+	require.Nil(t, err)
+	require.Equal(t, beforeGlobal, after)
+
+	// deleted users and topics drop off
+	err = deleteUser(db, finder)
+	require.Nil(t, err)
+
+	board, err = getLeaderboard(db, &clock, topics[0], KeyWindowAll, 0)
+	require.Nil(t, err)
+	require.Equal(t, 2, len(board.Entries))
+	require.Equal(t, int32(2), board.Entries[1].Rank)
+
+	err = deleteTopic(db, topics[0])
+	require.Nil(t, err)
+
+	board, err = getLeaderboard(db, &clock, topics[0], KeyWindowAll, 0)
+	require.Nil(t, err)
+	require.Equal(t, 0, len(board.Entries))
+}
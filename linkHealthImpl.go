@@ -0,0 +1,398 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"time"
+
+	openapi "github.com/SpyLime/flowBackend/go"
+	"github.com/go-pkgz/lgr"
+	bolt "go.etcd.io/bbolt"
+)
+
+// LinkChecker finds out whether a link still works, tests use a stand-in that never goes out to the network
+type LinkChecker interface {
+	// Check returns one of the link statuses, unchecked for links the server may not reach.
+	// An empty status means the link can't be checked and is left as it is
+	Check(ctx context.Context, resource openapi.LinkData) string
+}
+
+// LinkHealthWorker checks the links of every node again once their last check is older than the interval
+type LinkHealthWorker struct {
+	db      *bolt.DB
+	clock   Clock
+	checker LinkChecker
+	config  LinkHealthConfig
+}
+
+// NewLinkHealthWorker uses the default interval when the one set isn't positive, a ticker can't run without one
+func NewLinkHealthWorker(db *bolt.DB, clock Clock, checker LinkChecker, config LinkHealthConfig) *LinkHealthWorker {
+	if config.Interval <= 0 {
+		lgr.Printf("WARN link health interval %v isn't positive, using the default", config.Interval)
+		config.Interval = DefaultLinkHealthConfig().Interval
+	}
+
+	return &LinkHealthWorker{
+		db:      db,
+		clock:   clock,
+		checker: checker,
+		config:  config,
+	}
+}
+
+// a link that is due and what the checker found
+type linkCheck struct {
+	topicId  string
+	nodeId   string
+	resource openapi.LinkData
+	status   string
+}
+
+// Run checks the links that are due every interval until the context is done
+func (w *LinkHealthWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		checked, err := w.CheckLinks(ctx)
+		if err != nil {
+			lgr.Printf("WARN link health check failed: %v", err)
+		} else {
+			lgr.Printf("INFO checked %d links", checked)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// CheckLinks checks every link that is due. The links are checked outside of any transaction
+// so the database isn't held while waiting on the network, what was found is stored together at the end
+func (w *LinkHealthWorker) CheckLinks(ctx context.Context) (checked int, err error) {
+	// every link of a run counts as checked when the run started so the next run finds it due again
+	now := w.clock.Now()
+
+	var due []linkCheck
+	err = w.db.View(func(tx *bolt.Tx) error {
+		due, err = dueLinksRx(tx, now, w.config.Interval)
+		return err
+	})
+	if err != nil {
+		return
+	}
+
+	for i := range due {
+		// what was checked before the context was done is still stored
+		if ctx.Err() != nil {
+			break
+		}
+
+		due[i].status = w.checker.Check(ctx, due[i].resource)
+		if due[i].status != "" && due[i].status != KeyLinkUnchecked {
+			checked++
+		}
+	}
+
+	err = w.db.Update(func(tx *bolt.Tx) error {
+		return putLinkChecksTx(tx, now, w.config.HideAfter, due)
+	})
+
+	return
+}
+
+func dueLinksRx(tx *bolt.Tx, now time.Time, interval time.Duration) (due []linkCheck, err error) {
+	topicsBucket := tx.Bucket([]byte(KeyTopics))
+	if topicsBucket == nil {
+		return
+	}
+
+	err = topicsBucket.ForEach(func(topicId, v []byte) error {
+		if v != nil {
+			return nil
+		}
+
+		nodesBucket := topicsBucket.Bucket(topicId).Bucket([]byte(KeyNodes))
+		if nodesBucket == nil {
+			return nil
+		}
+
+		return nodesBucket.ForEach(func(nodeId, data []byte) error {
+			var node openapi.NodeData
+			err := json.Unmarshal(data, &node)
+			if err != nil {
+				return err
+			}
+
+			for _, resource := range node.Resources {
+				if resource.CheckedAt.IsZero() || !now.Before(resource.CheckedAt.Add(interval)) {
+					due = append(due, linkCheck{topicId: string(topicId), nodeId: string(nodeId), resource: resource})
+				}
+			}
+
+			return nil
+		})
+	})
+
+	return
+}
+
+// putLinkChecksTx stores what was found on the nodes as they are now, links removed during the checks are skipped
+func putLinkChecksTx(tx *bolt.Tx, now time.Time, hideAfter time.Duration, checks []linkCheck) (err error) {
+	type nodeKey struct{ topicId, nodeId string }
+	found := make(map[nodeKey]map[string]string)
+	order := make([]nodeKey, 0)
+	for _, check := range checks {
+		if check.status == "" {
+			continue
+		}
+
+		key := nodeKey{check.topicId, check.nodeId}
+		if found[key] == nil {
+			found[key] = make(map[string]string)
+			order = append(order, key)
+		}
+		found[key][check.resource.Link] = check.status
+	}
+
+	for _, key := range order {
+		nodesBucket, nodeData, err := nodeDataFinderTx(tx, key.topicId, key.nodeId)
+		if err != nil {
+			// the node was deleted while its links were checked
+			continue
+		}
+
+		var node openapi.NodeData
+		err = json.Unmarshal(nodeData, &node)
+		if err != nil {
+			return err
+		}
+
+		for i, resource := range node.Resources {
+			if status, ok := found[key][resource.Link]; ok {
+				node.Resources[i] = applyLinkStatus(resource, status, now, hideAfter)
+			}
+		}
+
+		marshal, err := json.Marshal(node)
+		if err != nil {
+			return err
+		}
+
+		err = nodesBucket.Put([]byte(key.nodeId), marshal)
+		if err != nil {
+			return err
+		}
+	}
+
+	return
+}
+
+func isDeadLink(status string) bool {
+	return status == KeyLinkRemoved || status == KeyLinkPrivate || status == KeyLinkRegionBlocked
+}
+
+// applyLinkStatus records the check and hides links that stayed dead past the grace period,
+// a link that works again is shown again unless a moderator hid it
+func applyLinkStatus(resource openapi.LinkData, status string, now time.Time, hideAfter time.Duration) openapi.LinkData {
+	resource.Status = status
+	resource.CheckedAt = now
+
+	// an unreachable link may be our own network, it neither starts nor ends the grace period
+	switch {
+	case status == KeyLinkOk:
+		resource.DeadSince = time.Time{}
+		if resource.IsHidden && resource.HiddenReason == KeyReasonDeadLink {
+			resource.IsHidden = false
+			resource.HiddenReason = ""
+		}
+	case isDeadLink(status) && resource.DeadSince.IsZero():
+		resource.DeadSince = now
+	}
+
+	if hideAfter > 0 && !resource.IsHidden && !resource.DeadSince.IsZero() && now.Sub(resource.DeadSince) >= hideAfter {
+		resource.IsHidden = true
+		resource.HiddenReason = KeyReasonDeadLink
+	}
+
+	return resource
+}
+
+func getDeadLinks(db *bolt.DB, topicId, viewerId string, moderator bool) (response []openapi.DeadLink, err error) {
+	err = db.View(func(tx *bolt.Tx) error {
+		response, err = getDeadLinksRx(tx, topicId, viewerId, moderator)
+		return err
+	})
+
+	return
+}
+
+// getDeadLinksRx returns every link of the topic that didn't work when it was last checked, in the order of their nodes
+func getDeadLinksRx(tx *bolt.Tx, topicId, viewerId string, moderator bool) (response []openapi.DeadLink, err error) {
+	topicsBucket := tx.Bucket([]byte(KeyTopics))
+	if topicsBucket == nil {
+		return nil, fmt.Errorf("can't find topics bucket")
+	}
+
+	topicBucket := topicsBucket.Bucket([]byte(topicId))
+	if topicBucket == nil {
+		return nil, fmt.Errorf("can't find topic bucket")
+	}
+
+	response = make([]openapi.DeadLink, 0)
+
+	nodesBucket := topicBucket.Bucket([]byte(KeyNodes))
+	if nodesBucket == nil {
+		return
+	}
+
+	err = nodesBucket.ForEach(func(_, data []byte) error {
+		var node openapi.NodeData
+		err := json.Unmarshal(data, &node)
+		if err != nil {
+			return err
+		}
+
+		if node.IsHidden && !moderator && (viewerId == "" || node.CreatedBy.Id != viewerId) {
+			return nil
+		}
+
+		for _, resource := range node.Resources {
+			if resource.Status == "" || resource.Status == KeyLinkOk || resource.Status == KeyLinkUnchecked {
+				continue
+			}
+
+			// links hidden for being dead are what the report is for, anything else hidden stays hidden
+			if resource.IsHidden && resource.HiddenReason != KeyReasonDeadLink && !moderator && (viewerId == "" || resource.AddedBy.Id != viewerId) {
+				continue
+			}
+
+			response = append(response, openapi.DeadLink{NodeId: node.Id, NodeTitle: node.Title, Resource: resource})
+		}
+
+		return nil
+	})
+
+	return
+}
+
+// httpLinkChecker asks the oEmbed endpoint of YouTube about videos and requests any other link
+type httpLinkChecker struct {
+	client *OutboundClient
+	oEmbed string
+	watch  string
+}
+
+func NewHTTPLinkChecker(client *OutboundClient) LinkChecker {
+	return &httpLinkChecker{
+		client: client,
+		oEmbed: "https://www.youtube.com/oembed",
+		watch:  "https://www.youtube.com/watch",
+	}
+}
+
+// the playability status a watch page starts with when the video isn't played in the country of the server,
+// the reason is either in the status or in the error screen right after it
+var regionBlockedPattern = regexp.MustCompile(`"playabilityStatus":\{"status":"UNPLAYABLE"[^\n]{0,1000}?available in your country`)
+
+// the playability status a watch page starts with when the video is private
+var privatePattern = regexp.MustCompile(`"playabilityStatus":\{"status":"LOGIN_REQUIRED"[^\n]{0,1000}?video is private`)
+
+func (c *httpLinkChecker) Check(ctx context.Context, resource openapi.LinkData) string {
+	link, err := parseResourceURL(resource.Link)
+	if err != nil {
+		return KeyLinkUnreachable
+	}
+
+	// links on hosts outside the allow list aren't checked
+	if c.client.allowed(link) != nil {
+		return KeyLinkUnchecked
+	}
+
+	if resource.Provider != KeyProviderYouTube || resource.VideoId == "" {
+		return c.checkPage(ctx, link.String())
+	}
+
+	// YouTube answers 401 for private videos and 404 for removed ones,
+	// videos that can't be embedded get a 401 as well so the watch page tells them apart
+	watch := VideoRef{Id: resource.VideoId}.Canonical()
+	code, _, err := c.request(ctx, c.oEmbed+"?format=json&url="+url.QueryEscape(watch), false)
+	if err != nil {
+		return KeyLinkUnreachable
+	}
+	if code == http.StatusBadRequest {
+		return KeyLinkRemoved
+	}
+	unauthorized := code == http.StatusUnauthorized
+	if code != http.StatusOK && !unauthorized {
+		return linkStatus(code)
+	}
+
+	// region blocks only show on the watch page, a page cut at the size limit counts as ok
+	code, page, err := c.request(ctx, c.watch+"?v="+url.QueryEscape(resource.VideoId), true)
+	if unauthorized {
+		if err != nil || code != http.StatusOK {
+			return KeyLinkUnreachable
+		}
+		if privatePattern.Match(page) {
+			return KeyLinkPrivate
+		}
+	}
+	if err != nil || code != http.StatusOK || !regionBlockedPattern.Match(page) {
+		return KeyLinkOk
+	}
+
+	return KeyLinkRegionBlocked
+}
+
+func (c *httpLinkChecker) checkPage(ctx context.Context, link string) string {
+	code, _, err := c.request(ctx, link, false)
+	if err != nil {
+		return KeyLinkUnreachable
+	}
+
+	return linkStatus(code)
+}
+
+// request returns the status code and the body when it is read, at most MaxBytes of it
+func (c *httpLinkChecker) request(ctx context.Context, link string, read bool) (code int, body []byte, err error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, link, nil)
+	if err != nil {
+		return
+	}
+	request.Header.Set("User-Agent", KeyOutboundUserAgent)
+
+	response, err := c.client.Do(request)
+	if err != nil {
+		return
+	}
+	defer response.Body.Close()
+
+	if read {
+		body, err = io.ReadAll(io.LimitReader(response.Body, c.client.config.MaxBytes))
+	}
+
+	return response.StatusCode, body, err
+}
+
+func linkStatus(code int) string {
+	switch {
+	case code >= 200 && code < 400:
+		return KeyLinkOk
+	case code == http.StatusNotFound || code == http.StatusGone:
+		return KeyLinkRemoved
+	case code == http.StatusUnauthorized || code == http.StatusForbidden:
+		return KeyLinkPrivate
+	case code == http.StatusUnavailableForLegalReasons:
+		return KeyLinkRegionBlocked
+	}
+
+	return KeyLinkUnreachable
+}
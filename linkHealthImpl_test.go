@@ -0,0 +1,233 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	openapi "github.com/SpyLime/flowBackend/go"
+	"github.com/go-pkgz/lgr"
+	"github.com/stretchr/testify/require"
+	bolt "go.etcd.io/bbolt"
+)
+
+// a stand-in checker that answers from a map and counts what it is asked
+type standInLinkChecker struct {
+	statuses map[string]string
+	checks   map[string]int
+}
+
+func (c *standInLinkChecker) Check(_ context.Context, resource openapi.LinkData) string {
+	c.checks[resource.Link]++
+	return c.statuses[resource.Link]
+}
+
+func TestLinkHealthImpl(t *testing.T) {
+
+	lgr.Printf("INFO TestLinkHealthImpl")
+	t.Log("INFO TestLinkHealthImpl")
+	clock := TestClock{}
+	db, dbTearDown := OpenTestDB("LinkHealthImpl")
+	defer dbTearDown()
+
+	users, topics, nodesAndEdges, err := CreateTestData(db, &clock, 2, 1, 1)
+	require.Nil(t, err)
+
+	adder, err := getUser(db, users[0])
+	require.Nil(t, err)
+
+	nodeId := nodesAndEdges[1].TargetId
+	removed := "https://www.youtube.com/watch?v=dQw4w9WgXcQ"
+	working := "https://example.org/blog/ninjas"
+	offline := "https://example.org/offline"
+	unchecked := "https://example.com/elsewhere"
+	for _, link := range []string{removed, working, offline, unchecked} {
+		_, err = updateNodeVideoEdit(db, &clock, openapi.NodeData{Id: nodeId, Topic: topics[0], Resources: []openapi.LinkData{{Link: link, Votes: 1}}}, adder)
+		require.Nil(t, err)
+	}
+
+	checker := &standInLinkChecker{
+		statuses: map[string]string{removed: KeyLinkRemoved, working: KeyLinkOk, offline: KeyLinkUnreachable, unchecked: KeyLinkUnchecked},
+		checks:   make(map[string]int),
+	}
+	config := LinkHealthConfig{Interval: 24 * time.Hour, HideAfter: 72 * time.Hour}
+	worker := NewLinkHealthWorker(db, &clock, checker, config)
+
+	// a worker can't tick without an interval
+	require.Equal(t, DefaultLinkHealthConfig().Interval, NewLinkHealthWorker(db, &clock, checker, LinkHealthConfig{Interval: -time.Hour}).config.Interval)
+
+	checked, err := worker.CheckLinks(context.Background())
+	require.Nil(t, err)
+	require.Equal(t, 3, checked)
+
+	node, err := getNode(db, nodeId.Format(time.RFC3339Nano), topics[0])
+	require.Nil(t, err)
+	require.Equal(t, KeyLinkRemoved, node.Resources[0].Status)
+	require.Equal(t, clock.Now(), node.Resources[0].DeadSince)
+	require.Equal(t, KeyLinkOk, node.Resources[1].Status)
+	require.Equal(t, clock.Now(), node.Resources[1].CheckedAt)
+	require.True(t, node.Resources[2].DeadSince.IsZero())
+	require.Equal(t, KeyLinkUnchecked, node.Resources[3].Status)
+
+	// links are only checked again after the interval
+	clock.TickOne(time.Hour)
+	checked, err = worker.CheckLinks(context.Background())
+	require.Nil(t, err)
+	require.Equal(t, 0, checked)
+	require.Equal(t, 1, checker.checks[removed])
+
+	// links that stay dead past the grace period are hidden, unreachable ones never are
+	for i := 0; i < 3; i++ {
+		clock.TickOne(config.Interval)
+		_, err = worker.CheckLinks(context.Background())
+		require.Nil(t, err)
+	}
+	require.Equal(t, 4, checker.checks[removed])
+
+	node, err = getNode(db, nodeId.Format(time.RFC3339Nano), topics[0])
+	require.Nil(t, err)
+	require.True(t, node.Resources[0].IsHidden)
+	require.Equal(t, KeyReasonDeadLink, node.Resources[0].HiddenReason)
+	require.False(t, node.Resources[2].IsHidden)
+
+	shown, _ := hideNodeContent(node, users[1], false)
+	require.Empty(t, shown.YoutubeLinks)
+
+	// restoring the node after moderation leaves dead links hidden
+	err = db.Update(func(tx *bolt.Tx) error {
+		return restoreNodeTx(tx, topics[0], nodeId)
+	})
+	require.Nil(t, err)
+
+	node, err = getNode(db, nodeId.Format(time.RFC3339Nano), topics[0])
+	require.Nil(t, err)
+	require.True(t, node.Resources[0].IsHidden)
+	require.Equal(t, KeyReasonDeadLink, node.Resources[0].HiddenReason)
+
+	// the report shows dead links to anyone who sees the topic, hidden or not
+	report, err := getDeadLinks(db, topics[0], users[1], false)
+	require.Nil(t, err)
+	require.Equal(t, 2, len(report))
+	require.Equal(t, removed, report[0].Resource.Link)
+	require.Equal(t, nodeId, report[0].NodeId)
+	require.Equal(t, offline, report[1].Resource.Link)
+
+	// a link that works again is shown again
+	checker.statuses[removed] = KeyLinkOk
+	clock.TickOne(config.Interval)
+	_, err = worker.CheckLinks(context.Background())
+	require.Nil(t, err)
+
+	node, err = getNode(db, nodeId.Format(time.RFC3339Nano), topics[0])
+	require.Nil(t, err)
+	require.False(t, node.Resources[0].IsHidden)
+	require.True(t, node.Resources[0].DeadSince.IsZero())
+
+	shown, _ = hideNodeContent(node, users[1], false)
+	require.Equal(t, 1, len(shown.YoutubeLinks))
+
+	report, err = getDeadLinks(db, topics[0], users[1], false)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(report))
+
+	// links hidden by moderation aren't shown past it
+	err = db.Update(func(tx *bolt.Tx) error {
+		nodesBucket, nodeData, err := nodeDataFinderTx(tx, topics[0], nodeId.Format(time.RFC3339Nano))
+		if err != nil {
+			return err
+		}
+
+		var stored openapi.NodeData
+		err = json.Unmarshal(nodeData, &stored)
+		if err != nil {
+			return err
+		}
+
+		stored.Resources[2].IsHidden = true
+		stored.Resources[2].HiddenReason = KeyReasonSpam
+
+		marshal, err := json.Marshal(stored)
+		if err != nil {
+			return err
+		}
+
+		return nodesBucket.Put([]byte(nodeId.Format(time.RFC3339Nano)), marshal)
+	})
+	require.Nil(t, err)
+
+	report, err = getDeadLinks(db, topics[0], users[1], false)
+	require.Nil(t, err)
+	require.Empty(t, report)
+
+	report, err = getDeadLinks(db, topics[0], users[1], true)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(report))
+}
+
+// a local stand-in for YouTube and the pages links point to
+func newLinkStandIn() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/oembed":
+			switch {
+			case strings.Contains(r.URL.Query().Get("url"), "privateVide"), strings.Contains(r.URL.Query().Get("url"), "noEmbedVide"):
+				w.WriteHeader(http.StatusUnauthorized)
+			case strings.Contains(r.URL.Query().Get("url"), "removedVide"):
+				w.WriteHeader(http.StatusNotFound)
+			default:
+				fmt.Fprint(w, `{"title":"a video"}`)
+			}
+		case "/watch":
+			switch r.URL.Query().Get("v") {
+			case "regionVide":
+				fmt.Fprint(w, `{"playabilityStatus":{"status":"UNPLAYABLE","reason":"Video unavailable","errorScreen":{"playerErrorMessageRenderer":{"subreason":{"simpleText":"The uploader has not made this video available in your country"}}}}}`)
+			case "privateVide":
+				fmt.Fprint(w, `{"playabilityStatus":{"status":"LOGIN_REQUIRED","messages":["This is a private video. Please sign in to verify that you may see it."],"reason":"This video is private"}}`)
+			default:
+				fmt.Fprint(w, `{"playabilityStatus":{"status":"OK"},"countryCode":"US","availableCountries":["US"]}`)
+			}
+		case "/gone":
+			w.WriteHeader(http.StatusGone)
+		case "/legal":
+			w.WriteHeader(http.StatusUnavailableForLegalReasons)
+		case "/broken":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			fmt.Fprint(w, "ok")
+		}
+	}))
+}
+
+func TestHTTPLinkChecker(t *testing.T) {
+	server := newLinkStandIn()
+	defer server.Close()
+
+	checker := NewHTTPLinkChecker(newStandInClient(server, DefaultOutboundConfig())).(*httpLinkChecker)
+	checker.oEmbed = server.URL + "/oembed"
+	checker.watch = server.URL + "/watch"
+
+	tests := []struct {
+		resource openapi.LinkData
+		status   string
+	}{
+		{openapi.LinkData{Link: server.URL + "/page"}, KeyLinkOk},
+		{openapi.LinkData{Link: server.URL + "/gone"}, KeyLinkRemoved},
+		{openapi.LinkData{Link: server.URL + "/legal"}, KeyLinkRegionBlocked},
+		{openapi.LinkData{Link: server.URL + "/broken"}, KeyLinkUnreachable},
+		{openapi.LinkData{Link: "https://www.youtube.com/watch?v=privateVide", Provider: KeyProviderYouTube, VideoId: "privateVide"}, KeyLinkPrivate},
+		{openapi.LinkData{Link: "https://www.youtube.com/watch?v=noEmbedVide", Provider: KeyProviderYouTube, VideoId: "noEmbedVide"}, KeyLinkOk},
+		{openapi.LinkData{Link: "https://www.youtube.com/watch?v=removedVide", Provider: KeyProviderYouTube, VideoId: "removedVide"}, KeyLinkRemoved},
+		{openapi.LinkData{Link: "https://www.youtube.com/watch?v=regionVide", Provider: KeyProviderYouTube, VideoId: "regionVide"}, KeyLinkRegionBlocked},
+		{openapi.LinkData{Link: "https://www.youtube.com/watch?v=playedVide", Provider: KeyProviderYouTube, VideoId: "playedVide"}, KeyLinkOk},
+		{openapi.LinkData{Link: "https://example.org/blog/ninjas"}, KeyLinkUnchecked},
+	}
+
+	for _, test := range tests {
+		require.Equal(t, test.status, checker.Check(context.Background(), test.resource), test.resource.Link)
+	}
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	openapi "github.com/SpyLime/flowBackend/go"
+	bolt "go.etcd.io/bbolt"
+)
+
+// lock a node or the whole topic when the lock has no node
+func putLock(db *bolt.DB, clock Clock, topicId string, request openapi.Lock) (response openapi.Lock, err error) {
+	err = db.Update(func(tx *bolt.Tx) error {
+		response, err = putLockTx(tx, clock, topicId, request)
+		return err
+	})
+
+	return
+}
+
+func putLockTx(tx *bolt.Tx, clock Clock, topicId string, request openapi.Lock) (response openapi.Lock, err error) {
+	if request.Reason == "" {
+		return response, fmt.Errorf("a lock needs a reason")
+	}
+
+	if !request.Until.IsZero() && !request.Until.After(clock.Now()) {
+		return response, fmt.Errorf("the lock would already be over")
+	}
+
+	response = request
+	response.CreatedAt = clock.Now()
+
+	err = setLockTx(tx, topicId, request.NodeId, response)
+
+	return
+}
+
+// unlock a node or the whole topic when nodeId is zero
+func deleteLock(db *bolt.DB, topicId string, nodeId time.Time) (err error) {
+	err = db.Update(func(tx *bolt.Tx) error {
+		return setLockTx(tx, topicId, nodeId, openapi.Lock{})
+	})
+
+	return
+}
+
+func setLockTx(tx *bolt.Tx, topicId string, nodeId time.Time, lock openapi.Lock) (err error) {
+	if nodeId.IsZero() {
+		info, err := getTopicInfoRx(tx, topicId)
+		if err != nil {
+			return err
+		}
+
+		info.Lock = lock
+
+		return putTopicInfoTx(tx, info)
+	}
+
+	nodesBucket, nodeData, err := nodeDataFinderTx(tx, topicId, nodeId.Format(time.RFC3339Nano))
+	if err != nil {
+		return
+	}
+
+	var node openapi.NodeData
+	err = json.Unmarshal(nodeData, &node)
+	if err != nil {
+		return
+	}
+
+	node.Lock = lock
+
+	marshal, err := json.Marshal(node)
+	if err != nil {
+		return
+	}
+
+	err = nodesBucket.Put([]byte(nodeId.Format(time.RFC3339Nano)), marshal)
+
+	return
+}
+
+// checkLock returns why the user can't change the topic or its nodes, votes are only blocked when the lock says so
+func checkLock(db *bolt.DB, clock Clock, user openapi.User, topicId string, vote bool, nodeIds ...time.Time) (err error) {
+	_ = db.View(func(tx *bolt.Tx) error {
+		err = checkLockRx(tx, clock, user, topicId, vote, nodeIds...)
+		return nil
+	})
+
+	return
+}
+
+func checkLockRx(tx *bolt.Tx, clock Clock, user openapi.User, topicId string, vote bool, nodeIds ...time.Time) error {
+	// admins and maintainers are the ones fixing the content
+	if user.Role == KeyAdmin || hasTopicRightRx(tx, topicId, user.Id, KeyRightEdit) {
+		return nil
+	}
+
+	info, err := getTopicInfoRx(tx, topicId)
+	if err != nil {
+		return err
+	}
+
+	if lockActive(clock, info.Lock, vote) {
+		return lockError("topic", info.Lock)
+	}
+
+	for _, nodeId := range nodeIds {
+		node, err := getNodeRx(tx, nodeId.Format(time.RFC3339Nano), topicId)
+		if err != nil {
+			// missing nodes are reported by whatever is done to them
+			continue
+		}
+
+		if lockActive(clock, node.Lock, vote) {
+			return lockError("node", node.Lock)
+		}
+	}
+
+	return nil
+}
+
+// an expired lock is over without anyone removing it
+func lockActive(clock Clock, lock openapi.Lock, vote bool) bool {
+	if lock.CreatedAt.IsZero() || (vote && !lock.Votes) {
+		return false
+	}
+
+	return lock.Until.IsZero() || lock.Until.After(clock.Now())
+}
+
+func lockError(kind string, lock openapi.Lock) error {
+	if lock.Until.IsZero() {
+		return fmt.Errorf("%s is locked: %s", kind, lock.Reason)
+	}
+
+	return fmt.Errorf("%s is locked until %s: %s", kind, lock.Until.Format("2006-01-02 15:04 MST"), lock.Reason)
+}
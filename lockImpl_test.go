@@ -0,0 +1,107 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	openapi "github.com/SpyLime/flowBackend/go"
+	"github.com/go-pkgz/lgr"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLockImpl(t *testing.T) {
+
+	lgr.Printf("INFO TestLockImpl")
+	t.Log("INFO TestLockImpl")
+	clock := TestClock{}
+	db, dbTearDown := OpenTestDB("LockImpl")
+	defer dbTearDown()
+
+	users, topics, nodesAndEdges, err := CreateTestData(db, &clock, 3, 1, 1)
+	require.Nil(t, err)
+
+	info, err := getTopicInfo(db, topics[0])
+	require.Nil(t, err)
+
+	// the creator of the topic is an owner and can always edit
+	var others []string
+	for _, id := range users {
+		if id != info.CreatedBy {
+			others = append(others, id)
+		}
+	}
+	outsider, maintainer := others[0], others[1]
+
+	err = UpdateUserRoleAndReputation(db, outsider, false, KeyReputationEditor)
+	require.Nil(t, err)
+	err = UpdateUserRoleAndReputation(db, maintainer, false, 0)
+	require.Nil(t, err)
+	_, err = updateTopicRole(db, topics[0], openapi.TopicRole{UserId: maintainer, Role: KeyTopicMaintainer})
+	require.Nil(t, err)
+
+	outsiderDetails, err := getUserForTopic(db, outsider, topics[0])
+	require.Nil(t, err)
+	maintainerDetails, err := getUserForTopic(db, maintainer, topics[0])
+	require.Nil(t, err)
+	admin, err := getUserForTopic(db, info.CreatedBy, topics[0])
+	require.Nil(t, err)
+
+	nodeId := nodesAndEdges[0].SourceId
+
+	err = checkLock(db, &clock, outsiderDetails, topics[0], false, nodeId)
+	require.Nil(t, err)
+
+	_, err = putLock(db, &clock, topics[0], openapi.Lock{})
+	require.NotNil(t, err)
+
+	_, err = putLock(db, &clock, topics[0], openapi.Lock{Reason: "exam week", Until: clock.Now()})
+	require.NotNil(t, err)
+
+	// a topic lock without votes still lets everyone vote
+	lock, err := putLock(db, &clock, topics[0], openapi.Lock{Reason: "exam week", Until: clock.Now().Add(time.Hour)})
+	require.Nil(t, err)
+	require.False(t, lock.CreatedAt.IsZero())
+
+	err = checkLock(db, &clock, outsiderDetails, topics[0], false, nodeId)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "exam week")
+
+	err = checkLock(db, &clock, outsiderDetails, topics[0], true, nodeId)
+	require.Nil(t, err)
+
+	err = checkLock(db, &clock, maintainerDetails, topics[0], false, nodeId)
+	require.Nil(t, err)
+
+	err = checkLock(db, &clock, admin, topics[0], false, nodeId)
+	require.Nil(t, err)
+
+	// the lock is over once it expires
+	clock.TickOne(2 * time.Hour)
+
+	err = checkLock(db, &clock, outsiderDetails, topics[0], false, nodeId)
+	require.Nil(t, err)
+
+	// a node lock only blocks that node
+	_, err = putLock(db, &clock, topics[0], openapi.Lock{NodeId: nodeId, Reason: "vandalism", Votes: true})
+	require.Nil(t, err)
+
+	err = checkLock(db, &clock, outsiderDetails, topics[0], true, nodeId)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "vandalism")
+
+	err = checkLock(db, &clock, outsiderDetails, topics[0], false, nodesAndEdges[0].TargetId)
+	require.Nil(t, err)
+
+	node, err := getNode(db, nodeId.Format(time.RFC3339Nano), topics[0])
+	require.Nil(t, err)
+	require.Equal(t, "vandalism", node.Lock.Reason)
+
+	err = deleteLock(db, topics[0], nodeId)
+	require.Nil(t, err)
+
+	err = checkLock(db, &clock, outsiderDetails, topics[0], true, nodeId)
+	require.Nil(t, err)
+
+	_, err = putLock(db, &clock, topics[0], openapi.Lock{NodeId: clock.Now().Add(time.Hour), Reason: "vandalism"})
+	require.NotNil(t, err)
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	openapi "github.com/SpyLime/flowBackend/go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLock(t *testing.T) {
+	clock := TestClock{}
+	db, tearDown := FullStartTestServer("Lock", 8088, "")
+	defer tearDown()
+
+	users, topics, nodesAndEdges, err := CreateTestData(db, &clock, 4, 1, 1)
+	require.Nil(t, err)
+
+	info, err := getTopicInfo(db, topics[0])
+	require.Nil(t, err)
+
+	nodeId := nodesAndEdges[0].SourceId
+	node, err := getNode(db, nodeId.Format(time.RFC3339Nano), topics[0])
+	require.Nil(t, err)
+
+	// the owners of the topic and node could edit through the lock
+	var others []string
+	for _, id := range users {
+		if id != info.CreatedBy && id != node.CreatedBy.Id {
+			others = append(others, id)
+		}
+	}
+	editor, admin := others[0], others[1]
+
+	err = UpdateUserRoleAndReputation(db, editor, false, KeyReputationEditor)
+	require.Nil(t, err)
+
+	client := &http.Client{}
+	lockUrl := "http://127.0.0.1:8088/api/v1/topic/" + url.PathEscape(topics[0]) + "/lock"
+
+	// editors can't lock
+	SetTestLoginUser(editor)
+
+	marshal, err := json.Marshal(openapi.Lock{Reason: "exam week", Votes: true})
+	require.Nil(t, err)
+
+	req, _ := http.NewRequest(http.MethodPut, lockUrl, bytes.NewBuffer(marshal))
+
+	resp, err := client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 401, resp.StatusCode)
+
+	SetTestLoginUser(admin)
+
+	req, _ = http.NewRequest(http.MethodPut, lockUrl, bytes.NewBuffer(marshal))
+
+	resp, err = client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 200, resp.StatusCode)
+
+	var lock openapi.Lock
+	err = json.NewDecoder(resp.Body).Decode(&lock)
+	require.Nil(t, err)
+	require.Equal(t, admin, lock.LockedBy)
+
+	// the editor is told why the topic is locked
+	SetTestLoginUser(editor)
+
+	marshal, err = json.Marshal(openapi.NodeData{Id: nodeId, Topic: topics[0], Title: "armbar"})
+	require.Nil(t, err)
+
+	req, _ = http.NewRequest(http.MethodPut, "http://127.0.0.1:8088/api/v1/node/title", bytes.NewBuffer(marshal))
+
+	resp, err = client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 423, resp.StatusCode)
+
+	body, err := io.ReadAll(resp.Body)
+	require.Nil(t, err)
+	require.Contains(t, string(body), "exam week")
+
+	req, _ = http.NewRequest(http.MethodPut, "http://127.0.0.1:8088/api/v1/node/battleVote", bytes.NewBuffer(marshal))
+
+	resp, err = client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 423, resp.StatusCode)
+
+	// admins still fix the content
+	SetTestLoginUser(admin)
+
+	req, _ = http.NewRequest(http.MethodPut, "http://127.0.0.1:8088/api/v1/node/title", bytes.NewBuffer(marshal))
+
+	resp, err = client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 200, resp.StatusCode)
+
+	req, _ = http.NewRequest(http.MethodDelete, lockUrl, nil)
+
+	resp, err = client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 204, resp.StatusCode)
+
+	SetTestLoginUser(editor)
+
+	marshal, err = json.Marshal(openapi.NodeData{Id: nodeId, Topic: topics[0], Title: "kimura"})
+	require.Nil(t, err)
+
+	req, _ = http.NewRequest(http.MethodPut, "http://127.0.0.1:8088/api/v1/node/title", bytes.NewBuffer(marshal))
+
+	resp, err = client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 200, resp.StatusCode)
+}
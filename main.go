@@ -57,6 +57,7 @@ func main() {
 	// Server started
 
 	config := LoadConfig()
+	setPeerTubeHosts(config.PeerTube.Hosts)
 
 	db, err := bolt.Open("fl.db", 0666, nil)
 	if err != nil {
@@ -65,7 +66,17 @@ func main() {
 	defer db.Close()
 
 	// Create main router
-	router, clock := createRouter(db)
+	router, clock := createRouter(db, config)
+
+	err = runMigrations(db, clock)
+	if err != nil {
+		panic(fmt.Errorf("cannot migrate db %v", err))
+	}
+
+	if !config.LinkHealth.Disabled {
+		checker := NewHTTPLinkChecker(NewOutboundClient(config.Outbound))
+		go NewLinkHealthWorker(db, clock, checker, config.LinkHealth).Run(context.Background())
+	}
 
 	// Initialize auth service
 	authService := initAuth(db, clock, config)
@@ -94,6 +105,23 @@ func main() {
 			responseRecorder := httptest.NewRecorder()
 			authRoutes.ServeHTTP(responseRecorder, r)
 
+			// Banned users don't get the cookies so they stay logged out
+			for _, cookie := range responseRecorder.Result().Cookies() {
+				if cookie.Name != "flow_jwt" {
+					continue
+				}
+
+				claims, err := authService.TokenService().Parse(cookie.Value)
+				if err != nil || claims.User == nil {
+					continue
+				}
+
+				if err := checkSanction(db, clock, claims.User.ID, true); err != nil {
+					http.Error(w, err.Error(), http.StatusForbidden)
+					return
+				}
+			}
+
 			// Copy all headers from the auth response
 			for name, values := range responseRecorder.Header() {
 				for _, value := range values {
@@ -142,6 +170,7 @@ func main() {
 	router.Handle("/auth/facebook/callback", authCallbackHandler)
 	router.Handle("/auth/microsoft/login", authRoutes)
 	router.Handle("/auth/microsoft/callback", authCallbackHandler)
+	router.Handle("/auth/school/login", authRoutes)
 
 	// Use our custom Twitter OAuth handlers instead of the library's
 	router.HandleFunc("/auth/twitter/login", func(w http.ResponseWriter, r *http.Request) {
@@ -444,6 +473,7 @@ func main() {
 
 	// backup
 	router.Handle("/admin/backup", backUpHandler(db))
+	router.Handle("/admin/school", newSchoolHandler(db, clock)).Methods(http.MethodPost)
 	router.HandleFunc("/admin/version", func(writer http.ResponseWriter, request *http.Request) {
 		writer.Header().Set("Content-Type", "application/octet-stream")
 		writer.Write([]byte(build_date))
@@ -453,41 +483,87 @@ func main() {
 	router.Use(buildCORSMiddleware())
 
 	// Apply auth middleware after CORS
-	router.Use(buildAuthMiddleware(middleAuth))
+	router.Use(buildAuthMiddleware(middleAuth, db, clock))
+
+	// Rate limits need the user so they come after auth
+	router.Use(buildRateLimitMiddleware(db, NewRateLimiter(clock, config.RateLimit)))
 
 	addr := fmt.Sprintf(":%d", config.ServerPort)
 	log.Fatal(http.ListenAndServe(addr, router))
 
 }
 
-func createRouter(db *bolt.DB) (*mux.Router, *AppClock) {
+func createRouter(db *bolt.DB, config ServerConfig) (*mux.Router, *AppClock) {
 	clock := &AppClock{}
 
-	return createRouterClock(db, clock), clock
+	var fetcher MetadataFetcher
+	if !config.Metadata.Disabled {
+		fetcher = NewHTTPMetadataFetcher(NewOutboundClient(config.Outbound))
+	}
+
+	metadata := NewMetadataService(db, clock, fetcher, config.Metadata)
+	playlists := NewHTTPPlaylistFetcher(NewOutboundClient(config.Outbound))
+
+	return createRouterConfig(db, clock, config.Policy, config.Moderation, metadata, playlists, config.Watch), clock
 }
 
+// tests never go out to the network for metadata or playlists
 func createRouterClock(db *bolt.DB, clock Clock) *mux.Router {
+	metadata := NewMetadataService(db, clock, nil, DefaultMetadataConfig())
 
-	MapAPIServiceImpl := NewMapAPIServiceImpl(db, clock)
+	return createRouterConfig(db, clock, DefaultPolicy(), DefaultModerationConfig(), metadata, nil, DefaultWatchConfig())
+}
+
+func createRouterConfig(db *bolt.DB, clock Clock, policy Policy, moderation ModerationConfig, metadata *MetadataService, playlists PlaylistFetcher, watch WatchConfig) *mux.Router {
+
+	MapAPIServiceImpl := NewMapAPIServiceImpl(db, clock, policy, watch)
 	MapAPIController := openapi.NewMapAPIController(MapAPIServiceImpl)
 
-	NodeAPIServiceImpl := NewNodeAPIServiceImpl(db, clock)
+	NodeAPIServiceImpl := NewNodeAPIServiceImpl(db, clock, policy, moderation, metadata, watch)
 	NodeAPIController := openapi.NewNodeAPIController(NodeAPIServiceImpl)
 
-	TopicAPIServiceImpl := NewTopicAPIServiceImpl(db, clock)
+	TopicAPIServiceImpl := NewTopicAPIServiceImpl(db, clock, policy, playlists)
 	TopicAPIController := openapi.NewTopicAPIController(TopicAPIServiceImpl)
 
-	UserAPIServiceImpl := NewUserAPIServiceImpl(db, clock)
+	UserAPIServiceImpl := NewUserAPIServiceImpl(db, clock, policy, watch)
 	UserAPIController := openapi.NewUserAPIController(UserAPIServiceImpl)
 
-	AllAPIServiceImpl := NewAllAPIServiceImpl(db, clock)
+	AllAPIServiceImpl := NewAllAPIServiceImpl(db, clock, metadata)
 	AllAPIController := openapi.NewAllAPIController(AllAPIServiceImpl)
 
+	CategoryAPIServiceImpl := NewCategoryAPIServiceImpl(db, clock)
+	CategoryAPIController := openapi.NewCategoryAPIController(CategoryAPIServiceImpl)
+
+	GroupAPIServiceImpl := NewGroupAPIServiceImpl(db, clock, policy)
+	GroupAPIController := openapi.NewGroupAPIController(GroupAPIServiceImpl)
+
+	OrganizationAPIServiceImpl := NewOrganizationAPIServiceImpl(db, clock)
+	OrganizationAPIController := openapi.NewOrganizationAPIController(OrganizationAPIServiceImpl)
+
+	PermissionAPIServiceImpl := NewPermissionAPIServiceImpl(db, clock, policy)
+	PermissionAPIController := openapi.NewPermissionAPIController(PermissionAPIServiceImpl)
+
+	ModerationAPIServiceImpl := NewModerationAPIServiceImpl(db, clock, policy, moderation)
+	ModerationAPIController := openapi.NewModerationAPIController(ModerationAPIServiceImpl)
+
+	AdminAPIServiceImpl := NewAdminAPIServiceImpl(db, clock, policy)
+	AdminAPIController := openapi.NewAdminAPIController(AdminAPIServiceImpl)
+
+	SuggestionAPIServiceImpl := NewSuggestionAPIServiceImpl(db, clock, policy)
+	SuggestionAPIController := openapi.NewSuggestionAPIController(SuggestionAPIServiceImpl)
+
 	return openapi.NewRouter(MapAPIController,
 		NodeAPIController,
 		TopicAPIController,
 		UserAPIController,
-		AllAPIController)
+		AllAPIController,
+		CategoryAPIController,
+		GroupAPIController,
+		OrganizationAPIController,
+		PermissionAPIController,
+		ModerationAPIController,
+		AdminAPIController,
+		SuggestionAPIController)
 
 }
 
@@ -537,10 +613,15 @@ func initAuth(db *bolt.DB, clock Clock, config ServerConfig) *auth.Service {
 	// Configure SSO providers
 	ConfigureSSO(service, config, config.ServerPort, db, clock)
 
+	// School admins log in with their email and the password they got when the school was made
+	service.AddDirectProviderWithUserIDFunc(KeySchoolProvider, schoolCredChecker(db, clock), func(user string, _ *http.Request) string {
+		return strings.ToLower(strings.TrimSpace(user))
+	})
+
 	return service
 }
 
-func buildAuthMiddleware(m middleware.Authenticator) func(http.Handler) http.Handler {
+func buildAuthMiddleware(m middleware.Authenticator, db *bolt.DB, clock Clock) func(http.Handler) http.Handler {
 	return func(handler http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 
@@ -554,9 +635,17 @@ func buildAuthMiddleware(m middleware.Authenticator) func(http.Handler) http.Han
 				return
 			}
 
-			// Allow all GET requests
+			// Allow all GET requests, the user is still added when logged in so private topics can be shown
 			if r.Method == http.MethodGet {
-				handler.ServeHTTP(w, r)
+				h := m.Trace(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					userInfo, err := token.GetUserInfo(r)
+					// banned users are treated as if they weren't logged in
+					if err == nil && checkSanction(db, clock, userInfo.ID, true) == nil {
+						r = r.WithContext(context.WithValue(r.Context(), userInfoKey, userInfo))
+					}
+					handler.ServeHTTP(w, r)
+				}))
+				h.ServeHTTP(w, r)
 				return
 			}
 
@@ -568,6 +657,13 @@ func buildAuthMiddleware(m middleware.Authenticator) func(http.Handler) http.Han
 					w.WriteHeader(http.StatusForbidden)
 					return
 				}
+
+				// suspended users can only read and banned users can't do anything
+				if err := checkSanction(db, clock, userInfo.ID, false); err != nil {
+					http.Error(w, err.Error(), http.StatusForbidden)
+					return
+				}
+
 				ctx := context.WithValue(r.Context(), userInfoKey, userInfo)
 				handler.ServeHTTP(w, r.WithContext(ctx))
 			}))
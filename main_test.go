@@ -64,11 +64,22 @@ func InitTestServer(port int, db *bolt.DB, id string, clock Clock) (teardown fun
 			user := token.User{
 				ID: testLoginUser,
 			}
+
+			// sanctions are enforced the same way buildAuthMiddleware does
+			if err := checkSanction(db, clock, user.ID, request.Method == http.MethodGet); err != nil {
+				if request.Method != http.MethodGet {
+					http.Error(writer, err.Error(), http.StatusForbidden)
+					return
+				}
+				user = token.User{}
+			}
+
 			ctx := request.Context()
 			ctx = context.WithValue(ctx, userInfoKey, user)
 			handler.ServeHTTP(writer, request.WithContext(ctx))
 		})
 	})
+	mux.Use(buildRateLimitMiddleware(db, NewRateLimiter(clock, DefaultRateLimitConfig())))
 	addr := fmt.Sprintf("127.0.0.1:%d", port)
 	l, _ := net.Listen("tcp", addr)
 	ts := httptest.NewUnstartedServer(mux)
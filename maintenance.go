@@ -8,6 +8,7 @@ import (
 	"strconv"
 
 	openapi "github.com/SpyLime/flowBackend/go"
+	"github.com/go-pkgz/auth/token"
 	bolt "go.etcd.io/bbolt"
 )
 
@@ -36,7 +37,44 @@ type NewSchoolRequest struct {
 }
 
 type NewSchoolResponse struct {
-	AdminPassword string
+	AdminPassword  string
+	AdminId        string
+	OrganizationId string
+}
+
+// newSchoolHandler makes a school and its first admin, only global admins can use it
+//
+// the admin logs in at /auth/school/login with their email and the returned password
+func newSchoolHandler(db *bolt.DB, clock Clock) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, ok := r.Context().Value(userInfoKey).(token.User)
+		if !ok {
+			http.Error(w, "unauthorized: user not found in context", http.StatusUnauthorized)
+			return
+		}
+
+		userDetails, err := getUser(db, user.ID)
+		if err != nil || userDetails.Role != KeyAdmin {
+			http.Error(w, "unauthorized: user is not an admin", http.StatusUnauthorized)
+			return
+		}
+
+		var request NewSchoolRequest
+		err = json.NewDecoder(r.Body).Decode(&request)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		response, err := postSchool(db, clock, request)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+	})
 }
 
 type ResetPasswordRequest struct {
@@ -49,6 +87,9 @@ type EventRequest struct {
 	Title       string `json:",omitempty"`
 }
 
+// UpdateUserRoleAndReputation sets the reputation without recording it in the history, it is for setting up tests
+//
+// admins change reputation with the adjustment in the admin api
 func UpdateUserRoleAndReputation(db *bolt.DB, userId string, isAdmin bool, reputation int32) error {
 	return db.Update(func(tx *bolt.Tx) error {
 		// Get users bucket
@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"errors"
+	"time"
 
 	openapi "github.com/SpyLime/flowBackend/go"
 	"github.com/go-pkgz/auth/token"
@@ -10,24 +11,51 @@ import (
 )
 
 type MapAPIServiceImpl struct {
-	db    *bolt.DB
-	clock Clock
+	db     *bolt.DB
+	clock  Clock
+	policy Policy
+	watch  WatchConfig
 }
 
-func NewMapAPIServiceImpl(db *bolt.DB, clock Clock) openapi.MapAPIServicer {
+func NewMapAPIServiceImpl(db *bolt.DB, clock Clock, policy Policy, watch WatchConfig) openapi.MapAPIServicer {
 	return &MapAPIServiceImpl{
-		db:    db,
-		clock: clock,
+		db:     db,
+		clock:  clock,
+		policy: policy,
+		watch:  watch,
 	}
 }
 
 // GetMapById - Find map by ID
 func (s *MapAPIServiceImpl) GetMapById(ctx context.Context, topicId string) (openapi.ImplResponse, error) {
-	response, err := getMapById(s.db, topicId)
+	// nobody is logged in when there is no user
+	user, _ := ctx.Value(userInfoKey).(token.User)
+
+	// hidden topics look the same as missing ones
+	visible, err := topicVisible(s.db, topicId, user.ID)
+	if err != nil || !visible {
+		return openapi.Response(404, nil), errors.New("topic not found")
+	}
+
+	// hidden content is only shown to moderators and its author
+	moderator := s.policy.checkUser(s.db, s.clock, KeyActionModerate, user.ID, PolicyTarget{TopicId: topicId}) == nil
+
+	response, err := getMapForViewer(s.db, topicId, user.ID, moderator)
 	if err != nil {
 		return openapi.Response(400, nil), err
 	}
 
+	if user.ID != "" {
+		completion, err := getMapCompletion(s.db, topicId, user.ID, s.watch.CompleteAt)
+		if err != nil {
+			return openapi.Response(400, nil), err
+		}
+
+		for i, node := range response.Nodes {
+			response.Nodes[i].Data.Completion = completion[node.Id.Format(time.RFC3339Nano)]
+		}
+	}
+
 	return openapi.Response(200, response), nil
 
 }
@@ -38,13 +66,25 @@ func (s *MapAPIServiceImpl) AddEdge(ctx context.Context, topicId string, edge op
 	if !ok {
 		return openapi.Response(401, nil), errors.New("unauthorized: user not found in context")
 	}
-	userDetails, err := getUser(s.db, user.ID)
+
+	// hidden topics look the same as missing ones
+	visible, err := topicVisible(s.db, topicId, user.ID)
+	if err != nil || !visible {
+		return openapi.Response(404, nil), errors.New("topic not found")
+	}
+	userDetails, err := getUserForTopic(s.db, user.ID, topicId)
 	if err != nil {
 		return openapi.Response(401, nil), err
 	}
 
-	if userDetails.Role != KeyAdmin && userDetails.Reputation < KeyReputationContributor {
-		return openapi.Response(401, nil), errors.New("unauthorized: user is not an admin or has low reputation(Contributor)")
+	err = s.policy.check(s.db, s.clock, KeyActionAddEdge, userDetails, PolicyTarget{TopicId: topicId})
+	if err != nil {
+		return openapi.Response(401, nil), err
+	}
+
+	err = checkLock(s.db, s.clock, userDetails, topicId, false, edge.Source, edge.Target)
+	if err != nil {
+		return openapi.Response(423, nil), err
 	}
 
 	_, err = postEdge(s.db, topicId, edge)
@@ -62,13 +102,27 @@ func (s *MapAPIServiceImpl) DeleteEdge(ctx context.Context, topicId string, edge
 	if !ok {
 		return openapi.Response(401, nil), errors.New("unauthorized: user not found in context")
 	}
-	userDetails, err := getUser(s.db, user.ID)
+
+	// hidden topics look the same as missing ones
+	visible, err := topicVisible(s.db, topicId, user.ID)
+	if err != nil || !visible {
+		return openapi.Response(404, nil), errors.New("topic not found")
+	}
+	userDetails, err := getUserForTopic(s.db, user.ID, topicId)
 	if err != nil {
 		return openapi.Response(401, nil), err
 	}
 
-	if userDetails.Role != KeyAdmin && userDetails.Reputation < KeyReputationEditor {
-		return openapi.Response(401, nil), errors.New("unauthorized: user is not an admin or has low reputation(Editor)")
+	err = s.policy.check(s.db, s.clock, KeyActionDeleteEdge, userDetails, PolicyTarget{TopicId: topicId})
+	if err != nil {
+		return openapi.Response(401, nil), err
+	}
+
+	// a missing edge is only checked against the topic lock
+	edge, _ := getEdge(s.db, topicId, edgeId)
+	err = checkLock(s.db, s.clock, userDetails, topicId, false, edge.Source, edge.Target)
+	if err != nil {
+		return openapi.Response(423, nil), err
 	}
 
 	err = deleteEdge(s.db, topicId, edgeId)
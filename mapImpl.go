@@ -65,6 +65,8 @@ func getMapByIdRx(tx *bolt.Tx, topicId string) (response openapi.MapData, err er
 				BattleTested: retrievedNode.BattleTested,
 				Fresh:        retrievedNode.Fresh,
 				Speed:        retrievedNode.Speed,
+				IsHidden:     retrievedNode.IsHidden,
+				HiddenReason: retrievedNode.HiddenReason,
 			},
 		}
 
@@ -95,6 +97,50 @@ func getMapByIdRx(tx *bolt.Tx, topicId string) (response openapi.MapData, err er
 	return
 }
 
+func getMapForViewer(db *bolt.DB, topicId, viewerId string, moderator bool) (response openapi.MapData, err error) {
+	err = db.View(func(tx *bolt.Tx) error {
+		response, err = getMapForViewerRx(tx, topicId, viewerId, moderator)
+		return err
+	})
+
+	return
+}
+
+// hidden nodes and their edges are left out unless the viewer moderates the topic or made the node
+func getMapForViewerRx(tx *bolt.Tx, topicId, viewerId string, moderator bool) (response openapi.MapData, err error) {
+	response, err = getMapByIdRx(tx, topicId)
+	if err != nil || moderator {
+		return
+	}
+
+	hidden := make(map[string]bool)
+	nodes := make([]openapi.FlowNode, 0, len(response.Nodes))
+	for _, node := range response.Nodes {
+		nodeId := node.Id.Format(time.RFC3339Nano)
+		if node.Data.IsHidden {
+			nodeData, nodeErr := getNodeRx(tx, nodeId, topicId)
+			if nodeErr != nil || viewerId == "" || nodeData.CreatedBy.Id != viewerId {
+				hidden[nodeId] = true
+				continue
+			}
+		}
+
+		nodes = append(nodes, node)
+	}
+
+	edges := make([]openapi.Edge, 0, len(response.Edges))
+	for _, edge := range response.Edges {
+		if !hidden[edge.Source.Format(time.RFC3339Nano)] && !hidden[edge.Target.Format(time.RFC3339Nano)] {
+			edges = append(edges, edge)
+		}
+	}
+
+	response.Nodes = nodes
+	response.Edges = edges
+
+	return
+}
+
 func postEdge(db *bolt.DB, topic string, edge openapi.Edge) (newId string, err error) {
 	if edge.Source == edge.Target {
 		return newId, fmt.Errorf("your trying to connect a node to itself")
@@ -179,3 +225,39 @@ func deleteEdgeTx(tx *bolt.Tx, topicId string, edgeId string) (err error) {
 
 	return
 }
+
+func getEdge(db *bolt.DB, topicId string, edgeId string) (response openapi.Edge, err error) {
+	err = db.View(func(tx *bolt.Tx) error {
+		response, err = getEdgeRx(tx, topicId, edgeId)
+		return err
+	})
+
+	return
+}
+
+func getEdgeRx(tx *bolt.Tx, topicId string, edgeId string) (response openapi.Edge, err error) {
+	topicsBucket := tx.Bucket([]byte(KeyTopics))
+	if topicsBucket == nil {
+		return response, fmt.Errorf("can't find topics bucket")
+	}
+
+	topicBucket := topicsBucket.Bucket([]byte(topicId))
+	if topicBucket == nil {
+		return response, fmt.Errorf("can't find topic bucket")
+	}
+
+	edgesBucket := topicBucket.Bucket([]byte(KeyEdges))
+	if edgesBucket == nil {
+		return response, fmt.Errorf("can't find edges bucket")
+	}
+
+	edgeData := edgesBucket.Get([]byte(edgeId))
+	if edgeData == nil {
+		return response, fmt.Errorf("can't find edge")
+	}
+
+	err = json.Unmarshal(edgeData, &response)
+	response.Id = edgeId
+
+	return
+}
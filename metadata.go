@@ -0,0 +1,312 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	openapi "github.com/SpyLime/flowBackend/go"
+	bolt "go.etcd.io/bbolt"
+)
+
+// ResourceMetadata is what is fetched about a resource, it is kept in the metadata bucket by its link
+type ResourceMetadata struct {
+	Link      string    `json:"link,omitempty"` // a video is kept once for all its segments
+	Title     string    `json:"title,omitempty"`
+	Channel   string    `json:"channel,omitempty"` // the channel or author of the resource
+	Duration  int32     `json:"duration,omitempty"`
+	Thumbnail string    `json:"thumbnail,omitempty"`
+	FetchedAt time.Time `json:"fetchedAt,omitempty"`
+}
+
+// MetadataFetcher looks up the title, channel, duration and thumbnail of a link, tests use a local stand-in
+type MetadataFetcher interface {
+	Fetch(ctx context.Context, resource openapi.LinkData) (ResourceMetadata, error)
+}
+
+// MetadataService keeps fetched metadata in the metadata bucket so a link is only fetched again once it is stale
+type MetadataService struct {
+	db      *bolt.DB
+	clock   Clock
+	fetcher MetadataFetcher // nil only uses what is kept
+	config  MetadataConfig
+}
+
+func NewMetadataService(db *bolt.DB, clock Clock, fetcher MetadataFetcher, config MetadataConfig) *MetadataService {
+	return &MetadataService{
+		db:      db,
+		clock:   clock,
+		fetcher: fetcher,
+		config:  config,
+	}
+}
+
+// Lookup returns the metadata of the link, fetching it when it isn't kept or is stale.
+// It is fetched outside of any transaction so the database isn't held while waiting on the network
+func (m *MetadataService) Lookup(ctx context.Context, link string) (metadata ResourceMetadata, err error) {
+	resource, err := resourceData(openapi.LinkData{Link: link})
+	if err != nil {
+		return
+	}
+
+	key := metadataKey(resource)
+	err = m.db.View(func(tx *bolt.Tx) error {
+		metadata, err = getMetadataRx(tx, key)
+		return err
+	})
+	if err == nil && m.clock.Now().Sub(metadata.FetchedAt) < m.config.TTL {
+		return
+	}
+
+	if m.fetcher == nil {
+		return ResourceMetadata{}, fmt.Errorf("no metadata for %s", link)
+	}
+
+	metadata, err = m.fetcher.Fetch(ctx, resource)
+	if err != nil {
+		return
+	}
+
+	metadata.Link = key
+	metadata.FetchedAt = m.clock.Now()
+
+	err = m.db.Update(func(tx *bolt.Tx) error {
+		return putMetadataTx(tx, metadata)
+	})
+
+	return
+}
+
+// a video is kept once for all its segments
+func metadataKey(resource openapi.LinkData) string {
+	if resource.Provider == KeyProviderYouTube && resource.VideoId != "" {
+		return VideoRef{Id: resource.VideoId}.Canonical()
+	}
+
+	return resource.Link
+}
+
+func getMetadataRx(tx *bolt.Tx, key string) (metadata ResourceMetadata, err error) {
+	metadataBucket := tx.Bucket([]byte(KeyMetadata))
+	if metadataBucket == nil {
+		return metadata, fmt.Errorf("can't find metadata bucket")
+	}
+
+	data := metadataBucket.Get([]byte(key))
+	if data == nil {
+		return metadata, fmt.Errorf("no metadata for %s", key)
+	}
+
+	err = json.Unmarshal(data, &metadata)
+
+	return
+}
+
+func putMetadataTx(tx *bolt.Tx, metadata ResourceMetadata) (err error) {
+	metadataBucket, err := tx.CreateBucketIfNotExists([]byte(KeyMetadata))
+	if err != nil {
+		return
+	}
+
+	marshal, err := json.Marshal(metadata)
+	if err != nil {
+		return
+	}
+
+	return metadataBucket.Put([]byte(metadata.Link), marshal)
+}
+
+// withMetadataRx fills what the resource doesn't say about itself from the kept metadata, stale metadata is better than none
+func withMetadataRx(tx *bolt.Tx, resource openapi.LinkData) openapi.LinkData {
+	metadata, err := getMetadataRx(tx, metadataKey(resource))
+	if err != nil {
+		return resource
+	}
+
+	if resource.Title == "" {
+		resource.Title = metadata.Title
+	}
+	if resource.Channel == "" {
+		resource.Channel = metadata.Channel
+	}
+	if resource.Duration == 0 {
+		resource.Duration = metadata.Duration
+	}
+	if resource.Thumbnail == "" {
+		resource.Thumbnail = metadata.Thumbnail
+	}
+
+	return resource
+}
+
+// httpMetadataFetcher asks the oEmbed endpoint of the provider first and reads the page for whatever is still missing
+type httpMetadataFetcher struct {
+	client *OutboundClient
+	oEmbed map[string]string // provider -> endpoint, PeerTube has one on every instance
+}
+
+func NewHTTPMetadataFetcher(client *OutboundClient) MetadataFetcher {
+	return &httpMetadataFetcher{
+		client: client,
+		oEmbed: map[string]string{
+			KeyProviderYouTube: "https://www.youtube.com/oembed",
+			KeyProviderVimeo:   "https://vimeo.com/api/oembed.json",
+		},
+	}
+}
+
+func (f *httpMetadataFetcher) Fetch(ctx context.Context, resource openapi.LinkData) (metadata ResourceMetadata, err error) {
+	endpoint := f.oEmbed[resource.Provider]
+	if resource.Provider == KeyProviderPeerTube {
+		if parsed, err := url.Parse(resource.Link); err == nil && peerTubeHosts[strings.ToLower(parsed.Hostname())] {
+			endpoint = "https://" + parsed.Host + "/services/oembed"
+		}
+	}
+
+	// a failed oEmbed still leaves the page
+	if endpoint != "" {
+		metadata, _ = f.fetchOEmbed(ctx, endpoint, resource.Link)
+	}
+
+	if metadata.Title != "" && metadata.Channel != "" && metadata.Duration != 0 && metadata.Thumbnail != "" {
+		return
+	}
+
+	page, err := f.fetchPage(ctx, resource.Link)
+	if err != nil && metadata.Title == "" {
+		return
+	}
+
+	if metadata.Title == "" {
+		metadata.Title = page.Title
+	}
+	if metadata.Channel == "" {
+		metadata.Channel = page.Channel
+	}
+	if metadata.Duration == 0 {
+		metadata.Duration = page.Duration
+	}
+	if metadata.Thumbnail == "" {
+		metadata.Thumbnail = page.Thumbnail
+	}
+
+	if metadata.Title == "" {
+		return metadata, fmt.Errorf("can't find the title of %s", resource.Link)
+	}
+
+	return metadata, nil
+}
+
+func (f *httpMetadataFetcher) fetchOEmbed(ctx context.Context, endpoint, link string) (metadata ResourceMetadata, err error) {
+	body, err := f.client.Get(ctx, endpoint+"?format=json&url="+url.QueryEscape(link), "application/json")
+	if err != nil {
+		return
+	}
+
+	var oEmbed struct {
+		Title        string  `json:"title"`
+		AuthorName   string  `json:"author_name"`
+		ThumbnailUrl string  `json:"thumbnail_url"`
+		Duration     float64 `json:"duration"`
+	}
+	err = json.Unmarshal(body, &oEmbed)
+	if err != nil {
+		return
+	}
+
+	metadata.Title = oEmbed.Title
+	metadata.Channel = oEmbed.AuthorName
+	metadata.Thumbnail = oEmbed.ThumbnailUrl
+	metadata.Duration = int32(oEmbed.Duration)
+
+	return
+}
+
+var metaTagPattern = regexp.MustCompile(`(?is)<(?:meta|link)\s[^>]*>`)
+var attributePattern = regexp.MustCompile(`(?is)([a-z:-]+)\s*=\s*(?:"([^"]*)"|'([^']*)')`)
+var titleTagPattern = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+
+// 1H2M3S as pages write durations, with or without the P and T
+var isoDurationPattern = regexp.MustCompile(`^P?T?(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?$`)
+
+// fetchPage reads the Open Graph, Twitter and schema.org tags of the page
+func (f *httpMetadataFetcher) fetchPage(ctx context.Context, link string) (metadata ResourceMetadata, err error) {
+	body, err := f.client.Get(ctx, link, "text/html")
+	if err != nil {
+		return
+	}
+
+	tags := make(map[string]string)
+	for _, tag := range metaTagPattern.FindAll(body, -1) {
+		attributes := make(map[string]string)
+		for _, match := range attributePattern.FindAllSubmatch(tag, -1) {
+			attributes[strings.ToLower(string(match[1]))] = string(match[2]) + string(match[3])
+		}
+
+		name := attributes["property"]
+		if name == "" {
+			name = attributes["name"]
+		}
+		if name == "" {
+			name = attributes["itemprop"]
+		}
+
+		// the first tag of a name wins, pages repeat some of them further down
+		name = strings.ToLower(name)
+		if _, ok := tags[name]; name != "" && !ok {
+			tags[name] = html.UnescapeString(strings.TrimSpace(attributes["content"]))
+		}
+	}
+
+	metadata.Title = firstTag(tags, "og:title", "twitter:title", "title")
+	if metadata.Title == "" {
+		if match := titleTagPattern.FindSubmatch(body); match != nil {
+			metadata.Title = html.UnescapeString(strings.TrimSpace(string(match[1])))
+		}
+	}
+
+	metadata.Channel = firstTag(tags, "author", "article:author", "name")
+	metadata.Thumbnail = firstTag(tags, "og:image", "twitter:image", "thumbnailurl")
+
+	if seconds, err := strconv.ParseFloat(firstTag(tags, "og:video:duration", "video:duration"), 64); err == nil {
+		metadata.Duration = int32(seconds)
+	} else {
+		metadata.Duration = parseISODuration(firstTag(tags, "duration"))
+	}
+
+	return metadata, nil
+}
+
+func firstTag(tags map[string]string, names ...string) string {
+	for _, name := range names {
+		if tags[name] != "" {
+			return tags[name]
+		}
+	}
+
+	return ""
+}
+
+// parseISODuration turns PT1H2M3S into seconds, anything it can't read is 0
+func parseISODuration(value string) int32 {
+	match := isoDurationPattern.FindStringSubmatch(strings.ToUpper(value))
+	if value == "" || match == nil {
+		return 0
+	}
+
+	var seconds int32
+	for i, unit := range []time.Duration{time.Hour, time.Minute, time.Second} {
+		n, err := strconv.Atoi(match[i+1])
+		if err == nil {
+			seconds += int32(time.Duration(n) * unit / time.Second)
+		}
+	}
+
+	return seconds
+}
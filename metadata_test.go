@@ -0,0 +1,184 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	openapi "github.com/SpyLime/flowBackend/go"
+	"github.com/go-pkgz/auth/token"
+	"github.com/go-pkgz/lgr"
+	"github.com/stretchr/testify/require"
+)
+
+// a local stand-in for YouTube and the pages resources link to
+func newMetadataStandIn(requests map[string]int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests[r.URL.Path]++
+
+		switch r.URL.Path {
+		case "/oembed":
+			if !strings.Contains(r.URL.Query().Get("url"), "dQw4w9WgXcQ") {
+				http.NotFound(w, r)
+				return
+			}
+			fmt.Fprint(w, `{"title":"Never Gonna Give You Up","author_name":"Rick Astley","thumbnail_url":"https://i.ytimg.com/vi/dQw4w9WgXcQ/hqdefault.jpg","duration":213}`)
+		case "/clip":
+			fmt.Fprint(w, `<html><head><meta name="title" content="Ninja &amp; friends"></head></html>`)
+		case "/page":
+			fmt.Fprint(w, `<html><head>
+				<title>ignored when og:title is there</title>
+				<meta content="How ninjas attack" property="og:title">
+				<meta property="og:image" content='https://example.org/ninja.png'>
+				<meta name="author" content="Ninja Academy">
+				<meta itemprop="duration" content="PT4M13S">
+				</head></html>`)
+		case "/big":
+			fmt.Fprint(w, "<html><head>"+strings.Repeat(" ", 2048)+`<title>too far down</title></head></html>`)
+		case "/slow":
+			time.Sleep(200 * time.Millisecond)
+			fmt.Fprint(w, `<title>too slow</title>`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+}
+
+// the stand-in runs on loopback, which the outbound client refuses outside of tests
+func newStandInClient(server *httptest.Server, config OutboundConfig) *OutboundClient {
+	client := NewOutboundClient(config)
+	client.config.AllowHosts = append(client.config.AllowHosts, "127.0.0.1")
+	client.blocked = func(ip net.IP) bool { return false }
+
+	return client
+}
+
+func newStandInFetcher(server *httptest.Server, config OutboundConfig) MetadataFetcher {
+	fetcher := NewHTTPMetadataFetcher(newStandInClient(server, config)).(*httpMetadataFetcher)
+	fetcher.oEmbed[KeyProviderYouTube] = server.URL + "/oembed"
+
+	return fetcher
+}
+
+func TestFetchClipTitle(t *testing.T) {
+	server := newMetadataStandIn(make(map[string]int))
+	defer server.Close()
+
+	fetcher := newStandInFetcher(server, DefaultOutboundConfig())
+
+	metadata, err := fetcher.Fetch(context.Background(), openapi.LinkData{Link: server.URL + "/clip"})
+	require.Nil(t, err)
+	require.Equal(t, "Ninja & friends", metadata.Title)
+
+	metadata, err = fetcher.Fetch(context.Background(), openapi.LinkData{Link: server.URL + "/page"})
+	require.Nil(t, err)
+	require.Equal(t, ResourceMetadata{Title: "How ninjas attack", Channel: "Ninja Academy", Duration: 253, Thumbnail: "https://example.org/ninja.png"}, metadata)
+
+	_, err = fetcher.Fetch(context.Background(), openapi.LinkData{Link: server.URL + "/missing"})
+	require.NotNil(t, err)
+
+	// answers are cut at the size limit and requests at the timeout
+	config := DefaultOutboundConfig()
+	config.MaxBytes = 1024
+	config.Timeout = 50 * time.Millisecond
+	fetcher = newStandInFetcher(server, config)
+
+	_, err = fetcher.Fetch(context.Background(), openapi.LinkData{Link: server.URL + "/big"})
+	require.NotNil(t, err)
+
+	_, err = fetcher.Fetch(context.Background(), openapi.LinkData{Link: server.URL + "/slow"})
+	require.NotNil(t, err)
+
+	require.Equal(t, int32(3723), parseISODuration("PT1H2M3S"))
+	require.Equal(t, int32(0), parseISODuration("soon"))
+}
+
+func TestMetadataImpl(t *testing.T) {
+
+	lgr.Printf("INFO TestMetadataImpl")
+	t.Log("INFO TestMetadataImpl")
+	clock := TestClock{}
+	db, dbTearDown := OpenTestDB("MetadataImpl")
+	defer dbTearDown()
+
+	users, topics, nodesAndEdges, err := CreateTestData(db, &clock, 2, 1, 1)
+	require.Nil(t, err)
+
+	requests := make(map[string]int)
+	server := newMetadataStandIn(requests)
+	defer server.Close()
+
+	config := DefaultMetadataConfig()
+	metadata := NewMetadataService(db, &clock, newStandInFetcher(server, DefaultOutboundConfig()), config)
+
+	// a video is fetched once for all its segments until it is stale
+	found, err := metadata.Lookup(context.Background(), "https://youtu.be/dQw4w9WgXcQ?t=30")
+	require.Nil(t, err)
+	require.Equal(t, "Rick Astley", found.Channel)
+	require.Equal(t, int32(213), found.Duration)
+	require.Equal(t, "https://www.youtube.com/watch?v=dQw4w9WgXcQ", found.Link)
+
+	_, err = metadata.Lookup(context.Background(), "https://www.youtube.com/embed/dQw4w9WgXcQ?start=60&end=90")
+	require.Nil(t, err)
+	require.Equal(t, 1, requests["/oembed"])
+
+	clock.TickOne(config.TTL)
+	_, err = metadata.Lookup(context.Background(), "https://youtu.be/dQw4w9WgXcQ")
+	require.Nil(t, err)
+	require.Equal(t, 2, requests["/oembed"])
+
+	_, err = metadata.Lookup(context.Background(), "https://youtu.be/aaaaaaaaaaa")
+	require.NotNil(t, err)
+
+	// without a fetcher only kept metadata is used
+	offline := NewMetadataService(db, &clock, nil, config)
+	_, err = offline.Lookup(context.Background(), "https://youtu.be/dQw4w9WgXcQ")
+	require.Nil(t, err)
+
+	_, err = offline.Lookup(context.Background(), server.URL+"/page")
+	require.NotNil(t, err)
+
+	// the clip endpoint answers from the same metadata
+	clip, err := NewAllAPIServiceImpl(db, &clock, metadata).ClipImage(context.Background(), url.PathEscape(server.URL+"/clip"))
+	require.Nil(t, err)
+	require.Equal(t, "Ninja & friends", clip.Body)
+
+	// added resources get their metadata, what the adder wrote wins
+	nodeId := nodesAndEdges[1].TargetId
+	service := NewNodeAPIServiceImpl(db, &clock, DefaultPolicy(), DefaultModerationConfig(), metadata, DefaultWatchConfig())
+	ctx := context.WithValue(context.Background(), userInfoKey, token.User{ID: users[0]})
+
+	response, err := service.UpdateNodeVideoEdit(ctx, openapi.NodeData{Id: nodeId, Topic: topics[0], Resources: []openapi.LinkData{{Link: server.URL + "/page", Title: "Ninjas", Votes: 1}}})
+	require.Nil(t, err)
+	require.Equal(t, 200, response.Code)
+
+	response, err = service.UpdateNodeVideoEdit(ctx, openapi.NodeData{Id: nodeId, Topic: topics[0], YoutubeLinks: []openapi.LinkData{{Link: "https://youtu.be/dQw4w9WgXcQ?t=30", Votes: 1}}})
+	require.Nil(t, err)
+	require.Equal(t, 200, response.Code)
+
+	// a resource without metadata is still added
+	response, err = service.UpdateNodeVideoEdit(ctx, openapi.NodeData{Id: nodeId, Topic: topics[0], Resources: []openapi.LinkData{{Link: server.URL + "/missing", Votes: 1}}})
+	require.Nil(t, err)
+	require.Equal(t, 200, response.Code)
+
+	node, err := getNode(db, nodeId.Format(time.RFC3339Nano), topics[0])
+	require.Nil(t, err)
+	require.Equal(t, 3, len(node.Resources))
+	require.Equal(t, "Ninjas", node.Resources[0].Title)
+	require.Equal(t, "Ninja Academy", node.Resources[0].Channel)
+	require.Equal(t, int32(253), node.Resources[0].Duration)
+	require.Equal(t, "https://example.org/ninja.png", node.Resources[0].Thumbnail)
+	require.Equal(t, "Never Gonna Give You Up", node.Resources[1].Title)
+	require.Equal(t, "https://i.ytimg.com/vi/dQw4w9WgXcQ/hqdefault.jpg", node.YoutubeLinks[0].Thumbnail)
+	require.Empty(t, node.Resources[2].Title)
+
+	user, err := getUser(db, users[0])
+	require.Nil(t, err)
+	require.Equal(t, "Rick Astley", user.Linked[len(user.Linked)-2].Channel)
+}
@@ -0,0 +1,783 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	openapi "github.com/SpyLime/flowBackend/go"
+	bolt "go.etcd.io/bbolt"
+)
+
+// a migration changes stored data once, the migrations bucket remembers which ones are done
+type migration struct {
+	Name  string
+	Apply func(tx *bolt.Tx) error
+}
+
+// migrations run in this order, add new ones at the end and never rename one.
+// The function of a migration that may have run is never changed, a later change to the same data is a new migration with its own function
+var migrations = []migration{
+	{Name: "leaderboards", Apply: buildLeaderboardsTx},
+	{Name: "canonicalVideoLinks", Apply: canonicalVideoLinksTx},
+	{Name: "videoSegments", Apply: videoSegmentsTx},
+	{Name: "resources", Apply: canonicalResourcesTx},
+	{Name: "videoIndex", Apply: buildVideoIndexTx},
+	{Name: "videoUpDown", Apply: countVideoVotesTx},
+	{Name: "videoVotesByNode", Apply: videoVotesByNodeTx},
+	{Name: "moderationIndex", Apply: buildModerationIndexTx},
+	{Name: "startTimes", Apply: foldStartTimesTx},
+	{Name: "achievements", Apply: countAchievementsTx},
+}
+
+// runMigrations applies every migration that isn't done yet, each in its own transaction
+func runMigrations(db *bolt.DB, clock Clock) (err error) {
+	for _, m := range migrations {
+		err = db.Update(func(tx *bolt.Tx) error {
+			migrationsBucket, err := tx.CreateBucketIfNotExists([]byte(KeyMigrations))
+			if err != nil {
+				return err
+			}
+
+			if migrationsBucket.Get([]byte(m.Name)) != nil {
+				return nil
+			}
+
+			err = m.Apply(tx)
+			if err != nil {
+				return err
+			}
+
+			return migrationsBucket.Put([]byte(m.Name), []byte(clock.Now().Format(time.RFC3339)))
+		})
+		if err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// canonicalVideoLinksTx stores every video link in its canonical form, videos a node had more than once become one with their votes added up
+func canonicalVideoLinksTx(tx *bolt.Tx) (err error) {
+	topicsBucket := tx.Bucket([]byte(KeyTopics))
+	if topicsBucket != nil {
+		err = topicsBucket.ForEach(func(topicId, v []byte) error {
+			if v != nil {
+				return nil
+			}
+
+			nodesBucket := topicsBucket.Bucket(topicId).Bucket([]byte(KeyNodes))
+			if nodesBucket == nil {
+				return nil
+			}
+
+			return updateEachTx(nodesBucket, func(data []byte) (interface{}, error) {
+				var node openapi.NodeData
+				err := json.Unmarshal(data, &node)
+				node.YoutubeLinks = mergeVideoLinks(node.YoutubeLinks)
+				return node, err
+			})
+		})
+		if err != nil {
+			return
+		}
+	}
+
+	usersBucket := tx.Bucket([]byte(KeyUsers))
+	if usersBucket != nil {
+		err = updateEachTx(usersBucket, func(data []byte) (interface{}, error) {
+			var user openapi.User
+			err := json.Unmarshal(data, &user)
+			user.Linked = mergeVideoLinks(user.Linked)
+			user.VideoUp = canonicalVideoLinks(user.VideoUp)
+			user.VideoDown = canonicalVideoLinks(user.VideoDown)
+			return user, err
+		})
+		if err != nil {
+			return
+		}
+	}
+
+	// votes and reputation are counted per video
+	votesBucket := tx.Bucket([]byte(KeyVotes))
+	if votesBucket != nil {
+		err = updateEachTx(votesBucket, func(data []byte) (interface{}, error) {
+			var record openapi.VoteRecord
+			err := json.Unmarshal(data, &record)
+			if record.Link != "" {
+				record.Link = canonicalVideoLink(record.Link)
+			}
+			return record, err
+		})
+		if err != nil {
+			return
+		}
+	}
+
+	historyBucket := tx.Bucket([]byte(KeyReputationHistory))
+	if historyBucket == nil {
+		return
+	}
+
+	return historyBucket.ForEach(func(userId, v []byte) error {
+		if v != nil {
+			return nil
+		}
+
+		return updateEachTx(historyBucket.Bucket(userId), func(data []byte) (interface{}, error) {
+			var event openapi.ReputationEvent
+			err := json.Unmarshal(data, &event)
+			if event.Link != "" {
+				event.Link = canonicalVideoLink(event.Link)
+			}
+			return event, err
+		})
+	})
+}
+
+// videoSegmentsTx stores the video links of nodes and users again with their segment and embed url.
+// canonicalVideoLinksTx already merged them so no votes are added up here
+func videoSegmentsTx(tx *bolt.Tx) (err error) {
+	topicsBucket := tx.Bucket([]byte(KeyTopics))
+	if topicsBucket != nil {
+		err = topicsBucket.ForEach(func(topicId, v []byte) error {
+			if v != nil {
+				return nil
+			}
+
+			nodesBucket := topicsBucket.Bucket(topicId).Bucket([]byte(KeyNodes))
+			if nodesBucket == nil {
+				return nil
+			}
+
+			return updateEachTx(nodesBucket, func(data []byte) (interface{}, error) {
+				var node openapi.NodeData
+				err := json.Unmarshal(data, &node)
+				node.YoutubeLinks = segmentVideoLinks(node.YoutubeLinks)
+				return node, err
+			})
+		})
+		if err != nil {
+			return
+		}
+	}
+
+	usersBucket := tx.Bucket([]byte(KeyUsers))
+	if usersBucket == nil {
+		return
+	}
+
+	return updateEachTx(usersBucket, func(data []byte) (interface{}, error) {
+		var user openapi.User
+		err := json.Unmarshal(data, &user)
+		user.Linked = segmentVideoLinks(user.Linked)
+		return user, err
+	})
+}
+
+// canonicalResourcesTx stores every resource with its link in canonical form, YouTube links of nodes become resources.
+// Resources a node had more than once become one with their votes added up
+func canonicalResourcesTx(tx *bolt.Tx) (err error) {
+	topicsBucket := tx.Bucket([]byte(KeyTopics))
+	if topicsBucket != nil {
+		err = topicsBucket.ForEach(func(topicId, v []byte) error {
+			if v != nil {
+				return nil
+			}
+
+			nodesBucket := topicsBucket.Bucket(topicId).Bucket([]byte(KeyNodes))
+			if nodesBucket == nil {
+				return nil
+			}
+
+			return updateEachTx(nodesBucket, func(data []byte) (interface{}, error) {
+				var node openapi.NodeData
+				err := json.Unmarshal(data, &node)
+				node.Resources = mergeResources(append(node.Resources, node.YoutubeLinks...))
+				node.YoutubeLinks = nil
+				return node, err
+			})
+		})
+		if err != nil {
+			return
+		}
+	}
+
+	usersBucket := tx.Bucket([]byte(KeyUsers))
+	if usersBucket != nil {
+		err = updateEachTx(usersBucket, func(data []byte) (interface{}, error) {
+			var user openapi.User
+			err := json.Unmarshal(data, &user)
+			user.Linked = mergeResources(user.Linked)
+			user.VideoUp = canonicalResourceLinks(user.VideoUp)
+			user.VideoDown = canonicalResourceLinks(user.VideoDown)
+			return user, err
+		})
+		if err != nil {
+			return
+		}
+	}
+
+	// votes and reputation are counted per video
+	votesBucket := tx.Bucket([]byte(KeyVotes))
+	if votesBucket != nil {
+		err = updateEachTx(votesBucket, func(data []byte) (interface{}, error) {
+			var record openapi.VoteRecord
+			err := json.Unmarshal(data, &record)
+			if record.Link != "" {
+				record.Link = canonicalResourceLink(record.Link)
+			}
+			return record, err
+		})
+		if err != nil {
+			return
+		}
+	}
+
+	historyBucket := tx.Bucket([]byte(KeyReputationHistory))
+	if historyBucket == nil {
+		return
+	}
+
+	return historyBucket.ForEach(func(userId, v []byte) error {
+		if v != nil {
+			return nil
+		}
+
+		return updateEachTx(historyBucket.Bucket(userId), func(data []byte) (interface{}, error) {
+			var event openapi.ReputationEvent
+			err := json.Unmarshal(data, &event)
+			if event.Link != "" {
+				event.Link = canonicalResourceLink(event.Link)
+			}
+			return event, err
+		})
+	})
+}
+
+// updateEachTx rewrites every value of the bucket, bolt doesn't allow changing a bucket while going over it
+func updateEachTx(bucket *bolt.Bucket, update func(data []byte) (interface{}, error)) (err error) {
+	updated := make(map[string][]byte)
+	err = bucket.ForEach(func(k, v []byte) error {
+		if v == nil {
+			return nil
+		}
+
+		value, err := update(v)
+		if err != nil {
+			return err
+		}
+
+		marshal, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+
+		updated[string(k)] = marshal
+
+		return nil
+	})
+	if err != nil {
+		return
+	}
+
+	for key, value := range updated {
+		err = bucket.Put([]byte(key), value)
+		if err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// the first time a resource shows up is kept, later ones add their votes to it
+func mergeResources(links []openapi.LinkData) []openapi.LinkData {
+	if links == nil {
+		return nil
+	}
+
+	merged := make([]openapi.LinkData, 0, len(links))
+	index := make(map[string]int)
+	for _, link := range links {
+		// a resource that isn't valid any more is kept as it was
+		if data, err := resourceData(link); err == nil {
+			link = data
+		}
+		if i, ok := index[link.Link]; ok {
+			merged[i].Votes += link.Votes
+			continue
+		}
+
+		index[link.Link] = len(merged)
+		merged = append(merged, link)
+	}
+
+	return merged
+}
+
+func canonicalResourceLinks(links []string) []string {
+	if links == nil {
+		return nil
+	}
+
+	canonical := make([]string, 0, len(links))
+	seen := make(map[string]bool)
+	for _, link := range links {
+		link = canonicalResourceLink(link)
+		if !seen[link] {
+			seen[link] = true
+			canonical = append(canonical, link)
+		}
+	}
+
+	return canonical
+}
+
+// the first time a video shows up is kept, later ones add their votes to it
+func mergeVideoLinks(links []openapi.LinkData) []openapi.LinkData {
+	if links == nil {
+		return nil
+	}
+
+	merged := make([]openapi.LinkData, 0, len(links))
+	index := make(map[string]int)
+	for _, link := range links {
+		if id, query, err := parseCanonicalVideoLink(link.Link); err == nil {
+			link.Link = "https://www.youtube.com/watch?v=" + id
+			link.VideoId = id
+			if link.Start == 0 {
+				link.Start = parseCanonicalVideoStart(query)
+			}
+			if link.Playlist == "" {
+				link.Playlist = query.Get("list")
+			}
+		} else {
+			link.Link = strings.TrimSpace(link.Link)
+		}
+		if i, ok := index[link.Link]; ok {
+			merged[i].Votes += link.Votes
+			continue
+		}
+
+		index[link.Link] = len(merged)
+		merged = append(merged, link)
+	}
+
+	return merged
+}
+
+func segmentVideoLinks(links []openapi.LinkData) []openapi.LinkData {
+	for i, link := range links {
+		if data, err := videoLinkData(link); err == nil {
+			links[i] = data
+		}
+	}
+
+	return links
+}
+
+func canonicalVideoLinks(links []string) []string {
+	if links == nil {
+		return nil
+	}
+
+	canonical := make([]string, 0, len(links))
+	seen := make(map[string]bool)
+	for _, link := range links {
+		link = canonicalVideoLink(link)
+		if !seen[link] {
+			seen[link] = true
+			canonical = append(canonical, link)
+		}
+	}
+
+	return canonical
+}
+
+// canonicalVideoLink returns the canonical link of a YouTube video, other links are only trimmed
+func canonicalVideoLink(link string) string {
+	id, _, err := parseCanonicalVideoLink(link)
+	if err != nil {
+		return strings.TrimSpace(link)
+	}
+
+	return "https://www.youtube.com/watch?v=" + id
+}
+
+// YouTube links as canonicalVideoLinksTx read them, the parser of the server goes on changing without it
+var canonicalVideoHosts = map[string]bool{
+	"youtube.com":              true,
+	"www.youtube.com":          true,
+	"m.youtube.com":            true,
+	"music.youtube.com":        true,
+	"youtube-nocookie.com":     true,
+	"www.youtube-nocookie.com": true,
+}
+
+var canonicalVideoIdPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{11}$`)
+
+var canonicalVideoTimePattern = regexp.MustCompile(`^(?:(\d+)h)?(?:(\d+)m)?(?:(\d+)s?)?$`)
+
+func parseCanonicalVideoLink(link string) (id string, query url.Values, err error) {
+	link = strings.TrimSpace(link)
+	if !strings.Contains(link, "://") {
+		link = "https://" + link
+	}
+
+	parsed, err := url.Parse(link)
+	if err != nil {
+		return id, query, fmt.Errorf("invalid link %s", link)
+	}
+
+	host := strings.ToLower(parsed.Hostname())
+	segments := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+	query = parsed.Query()
+
+	switch {
+	case host == "youtu.be" || host == "www.youtu.be":
+		id = segments[0]
+	case !canonicalVideoHosts[host]:
+		return "", query, fmt.Errorf("not a YouTube link %s", link)
+	case segments[0] == "watch":
+		id = query.Get("v")
+	case len(segments) > 1 && (segments[0] == "shorts" || segments[0] == "embed" || segments[0] == "live" || segments[0] == "v"):
+		id = segments[1]
+	}
+
+	if !canonicalVideoIdPattern.MatchString(id) {
+		return "", query, fmt.Errorf("can't find the video in %s", link)
+	}
+
+	return
+}
+
+// the start of the link in seconds, anything that can't be read starts at the beginning
+func parseCanonicalVideoStart(query url.Values) (seconds int32) {
+	value := query.Get("t")
+	if value == "" {
+		value = query.Get("start")
+	}
+
+	match := canonicalVideoTimePattern.FindStringSubmatch(value)
+	if value == "" || match == nil {
+		return 0
+	}
+
+	for i, unit := range []int32{3600, 60, 1} {
+		if match[i+1] == "" {
+			continue
+		}
+
+		n, err := strconv.ParseInt(match[i+1], 10, 32)
+		if err != nil {
+			return 0
+		}
+		seconds += int32(n) * unit
+	}
+
+	return
+}
+
+// links stored while a start alone made a segment, the start is only where they were shared from
+var startTimeLinkPattern = regexp.MustCompile(`^(https://www\.youtube\.com/watch\?v=[A-Za-z0-9_-]{11})&t=[0-9]+$`)
+
+func withoutStartTime(link string) string {
+	if match := startTimeLinkPattern.FindStringSubmatch(link); match != nil {
+		return match[1]
+	}
+
+	return link
+}
+
+// foldStartTimesTx makes links that only had a start the video they play again, everything kept by link follows.
+// What a node or user had twice becomes one, the votes of resources are added up and their up and down votes counted again
+func foldStartTimesTx(tx *bolt.Tx) (err error) {
+	up := make(map[string]int32)
+	down := make(map[string]int32)
+
+	usersBucket := tx.Bucket([]byte(KeyUsers))
+	if usersBucket != nil {
+		err = updateEachTx(usersBucket, func(data []byte) (interface{}, error) {
+			var user openapi.User
+			err := json.Unmarshal(data, &user)
+			if err != nil {
+				return user, err
+			}
+
+			user.Linked = foldStartTimeResources(user.Linked)
+			user.VideoUp = foldStartTimeLinks(user.VideoUp)
+			user.VideoDown = foldStartTimeLinks(user.VideoDown)
+
+			if user.VideoVotes != nil {
+				votes := make([]openapi.VideoVote, 0, len(user.VideoVotes))
+				seen := make(map[string]bool)
+				for _, vote := range user.VideoVotes {
+					vote.Link = withoutStartTime(vote.Link)
+					key := videoVoteKey(vote.Topic, vote.NodeId, vote.Link)
+					if seen[key] {
+						continue
+					}
+					seen[key] = true
+					votes = append(votes, vote)
+
+					if vote.Value > 0 {
+						up[key]++
+					} else if vote.Value < 0 {
+						down[key]++
+					}
+				}
+				user.VideoVotes = votes
+			}
+
+			return user, nil
+		})
+		if err != nil {
+			return
+		}
+	}
+
+	topicsBucket := tx.Bucket([]byte(KeyTopics))
+	if topicsBucket != nil {
+		err = topicsBucket.ForEach(func(topicId, v []byte) error {
+			if v != nil {
+				return nil
+			}
+
+			nodesBucket := topicsBucket.Bucket(topicId).Bucket([]byte(KeyNodes))
+			if nodesBucket == nil {
+				return nil
+			}
+
+			return updateEachTx(nodesBucket, func(data []byte) (interface{}, error) {
+				var node openapi.NodeData
+				err := json.Unmarshal(data, &node)
+				node.Resources = foldStartTimeResources(node.Resources)
+				for i, resource := range node.Resources {
+					if resource.Provider == KeyProviderYouTube {
+						node.Resources[i].Up = up[videoVoteKey(string(topicId), node.Id, resource.Link)]
+						node.Resources[i].Down = down[videoVoteKey(string(topicId), node.Id, resource.Link)]
+					}
+				}
+				return node, err
+			})
+		})
+		if err != nil {
+			return
+		}
+	}
+
+	votesBucket := tx.Bucket([]byte(KeyVotes))
+	if votesBucket != nil {
+		err = updateEachTx(votesBucket, func(data []byte) (interface{}, error) {
+			var record openapi.VoteRecord
+			err := json.Unmarshal(data, &record)
+			record.Link = withoutStartTime(record.Link)
+			return record, err
+		})
+		if err != nil {
+			return
+		}
+	}
+
+	historyBucket := tx.Bucket([]byte(KeyReputationHistory))
+	if historyBucket != nil {
+		err = historyBucket.ForEach(func(userId, v []byte) error {
+			if v != nil {
+				return nil
+			}
+
+			return updateEachTx(historyBucket.Bucket(userId), func(data []byte) (interface{}, error) {
+				var event openapi.ReputationEvent
+				err := json.Unmarshal(data, &event)
+				event.Link = withoutStartTime(event.Link)
+				return event, err
+			})
+		})
+		if err != nil {
+			return
+		}
+	}
+
+	moderationBucket := tx.Bucket([]byte(KeyModeration))
+	if moderationBucket != nil {
+		err = updateEachTx(moderationBucket, func(data []byte) (interface{}, error) {
+			var moderationCase openapi.ModerationCase
+			err := json.Unmarshal(data, &moderationCase)
+			for i, report := range moderationCase.Reports {
+				moderationCase.Reports[i].Link = withoutStartTime(report.Link)
+			}
+			return moderationCase, err
+		})
+		if err != nil {
+			return
+		}
+	}
+
+	// the latest progress is kept, a video finished under either link stays finished
+	watchesBucket := tx.Bucket([]byte(KeyWatches))
+	if watchesBucket != nil {
+		err = watchesBucket.ForEach(func(userId, v []byte) error {
+			if v != nil {
+				return nil
+			}
+
+			userBucket := watchesBucket.Bucket(userId)
+			err := foldStartTimeKeysTx(userBucket, func(kept, other []byte) ([]byte, error) {
+				var keptProgress, otherProgress openapi.WatchProgress
+				err := json.Unmarshal(kept, &keptProgress)
+				if err != nil {
+					return nil, err
+				}
+				err = json.Unmarshal(other, &otherProgress)
+				if err != nil {
+					return nil, err
+				}
+
+				finished := keptProgress.Finished || otherProgress.Finished
+				if otherProgress.UpdatedAt.After(keptProgress.UpdatedAt) {
+					keptProgress = otherProgress
+				}
+				keptProgress.Finished = finished
+
+				return json.Marshal(keptProgress)
+			})
+			if err != nil {
+				return err
+			}
+
+			return updateEachTx(userBucket, func(data []byte) (interface{}, error) {
+				var progress openapi.WatchProgress
+				err := json.Unmarshal(data, &progress)
+				progress.Link = withoutStartTime(progress.Link)
+				return progress, err
+			})
+		})
+		if err != nil {
+			return
+		}
+	}
+
+	// a video counts on the leaderboards from the first day it was up voted
+	leaderboardsBucket := tx.Bucket([]byte(KeyLeaderboards))
+	if leaderboardsBucket != nil {
+		scopes := []*bolt.Bucket{leaderboardsBucket.Bucket([]byte(KeyLeaderboardGlobal))}
+		if topicsBucket := leaderboardsBucket.Bucket([]byte(KeyTopics)); topicsBucket != nil {
+			err = topicsBucket.ForEach(func(topicId, v []byte) error {
+				if v == nil {
+					scopes = append(scopes, topicsBucket.Bucket(topicId))
+				}
+				return nil
+			})
+			if err != nil {
+				return
+			}
+		}
+
+		for _, scopeBucket := range scopes {
+			if scopeBucket == nil || scopeBucket.Bucket([]byte(KeyLeaderboardVideos)) == nil {
+				continue
+			}
+
+			videosBucket := scopeBucket.Bucket([]byte(KeyLeaderboardVideos))
+			err = videosBucket.ForEach(func(userId, v []byte) error {
+				if v != nil {
+					return nil
+				}
+
+				return foldStartTimeKeysTx(videosBucket.Bucket(userId), func(kept, other []byte) ([]byte, error) {
+					if string(other) < string(kept) {
+						return other, nil
+					}
+					return kept, nil
+				})
+			})
+			if err != nil {
+				return
+			}
+		}
+	}
+
+	// the index keeps the link each node has a video with
+	return buildVideoIndexTx(tx)
+}
+
+func foldStartTimeResources(resources []openapi.LinkData) []openapi.LinkData {
+	if resources == nil {
+		return nil
+	}
+
+	folded := make([]openapi.LinkData, 0, len(resources))
+	index := make(map[string]int)
+	for _, resource := range resources {
+		resource.Link = withoutStartTime(resource.Link)
+		if i, ok := index[resource.Link]; ok {
+			folded[i].Votes += resource.Votes
+			continue
+		}
+
+		index[resource.Link] = len(folded)
+		folded = append(folded, resource)
+	}
+
+	return folded
+}
+
+func foldStartTimeLinks(links []string) []string {
+	if links == nil {
+		return nil
+	}
+
+	folded := make([]string, 0, len(links))
+	seen := make(map[string]bool)
+	for _, link := range links {
+		link = withoutStartTime(link)
+		if !seen[link] {
+			seen[link] = true
+			folded = append(folded, link)
+		}
+	}
+
+	return folded
+}
+
+// foldStartTimeKeysTx moves values kept under a link with a start to the link without it, merge picks what stays when both are there
+func foldStartTimeKeysTx(bucket *bolt.Bucket, merge func(kept, other []byte) ([]byte, error)) (err error) {
+	moved := make(map[string][]byte)
+	err = bucket.ForEach(func(k, v []byte) error {
+		if v != nil && withoutStartTime(string(k)) != string(k) {
+			moved[string(k)] = v
+		}
+		return nil
+	})
+	if err != nil {
+		return
+	}
+
+	for key, value := range moved {
+		err = bucket.Delete([]byte(key))
+		if err != nil {
+			return
+		}
+
+		target := []byte(withoutStartTime(key))
+		if kept := bucket.Get(target); kept != nil {
+			value, err = merge(kept, value)
+			if err != nil {
+				return
+			}
+		}
+
+		err = bucket.Put(target, value)
+		if err != nil {
+			return
+		}
+	}
+
+	return
+}
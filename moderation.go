@@ -0,0 +1,166 @@
+package main
+
+import (
+	"context"
+	"errors"
+
+	openapi "github.com/SpyLime/flowBackend/go"
+	"github.com/go-pkgz/auth/token"
+	bolt "go.etcd.io/bbolt"
+)
+
+// ModerationAPIServiceImpl is a service that implements the logic for the ModerationAPIServicer
+// This service should implement the business logic for every endpoint for the ModerationAPI API.
+// Include any external packages or services that will be required by this service.
+type ModerationAPIServiceImpl struct {
+	db         *bolt.DB
+	clock      Clock
+	policy     Policy
+	moderation ModerationConfig
+}
+
+// NewModerationAPIService creates a default api service
+func NewModerationAPIServiceImpl(db *bolt.DB, clock Clock, policy Policy, moderation ModerationConfig) openapi.ModerationAPIServicer {
+	return &ModerationAPIServiceImpl{
+		db:         db,
+		clock:      clock,
+		policy:     policy,
+		moderation: moderation,
+	}
+}
+
+// GetModerationQueue - get the flagged nodes the user can moderate, oldest first
+func (s *ModerationAPIServiceImpl) GetModerationQueue(ctx context.Context, status string) (openapi.ImplResponse, error) {
+	user, ok := ctx.Value(userInfoKey).(token.User)
+	if !ok {
+		return openapi.Response(401, nil), errors.New("unauthorized: user not found in context")
+	}
+
+	cases, err := getModerationCases(s.db, status)
+	if err != nil {
+		return openapi.Response(400, nil), err
+	}
+
+	// the queue spans every topic the user moderates
+	canModerate := make(map[string]bool)
+	response := make([]openapi.ModerationCase, 0, len(cases))
+	for _, moderationCase := range cases {
+		allowed, checked := canModerate[moderationCase.Topic]
+		if !checked {
+			allowed = s.checkModerator(user.ID, moderationCase.Topic) == nil
+			canModerate[moderationCase.Topic] = allowed
+		}
+
+		if allowed {
+			response = append(response, moderationCase)
+		}
+	}
+
+	return openapi.Response(200, response), nil
+}
+
+// ReportNode - flag a node with a reason
+func (s *ModerationAPIServiceImpl) ReportNode(ctx context.Context, flagReport openapi.FlagReport) (openapi.ImplResponse, error) {
+	user, ok := ctx.Value(userInfoKey).(token.User)
+	if !ok {
+		return openapi.Response(401, nil), errors.New("unauthorized: user not found in context")
+	}
+
+	// hidden topics look the same as missing ones
+	visible, err := topicVisible(s.db, flagReport.Topic, user.ID)
+	if err != nil || !visible {
+		return openapi.Response(404, nil), errors.New("topic not found")
+	}
+
+	userDetails, err := getUserForTopic(s.db, user.ID, flagReport.Topic)
+	if err != nil {
+		return openapi.Response(401, nil), err
+	}
+
+	err = s.policy.check(s.db, s.clock, KeyActionFlag, userDetails, PolicyTarget{TopicId: flagReport.Topic})
+	if err != nil {
+		return openapi.Response(401, nil), err
+	}
+
+	flagReport.ReporterId = user.ID
+
+	response, err := reportFlag(s.db, s.clock, s.moderation, flagReport)
+	if err != nil {
+		return openapi.Response(400, nil), err
+	}
+
+	return openapi.Response(200, response), nil
+}
+
+// AssignModerationCase - assign a case to a moderator
+func (s *ModerationAPIServiceImpl) AssignModerationCase(ctx context.Context, caseId string, moderationAction openapi.ModerationAction) (openapi.ImplResponse, error) {
+	user, ok := ctx.Value(userInfoKey).(token.User)
+	if !ok {
+		return openapi.Response(401, nil), errors.New("unauthorized: user not found in context")
+	}
+
+	moderationCase, err := getModerationCase(s.db, caseId)
+	if err != nil {
+		return openapi.Response(404, nil), err
+	}
+
+	err = s.checkModerator(user.ID, moderationCase.Topic)
+	if err != nil {
+		return openapi.Response(401, nil), err
+	}
+
+	// cases can only be handed to someone who can moderate them
+	if moderationAction.AssignedTo != "" && s.checkModerator(moderationAction.AssignedTo, moderationCase.Topic) != nil {
+		return openapi.Response(400, nil), errors.New("the case can only be assigned to a moderator of the topic")
+	}
+
+	moderationAction.ModeratorId = user.ID
+
+	response, err := assignModerationCase(s.db, s.clock, caseId, moderationAction)
+	if err != nil {
+		return openapi.Response(400, nil), err
+	}
+
+	return openapi.Response(200, response), nil
+}
+
+// ResolveModerationCase - close a case after acting on the reports
+func (s *ModerationAPIServiceImpl) ResolveModerationCase(ctx context.Context, caseId string, moderationAction openapi.ModerationAction) (openapi.ImplResponse, error) {
+	return s.closeCase(ctx, caseId, KeyModerationResolve, moderationAction)
+}
+
+// DismissModerationCase - close a case without acting on the reports
+func (s *ModerationAPIServiceImpl) DismissModerationCase(ctx context.Context, caseId string, moderationAction openapi.ModerationAction) (openapi.ImplResponse, error) {
+	return s.closeCase(ctx, caseId, KeyModerationDismiss, moderationAction)
+}
+
+func (s *ModerationAPIServiceImpl) closeCase(ctx context.Context, caseId, action string, moderationAction openapi.ModerationAction) (openapi.ImplResponse, error) {
+	user, ok := ctx.Value(userInfoKey).(token.User)
+	if !ok {
+		return openapi.Response(401, nil), errors.New("unauthorized: user not found in context")
+	}
+
+	moderationCase, err := getModerationCase(s.db, caseId)
+	if err != nil {
+		return openapi.Response(404, nil), err
+	}
+
+	err = s.checkModerator(user.ID, moderationCase.Topic)
+	if err != nil {
+		return openapi.Response(401, nil), err
+	}
+
+	moderationAction.ModeratorId = user.ID
+
+	response, err := closeModerationCase(s.db, s.clock, caseId, action, moderationAction)
+	if err != nil {
+		return openapi.Response(400, nil), err
+	}
+
+	return openapi.Response(200, response), nil
+}
+
+// admins and moderators of the topic can work on its cases
+func (s *ModerationAPIServiceImpl) checkModerator(userId, topicId string) error {
+	return s.policy.checkUser(s.db, s.clock, KeyActionModerate, userId, PolicyTarget{TopicId: topicId})
+}
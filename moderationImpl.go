@@ -0,0 +1,604 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	openapi "github.com/SpyLime/flowBackend/go"
+	bolt "go.etcd.io/bbolt"
+)
+
+func reportFlag(db *bolt.DB, clock Clock, config ModerationConfig, report openapi.FlagReport) (response openapi.ModerationCase, err error) {
+	err = db.Update(func(tx *bolt.Tx) error {
+		response, err = reportFlagTx(tx, clock, config, report)
+		return err
+	})
+
+	return
+}
+
+// a report can hide the node or video right away when enough people reported it
+func reportFlagTx(tx *bolt.Tx, clock Clock, config ModerationConfig, report openapi.FlagReport) (response openapi.ModerationCase, err error) {
+	switch report.Reason {
+	case KeyReasonSpam, KeyReasonOffensive, KeyReasonIncorrect, KeyReasonCopyright, KeyReasonOther:
+	default:
+		return response, fmt.Errorf("unknown flag reason %s", report.Reason)
+	}
+
+	response, err = addReportTx(tx, clock, report)
+	if err != nil {
+		return
+	}
+
+	err = applyAutoHideTx(tx, clock, config, report.Topic, report.NodeId)
+	if err != nil {
+		return
+	}
+
+	return getModerationCaseRx(tx, response.Id)
+}
+
+// every report on a node goes into the same case, a closed case is opened again by a new report
+//
+// only the latest report of a reporter on the node or one of its videos is kept
+func addReportTx(tx *bolt.Tx, clock Clock, report openapi.FlagReport) (response openapi.ModerationCase, err error) {
+	node, err := getNodeRx(tx, report.NodeId.Format(time.RFC3339Nano), report.Topic)
+	if err != nil {
+		return
+	}
+
+	if report.Link != "" {
+		i := findVideo(node.Resources, report.Link)
+		if i < 0 {
+			return response, fmt.Errorf("can't find video")
+		}
+
+		// reports keep the link as it is stored so they match however the reporter wrote it
+		report.Link = node.Resources[i].Link
+	}
+
+	moderationBucket, err := tx.CreateBucketIfNotExists([]byte(KeyModeration))
+	if err != nil {
+		return
+	}
+
+	now := clock.Now()
+	report.CreatedAt = now
+
+	response, found, err := findModerationCaseRx(tx, report.Topic, report.NodeId)
+	if err != nil {
+		return
+	}
+
+	if !found {
+		id := RandomString(8)
+		for moderationBucket.Get([]byte(id)) != nil {
+			id = RandomString(8)
+		}
+
+		response = openapi.ModerationCase{
+			Id:        id,
+			Topic:     report.Topic,
+			NodeId:    report.NodeId,
+			Status:    KeyCaseOpen,
+			CreatedAt: now,
+		}
+
+		err = indexModerationCaseTx(tx, response)
+		if err != nil {
+			return
+		}
+	}
+
+	if response.Status != KeyCaseOpen {
+		response.Status = KeyCaseOpen
+		response.AssignedTo = ""
+		response.History = append(response.History, openapi.ModerationAction{
+			Action:      KeyModerationReopen,
+			ModeratorId: report.ReporterId,
+			CreatedAt:   now,
+		})
+	}
+
+	reports := make([]openapi.FlagReport, 0, len(response.Reports)+1)
+	for _, old := range response.Reports {
+		if old.ReporterId != report.ReporterId || old.Link != report.Link {
+			reports = append(reports, old)
+		}
+	}
+	response.Reports = append(reports, report)
+	response.UpdatedAt = now
+
+	err = putModerationCaseTx(moderationBucket, response)
+	if err != nil {
+		return
+	}
+
+	if !node.IsFlagged {
+		err = updateNodeFlagTx(tx, openapi.NodeData{Topic: report.Topic, Id: report.NodeId})
+	}
+
+	return
+}
+
+func applyAutoHide(db *bolt.DB, clock Clock, config ModerationConfig, topicId string, nodeId time.Time) (err error) {
+	err = db.Update(func(tx *bolt.Tx) error {
+		err = applyAutoHideTx(tx, clock, config, topicId, nodeId)
+		return err
+	})
+
+	return
+}
+
+// hides the node and any of its videos that passed a threshold and puts them in the moderation queue
+//
+// hidden content stays hidden until a moderator closes the case, after that only new reports or votes down hide it again
+func applyAutoHideTx(tx *bolt.Tx, clock Clock, config ModerationConfig, topicId string, nodeId time.Time) (err error) {
+	nodesBucket, nodeData, err := nodeDataFinderTx(tx, topicId, nodeId.Format(time.RFC3339Nano))
+	if err != nil {
+		return
+	}
+
+	var node openapi.NodeData
+	err = json.Unmarshal(nodeData, &node)
+	if err != nil {
+		return
+	}
+
+	// people reporting the node are under "" and people reporting a video under its link
+	reporters := make(map[string]map[string]bool)
+	moderationCase, found, err := findModerationCaseRx(tx, topicId, nodeId)
+	if err != nil {
+		return
+	}
+
+	// reports and votes a moderator already closed the case on don't count again
+	var closedAt time.Time
+	for _, action := range moderationCase.History {
+		if action.Action == KeyModerationResolve || action.Action == KeyModerationDismiss {
+			closedAt = action.CreatedAt
+		}
+	}
+
+	if found && moderationCase.Status == KeyCaseOpen {
+		for _, report := range moderationCase.Reports {
+			if report.ReporterId == "" || !report.CreatedAt.After(closedAt) {
+				continue
+			}
+			// reports from before links were canonical
+			link := canonicalResourceLink(report.Link)
+			if reporters[link] == nil {
+				reporters[link] = make(map[string]bool)
+			}
+			reporters[link][report.ReporterId] = true
+		}
+	}
+
+	// a vote threshold only hides again after a new vote down since the case was closed, links of videos are under their link
+	votedDown := make(map[string]bool)
+	if !closedAt.IsZero() {
+		votedDown, err = votedDownSinceRx(tx, topicId, nodeId, closedAt)
+		if err != nil {
+			return
+		}
+	}
+
+	votesLimit := func(link string, limit int32) int32 {
+		if !closedAt.IsZero() && !votedDown[link] {
+			return 0
+		}
+		return limit
+	}
+
+	var hidden []openapi.FlagReport
+
+	if !node.IsHidden {
+		reason := hideReason(config, len(reporters[""]), node.BattleTested, votesLimit("", config.HideNodeVotes))
+		if reason != "" {
+			node.IsHidden = true
+			node.HiddenReason = reason
+			hidden = append(hidden, openapi.FlagReport{Text: reason})
+		}
+	}
+
+	for i, video := range node.Resources {
+		if video.IsHidden {
+			continue
+		}
+
+		reason := hideReason(config, len(reporters[canonicalResourceLink(video.Link)]), video.Votes, votesLimit(canonicalResourceLink(video.Link), config.HideVideoVotes))
+		if reason != "" {
+			node.Resources[i].IsHidden = true
+			node.Resources[i].HiddenReason = reason
+			hidden = append(hidden, openapi.FlagReport{Text: reason, Link: video.Link})
+		}
+	}
+
+	if len(hidden) == 0 {
+		return
+	}
+
+	marshal, err := json.Marshal(node)
+	if err != nil {
+		return
+	}
+
+	err = nodesBucket.Put([]byte(nodeId.Format(time.RFC3339Nano)), marshal)
+	if err != nil {
+		return
+	}
+
+	// reports without a reporter are made by the server
+	for _, report := range hidden {
+		report.Topic = topicId
+		report.NodeId = nodeId
+		report.Reason = KeyReasonHidden
+
+		_, err = addReportTx(tx, clock, report)
+		if err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// votedDownSinceRx finds what of the node got a vote down after the time, "" is the node and a video is under its link.
+// Votes are kept in the order they happened so only the ones since are read
+func votedDownSinceRx(tx *bolt.Tx, topicId string, nodeId, since time.Time) (response map[string]bool, err error) {
+	response = make(map[string]bool)
+
+	votesBucket := tx.Bucket([]byte(KeyVotes))
+	if votesBucket == nil {
+		return
+	}
+
+	c := votesBucket.Cursor()
+	for k, v := c.Last(); k != nil; k, v = c.Prev() {
+		var record openapi.VoteRecord
+		err = json.Unmarshal(v, &record)
+		if err != nil {
+			return
+		}
+
+		if !record.CreatedAt.After(since) {
+			break
+		}
+
+		if record.Topic != topicId || !record.NodeId.Equal(nodeId) || record.Value >= 0 || record.Kind == KeyVoteFresh {
+			continue
+		}
+
+		response[canonicalResourceLink(record.Link)] = true
+	}
+
+	return
+}
+
+// the reason is shown to the author, an empty reason means the content stays visible
+func hideReason(config ModerationConfig, reporters int, votes, votesLimit int32) string {
+	if config.HideReporters > 0 && reporters >= config.HideReporters {
+		return fmt.Sprintf("hidden after being reported by %d people", reporters)
+	}
+
+	if votesLimit < 0 && votes <= votesLimit {
+		return fmt.Sprintf("hidden after being voted down to %d", votes)
+	}
+
+	return ""
+}
+
+func findVideo(videos []openapi.LinkData, link string) int {
+	for i, video := range videos {
+		if areSameResource(video.Link, link) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func putModerationCaseTx(moderationBucket *bolt.Bucket, moderationCase openapi.ModerationCase) (err error) {
+	marshal, err := json.Marshal(moderationCase)
+	if err != nil {
+		return
+	}
+
+	return moderationBucket.Put([]byte(moderationCase.Id), marshal)
+}
+
+// there is at most one case for a node, the case index finds it by the topic and node
+func findModerationCaseRx(tx *bolt.Tx, topicId string, nodeId time.Time) (response openapi.ModerationCase, found bool, err error) {
+	indexBucket := tx.Bucket([]byte(KeyModerationIndex))
+	if indexBucket == nil {
+		return
+	}
+
+	caseId := indexBucket.Get([]byte(moderationIndexKey(topicId, nodeId)))
+	if caseId == nil {
+		return
+	}
+
+	response, err = getModerationCaseRx(tx, string(caseId))
+
+	return response, err == nil, err
+}
+
+func moderationIndexKey(topicId string, nodeId time.Time) string {
+	return topicId + " " + nodeId.Format(time.RFC3339Nano)
+}
+
+func indexModerationCaseTx(tx *bolt.Tx, moderationCase openapi.ModerationCase) (err error) {
+	indexBucket, err := tx.CreateBucketIfNotExists([]byte(KeyModerationIndex))
+	if err != nil {
+		return
+	}
+
+	return indexBucket.Put([]byte(moderationIndexKey(moderationCase.Topic, moderationCase.NodeId)), []byte(moderationCase.Id))
+}
+
+// buildModerationIndexTx indexes the cases from before the index by their topic and node
+func buildModerationIndexTx(tx *bolt.Tx) (err error) {
+	moderationBucket := tx.Bucket([]byte(KeyModeration))
+	if moderationBucket == nil {
+		return
+	}
+
+	return moderationBucket.ForEach(func(_, v []byte) error {
+		var moderationCase openapi.ModerationCase
+		err := json.Unmarshal(v, &moderationCase)
+		if err != nil {
+			return err
+		}
+
+		return indexModerationCaseTx(tx, moderationCase)
+	})
+}
+
+func getModerationCase(db *bolt.DB, caseId string) (response openapi.ModerationCase, err error) {
+	err = db.View(func(tx *bolt.Tx) error {
+		response, err = getModerationCaseRx(tx, caseId)
+		return err
+	})
+
+	return
+}
+
+func getModerationCaseRx(tx *bolt.Tx, caseId string) (response openapi.ModerationCase, err error) {
+	moderationBucket := tx.Bucket([]byte(KeyModeration))
+	if moderationBucket == nil {
+		return response, fmt.Errorf("can't find moderation bucket")
+	}
+
+	caseData := moderationBucket.Get([]byte(caseId))
+	if caseData == nil {
+		return response, fmt.Errorf("can't find moderation case")
+	}
+
+	err = json.Unmarshal(caseData, &response)
+
+	return
+}
+
+func getModerationCases(db *bolt.DB, status string) (response []openapi.ModerationCase, err error) {
+	err = db.View(func(tx *bolt.Tx) error {
+		response, err = getModerationCasesRx(tx, status)
+		return err
+	})
+
+	return
+}
+
+// open cases are returned when no status is given, all returns every case
+//
+// oldest first so the queue is worked through in order
+func getModerationCasesRx(tx *bolt.Tx, status string) (response []openapi.ModerationCase, err error) {
+	if status == "" {
+		status = KeyCaseOpen
+	}
+
+	response = make([]openapi.ModerationCase, 0)
+
+	moderationBucket := tx.Bucket([]byte(KeyModeration))
+	if moderationBucket == nil {
+		return
+	}
+
+	c := moderationBucket.Cursor()
+	for k, v := c.First(); k != nil; k, v = c.Next() {
+		var moderationCase openapi.ModerationCase
+		err = json.Unmarshal(v, &moderationCase)
+		if err != nil {
+			return
+		}
+
+		if status != KeyCaseAll && moderationCase.Status != status {
+			continue
+		}
+
+		// cases of deleted topics can't be acted on anymore
+		_, infoErr := getTopicInfoRx(tx, moderationCase.Topic)
+		if infoErr != nil {
+			continue
+		}
+
+		response = append(response, moderationCase)
+	}
+
+	sort.SliceStable(response, func(i, j int) bool {
+		return response[i].CreatedAt.Before(response[j].CreatedAt)
+	})
+
+	return
+}
+
+func assignModerationCase(db *bolt.DB, clock Clock, caseId string, request openapi.ModerationAction) (response openapi.ModerationCase, err error) {
+	err = db.Update(func(tx *bolt.Tx) error {
+		response, err = assignModerationCaseTx(tx, clock, caseId, request)
+		return err
+	})
+
+	return
+}
+
+// the moderator takes the case when it isn't assigned to anyone else
+func assignModerationCaseTx(tx *bolt.Tx, clock Clock, caseId string, request openapi.ModerationAction) (response openapi.ModerationCase, err error) {
+	response, err = getModerationCaseRx(tx, caseId)
+	if err != nil {
+		return
+	}
+
+	if response.Status != KeyCaseOpen {
+		return response, fmt.Errorf("the case is already %s", response.Status)
+	}
+
+	if request.AssignedTo == "" {
+		request.AssignedTo = request.ModeratorId
+	}
+
+	_, err = getUserRx(tx, request.AssignedTo)
+	if err != nil {
+		return
+	}
+
+	request.Action = KeyModerationAssign
+	request.CreatedAt = clock.Now()
+
+	response.AssignedTo = request.AssignedTo
+	response.History = append(response.History, request)
+	response.UpdatedAt = request.CreatedAt
+
+	err = putModerationCaseTx(tx.Bucket([]byte(KeyModeration)), response)
+
+	return
+}
+
+func closeModerationCase(db *bolt.DB, clock Clock, caseId, action string, request openapi.ModerationAction) (response openapi.ModerationCase, err error) {
+	err = db.Update(func(tx *bolt.Tx) error {
+		response, err = closeModerationCaseTx(tx, clock, caseId, action, request)
+		return err
+	})
+
+	return
+}
+
+// resolving means the reports were acted on and dismissing that they were wrong, either way the flag and hidden state are cleared
+func closeModerationCaseTx(tx *bolt.Tx, clock Clock, caseId, action string, request openapi.ModerationAction) (response openapi.ModerationCase, err error) {
+	response, err = getModerationCaseRx(tx, caseId)
+	if err != nil {
+		return
+	}
+
+	if response.Status != KeyCaseOpen {
+		return response, fmt.Errorf("the case is already %s", response.Status)
+	}
+
+	request.Action = action
+	request.AssignedTo = ""
+	request.CreatedAt = clock.Now()
+
+	switch action {
+	case KeyModerationResolve:
+		response.Status = KeyCaseResolved
+	case KeyModerationDismiss:
+		response.Status = KeyCaseDismissed
+	default:
+		return response, fmt.Errorf("unknown moderation action %s", action)
+	}
+
+	response.History = append(response.History, request)
+	response.UpdatedAt = request.CreatedAt
+
+	err = putModerationCaseTx(tx.Bucket([]byte(KeyModeration)), response)
+	if err != nil {
+		return
+	}
+
+	err = restoreNodeTx(tx, response.Topic, response.NodeId)
+
+	return
+}
+
+func clearNodeFlag(db *bolt.DB, clock Clock, topicId string, nodeId time.Time, moderatorId string) (err error) {
+	err = db.Update(func(tx *bolt.Tx) error {
+		err = clearNodeFlagTx(tx, clock, topicId, nodeId, moderatorId)
+		return err
+	})
+
+	return
+}
+
+// unflagging a node outside of the queue resolves its open case
+func clearNodeFlagTx(tx *bolt.Tx, clock Clock, topicId string, nodeId time.Time, moderatorId string) (err error) {
+	moderationCase, found, err := findModerationCaseRx(tx, topicId, nodeId)
+	if err != nil {
+		return
+	}
+
+	if found && moderationCase.Status == KeyCaseOpen {
+		_, err = closeModerationCaseTx(tx, clock, moderationCase.Id, KeyModerationResolve, openapi.ModerationAction{
+			ModeratorId: moderatorId,
+			Note:        "unflagged",
+		})
+		return
+	}
+
+	return restoreNodeTx(tx, topicId, nodeId)
+}
+
+// clears the flag and hidden state of the node and its videos, a node that has been deleted since is left alone.
+// Videos hidden as dead links stay hidden until the link checker finds them working again
+func restoreNodeTx(tx *bolt.Tx, topicId string, nodeId time.Time) (err error) {
+	nodesBucket, nodeData, findErr := nodeDataFinderTx(tx, topicId, nodeId.Format(time.RFC3339Nano))
+	if findErr != nil {
+		return
+	}
+
+	var node openapi.NodeData
+	err = json.Unmarshal(nodeData, &node)
+	if err != nil {
+		return
+	}
+
+	node.IsFlagged = false
+	node.IsHidden = false
+	node.HiddenReason = ""
+	for i := range node.Resources {
+		if node.Resources[i].HiddenReason == KeyReasonDeadLink {
+			continue
+		}
+		node.Resources[i].IsHidden = false
+		node.Resources[i].HiddenReason = ""
+	}
+
+	marshal, err := json.Marshal(node)
+	if err != nil {
+		return
+	}
+
+	return nodesBucket.Put([]byte(nodeId.Format(time.RFC3339Nano)), marshal)
+}
+
+// hidden content is left out unless the viewer moderates it or made it, they also see why it is hidden
+func hideNodeContent(node openapi.NodeData, viewerId string, moderator bool) (response openapi.NodeData, visible bool) {
+	if moderator {
+		return node, true
+	}
+
+	if node.IsHidden && (viewerId == "" || node.CreatedBy.Id != viewerId) {
+		return node, false
+	}
+
+	resources := make([]openapi.LinkData, 0, len(node.Resources))
+	for _, resource := range node.Resources {
+		if !resource.IsHidden || (viewerId != "" && resource.AddedBy.Id == viewerId) {
+			resources = append(resources, resource)
+		}
+	}
+	node.Resources = resources
+	node.YoutubeLinks = youTubeResources(resources)
+
+	return node, true
+}
@@ -0,0 +1,257 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	openapi "github.com/SpyLime/flowBackend/go"
+	"github.com/go-pkgz/lgr"
+	"github.com/stretchr/testify/require"
+	bolt "go.etcd.io/bbolt"
+)
+
+func TestModerationCaseImpl(t *testing.T) {
+
+	lgr.Printf("INFO TestModerationCaseImpl")
+	t.Log("INFO TestModerationCaseImpl")
+	clock := TestClock{}
+	db, dbTearDown := OpenTestDB("ModerationCaseImpl")
+	defer dbTearDown()
+
+	users, topics, nodesAndEdges, err := CreateTestData(db, &clock, 2, 1, 0)
+	require.Nil(t, err)
+
+	nodeId := nodesAndEdges[0].SourceId
+	report := openapi.FlagReport{Topic: topics[0], NodeId: nodeId, Reason: KeyReasonSpam, Text: "ads", ReporterId: users[0]}
+
+	_, err = reportFlag(db, &clock, ModerationConfig{}, openapi.FlagReport{Topic: topics[0], NodeId: nodeId, Reason: "boring", ReporterId: users[0]})
+	require.NotNil(t, err)
+
+	first, err := reportFlag(db, &clock, ModerationConfig{}, report)
+	require.Nil(t, err)
+	require.Equal(t, KeyCaseOpen, first.Status)
+
+	node, err := getNode(db, nodeId.Format(time.RFC3339Nano), topics[0])
+	require.Nil(t, err)
+	require.True(t, node.IsFlagged)
+
+	// reports on the same node are combined and a reporter only counts once
+	clock.Tick()
+	report.Text = "more ads"
+	_, err = reportFlag(db, &clock, ModerationConfig{}, report)
+	require.Nil(t, err)
+
+	second, err := reportFlag(db, &clock, ModerationConfig{}, openapi.FlagReport{Topic: topics[0], NodeId: nodeId, Reason: KeyReasonOffensive, ReporterId: users[1]})
+	require.Nil(t, err)
+	require.Equal(t, first.Id, second.Id)
+	require.Equal(t, 2, len(second.Reports))
+	require.Equal(t, "more ads", second.Reports[0].Text)
+
+	node, err = getNode(db, nodeId.Format(time.RFC3339Nano), topics[0])
+	require.Nil(t, err)
+	require.True(t, node.IsFlagged)
+
+	queue, err := getModerationCases(db, "")
+	require.Nil(t, err)
+	require.Equal(t, 1, len(queue))
+
+	_, err = assignModerationCase(db, &clock, first.Id, openapi.ModerationAction{ModeratorId: users[0], AssignedTo: "missing"})
+	require.NotNil(t, err)
+
+	assigned, err := assignModerationCase(db, &clock, first.Id, openapi.ModerationAction{ModeratorId: users[0]})
+	require.Nil(t, err)
+	require.Equal(t, users[0], assigned.AssignedTo)
+
+	dismissed, err := closeModerationCase(db, &clock, first.Id, KeyModerationDismiss, openapi.ModerationAction{ModeratorId: users[0], Note: "not spam"})
+	require.Nil(t, err)
+	require.Equal(t, KeyCaseDismissed, dismissed.Status)
+	require.Equal(t, 2, len(dismissed.History))
+	require.Equal(t, KeyModerationDismiss, dismissed.History[1].Action)
+
+	_, err = closeModerationCase(db, &clock, first.Id, KeyModerationResolve, openapi.ModerationAction{ModeratorId: users[0]})
+	require.NotNil(t, err)
+
+	node, err = getNode(db, nodeId.Format(time.RFC3339Nano), topics[0])
+	require.Nil(t, err)
+	require.False(t, node.IsFlagged)
+
+	queue, err = getModerationCases(db, "")
+	require.Nil(t, err)
+	require.Equal(t, 0, len(queue))
+
+	queue, err = getModerationCases(db, KeyCaseDismissed)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(queue))
+
+	// a new report opens the case again and keeps its history
+	reopened, err := reportFlag(db, &clock, ModerationConfig{}, report)
+	require.Nil(t, err)
+	require.Equal(t, first.Id, reopened.Id)
+	require.Equal(t, KeyCaseOpen, reopened.Status)
+	require.Empty(t, reopened.AssignedTo)
+	require.Equal(t, 3, len(reopened.History))
+
+	err = clearNodeFlag(db, &clock, topics[0], nodeId, users[0])
+	require.Nil(t, err)
+
+	resolved, err := getModerationCase(db, first.Id)
+	require.Nil(t, err)
+	require.Equal(t, KeyCaseResolved, resolved.Status)
+
+	queue, err = getModerationCases(db, KeyCaseAll)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(queue))
+}
+
+func TestAutoHideImpl(t *testing.T) {
+
+	lgr.Printf("INFO TestAutoHideImpl")
+	t.Log("INFO TestAutoHideImpl")
+	clock := TestClock{}
+	db, dbTearDown := OpenTestDB("AutoHideImpl")
+	defer dbTearDown()
+
+	users, topics, nodesAndEdges, err := CreateTestData(db, &clock, 3, 1, 1)
+	require.Nil(t, err)
+
+	config := ModerationConfig{HideReporters: 2, HideVideoVotes: -2}
+	rootId := nodesAndEdges[0].SourceId
+	childId := nodesAndEdges[1].TargetId
+
+	// one report isn't enough
+	_, err = reportFlag(db, &clock, config, openapi.FlagReport{Topic: topics[0], NodeId: childId, Reason: KeyReasonSpam, ReporterId: users[0]})
+	require.Nil(t, err)
+
+	node, err := getNode(db, childId.Format(time.RFC3339Nano), topics[0])
+	require.Nil(t, err)
+	require.False(t, node.IsHidden)
+
+	clock.Tick()
+	moderationCase, err := reportFlag(db, &clock, config, openapi.FlagReport{Topic: topics[0], NodeId: childId, Reason: KeyReasonSpam, ReporterId: users[1]})
+	require.Nil(t, err)
+	require.Equal(t, 3, len(moderationCase.Reports))
+	require.Equal(t, KeyReasonHidden, moderationCase.Reports[2].Reason)
+
+	node, err = getNode(db, childId.Format(time.RFC3339Nano), topics[0])
+	require.Nil(t, err)
+	require.True(t, node.IsHidden)
+	require.NotEmpty(t, node.HiddenReason)
+
+	outsider := users[0]
+	if outsider == node.CreatedBy.Id {
+		outsider = users[1]
+	}
+
+	// only moderators and the author still see it
+	_, visible := hideNodeContent(node, outsider, false)
+	require.False(t, visible)
+	_, visible = hideNodeContent(node, "", false)
+	require.False(t, visible)
+	_, visible = hideNodeContent(node, node.CreatedBy.Id, false)
+	require.True(t, visible)
+	_, visible = hideNodeContent(node, outsider, true)
+	require.True(t, visible)
+
+	full, err := getMapForViewer(db, topics[0], "", true)
+	require.Nil(t, err)
+	require.Equal(t, 2, len(full.Nodes))
+	require.Equal(t, 1, len(full.Edges))
+
+	filtered, err := getMapForViewer(db, topics[0], "", false)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(filtered.Nodes))
+	require.Equal(t, 0, len(filtered.Edges))
+
+	next, err := getNextNode(db, rootId.Format(time.RFC3339Nano), topics[0], "battleTested")
+	require.Nil(t, err)
+	require.Empty(t, next)
+
+	// a downvoted video is hidden on its own
+	err = db.Update(func(tx *bolt.Tx) error {
+		nodesBucket, _, err := nodeDataFinderTx(tx, topics[0], rootId.Format(time.RFC3339Nano))
+		if err != nil {
+			return err
+		}
+
+		root, err := getNodeRx(tx, rootId.Format(time.RFC3339Nano), topics[0])
+		if err != nil {
+			return err
+		}
+
+		root.Resources = []openapi.LinkData{
+			{Link: "https://youtu.be/good", Votes: 3, AddedBy: openapi.UserIdentifier{Id: users[0]}},
+			{Link: "https://youtu.be/bad", Votes: -2, AddedBy: openapi.UserIdentifier{Id: users[1]}},
+		}
+
+		marshal, err := json.Marshal(root)
+		if err != nil {
+			return err
+		}
+
+		return nodesBucket.Put([]byte(rootId.Format(time.RFC3339Nano)), marshal)
+	})
+	require.Nil(t, err)
+
+	err = applyAutoHide(db, &clock, config, topics[0], rootId)
+	require.Nil(t, err)
+
+	root, err := getNode(db, rootId.Format(time.RFC3339Nano), topics[0])
+	require.Nil(t, err)
+	require.False(t, root.IsHidden)
+	require.True(t, root.YoutubeLinks[1].IsHidden)
+
+	shown, visible := hideNodeContent(root, users[2], false)
+	require.True(t, visible)
+	require.Equal(t, 1, len(shown.YoutubeLinks))
+
+	shown, _ = hideNodeContent(root, users[1], false)
+	require.Equal(t, 2, len(shown.YoutubeLinks))
+
+	queue, err := getModerationCases(db, "")
+	require.Nil(t, err)
+	require.Equal(t, 2, len(queue))
+
+	// closing the case shows the content again
+	_, err = closeModerationCase(db, &clock, moderationCase.Id, KeyModerationDismiss, openapi.ModerationAction{ModeratorId: users[2]})
+	require.Nil(t, err)
+
+	node, err = getNode(db, childId.Format(time.RFC3339Nano), topics[0])
+	require.Nil(t, err)
+	require.False(t, node.IsHidden)
+	require.False(t, node.IsFlagged)
+
+	// old reports don't count once the case is opened again
+	clock.Tick()
+	_, err = reportFlag(db, &clock, config, openapi.FlagReport{Topic: topics[0], NodeId: childId, Reason: KeyReasonSpam, ReporterId: users[0]})
+	require.Nil(t, err)
+
+	node, err = getNode(db, childId.Format(time.RFC3339Nano), topics[0])
+	require.Nil(t, err)
+	require.False(t, node.IsHidden)
+
+	// a video a moderator shows again stays shown until it is voted down again
+	clock.Tick()
+	_, err = closeModerationCase(db, &clock, queue[1].Id, KeyModerationResolve, openapi.ModerationAction{ModeratorId: users[2]})
+	require.Nil(t, err)
+
+	clock.Tick()
+	err = applyAutoHide(db, &clock, config, topics[0], rootId)
+	require.Nil(t, err)
+
+	root, err = getNode(db, rootId.Format(time.RFC3339Nano), topics[0])
+	require.Nil(t, err)
+	require.False(t, root.YoutubeLinks[1].IsHidden)
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		return voteReputationTx(tx, &clock, openapi.VoteRecord{VoterId: users[2], CreatorId: users[1], Topic: topics[0], NodeId: rootId, Link: "https://youtu.be/bad", Kind: KeyVoteVideo, Value: -1})
+	})
+	require.Nil(t, err)
+
+	err = applyAutoHide(db, &clock, config, topics[0], rootId)
+	require.Nil(t, err)
+
+	root, err = getNode(db, rootId.Format(time.RFC3339Nano), topics[0])
+	require.Nil(t, err)
+	require.True(t, root.YoutubeLinks[1].IsHidden)
+}
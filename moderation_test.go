@@ -0,0 +1,210 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	openapi "github.com/SpyLime/flowBackend/go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModerationQueue(t *testing.T) {
+	clock := TestClock{}
+	db, tearDown := FullStartTestServer("ModerationQueue", 8088, "")
+	defer tearDown()
+
+	users, topics, nodesAndEdges, err := CreateTestData(db, &clock, 3, 2, 0)
+	require.Nil(t, err)
+
+	info, err := getTopicInfo(db, topics[0])
+	require.Nil(t, err)
+
+	var others []string
+	for _, id := range users {
+		err = UpdateUserRoleAndReputation(db, id, false, 0)
+		require.Nil(t, err)
+		if id != info.CreatedBy {
+			others = append(others, id)
+		}
+	}
+	reporter, moderator := others[0], others[1]
+
+	_, err = updateTopicRole(db, topics[0], openapi.TopicRole{UserId: moderator, Role: KeyTopicModerator})
+	require.Nil(t, err)
+
+	client := &http.Client{}
+
+	// report the root node of both topics
+	SetTestLoginUser(reporter)
+	for _, nodeIds := range nodesAndEdges {
+		for _, topic := range topics {
+			if _, err := getNode(db, nodeIds.SourceId.Format(time.RFC3339Nano), topic); err != nil {
+				continue
+			}
+
+			marshal, err := json.Marshal(openapi.FlagReport{Topic: topic, NodeId: nodeIds.SourceId, Reason: KeyReasonIncorrect, Text: "wrong"})
+			require.Nil(t, err)
+
+			req, _ := http.NewRequest(http.MethodPost, "http://127.0.0.1:8088/api/v1/moderation/report", bytes.NewBuffer(marshal))
+
+			resp, err := client.Do(req)
+			require.Nil(t, err)
+			defer resp.Body.Close()
+			require.Equal(t, 200, resp.StatusCode)
+		}
+	}
+
+	// the reporter isn't a moderator so their queue is empty
+	req, _ := http.NewRequest(http.MethodGet, "http://127.0.0.1:8088/api/v1/moderation", nil)
+
+	resp, err := client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 200, resp.StatusCode)
+
+	var queue []openapi.ModerationCase
+	err = json.NewDecoder(resp.Body).Decode(&queue)
+	require.Nil(t, err)
+	require.Equal(t, 0, len(queue))
+
+	// the moderator only sees the topic they moderate
+	SetTestLoginUser(moderator)
+
+	req, _ = http.NewRequest(http.MethodGet, "http://127.0.0.1:8088/api/v1/moderation", nil)
+
+	resp, err = client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 200, resp.StatusCode)
+
+	err = json.NewDecoder(resp.Body).Decode(&queue)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(queue))
+	require.Equal(t, topics[0], queue[0].Topic)
+	require.Equal(t, reporter, queue[0].Reports[0].ReporterId)
+
+	// cases can't be handed to someone who can't moderate them
+	marshal, err := json.Marshal(openapi.ModerationAction{AssignedTo: reporter})
+	require.Nil(t, err)
+
+	req, _ = http.NewRequest(http.MethodPut, "http://127.0.0.1:8088/api/v1/moderation/"+queue[0].Id+"/assign", bytes.NewBuffer(marshal))
+
+	resp, err = client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 400, resp.StatusCode)
+
+	marshal, err = json.Marshal(openapi.ModerationAction{Note: "fixed the title"})
+	require.Nil(t, err)
+
+	req, _ = http.NewRequest(http.MethodPut, "http://127.0.0.1:8088/api/v1/moderation/"+queue[0].Id+"/resolve", bytes.NewBuffer(marshal))
+
+	resp, err = client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 200, resp.StatusCode)
+
+	var resolved openapi.ModerationCase
+	err = json.NewDecoder(resp.Body).Decode(&resolved)
+	require.Nil(t, err)
+	require.Equal(t, KeyCaseResolved, resolved.Status)
+	require.Equal(t, moderator, resolved.History[0].ModeratorId)
+
+	// the reporter can't dismiss the other case
+	all, err := getModerationCases(db, "")
+	require.Nil(t, err)
+	require.Equal(t, 1, len(all))
+
+	SetTestLoginUser(reporter)
+
+	req, _ = http.NewRequest(http.MethodPut, "http://127.0.0.1:8088/api/v1/moderation/"+all[0].Id+"/dismiss", bytes.NewBuffer(marshal))
+
+	resp, err = client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 401, resp.StatusCode)
+}
+
+func TestHiddenNode(t *testing.T) {
+	clock := TestClock{}
+	db, tearDown := FullStartTestServer("HiddenNode", 8088, "")
+	defer tearDown()
+
+	users, topics, nodesAndEdges, err := CreateTestData(db, &clock, 4, 1, 1)
+	require.Nil(t, err)
+
+	childId := nodesAndEdges[1].TargetId
+	child, err := getNode(db, childId.Format(time.RFC3339Nano), topics[0])
+	require.Nil(t, err)
+
+	var others []string
+	for _, id := range users {
+		err = UpdateUserRoleAndReputation(db, id, false, 0)
+		require.Nil(t, err)
+		if id != child.CreatedBy.Id {
+			others = append(others, id)
+		}
+	}
+
+	client := &http.Client{}
+
+	// enough reports hide the node
+	for _, reporter := range others {
+		SetTestLoginUser(reporter)
+
+		marshal, err := json.Marshal(openapi.FlagReport{Topic: topics[0], NodeId: childId, Reason: KeyReasonOffensive})
+		require.Nil(t, err)
+
+		req, _ := http.NewRequest(http.MethodPost, "http://127.0.0.1:8088/api/v1/moderation/report", bytes.NewBuffer(marshal))
+
+		resp, err := client.Do(req)
+		require.Nil(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, 200, resp.StatusCode)
+	}
+
+	params := url.Values{}
+	params.Add("nodeId", childId.Format(time.RFC3339Nano))
+	params.Add("tid", topics[0])
+	nodeURL := "http://127.0.0.1:8088/api/v1/node?" + params.Encode()
+
+	// other people can't find it
+	req, _ := http.NewRequest(http.MethodGet, nodeURL, nil)
+
+	resp, err := client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 404, resp.StatusCode)
+
+	req, _ = http.NewRequest(http.MethodGet, "http://127.0.0.1:8088/api/v1/map/"+topics[0], nil)
+
+	resp, err = client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 200, resp.StatusCode)
+
+	var mapData openapi.MapData
+	err = json.NewDecoder(resp.Body).Decode(&mapData)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(mapData.Nodes))
+
+	// the author still sees it with the reason
+	SetTestLoginUser(child.CreatedBy.Id)
+
+	req, _ = http.NewRequest(http.MethodGet, nodeURL, nil)
+
+	resp, err = client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 200, resp.StatusCode)
+
+	var hidden openapi.NodeData
+	err = json.NewDecoder(resp.Body).Decode(&hidden)
+	require.Nil(t, err)
+	require.True(t, hidden.IsHidden)
+	require.NotEmpty(t, hidden.HiddenReason)
+}
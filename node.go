@@ -7,6 +7,7 @@ import (
 
 	openapi "github.com/SpyLime/flowBackend/go"
 	"github.com/go-pkgz/auth/token"
+	"github.com/go-pkgz/lgr"
 	bolt "go.etcd.io/bbolt"
 )
 
@@ -14,15 +15,23 @@ import (
 // This service should implement the business logic for every endpoint for the NodeAPI API.
 // Include any external packages or services that will be required by this service.
 type NodeAPIServiceImpl struct {
-	db    *bolt.DB
-	clock Clock
+	db         *bolt.DB
+	clock      Clock
+	policy     Policy
+	moderation ModerationConfig
+	metadata   *MetadataService
+	watch      WatchConfig
 }
 
 // NewNodeAPIService creates a default api service
-func NewNodeAPIServiceImpl(db *bolt.DB, clock Clock) openapi.NodeAPIServicer {
+func NewNodeAPIServiceImpl(db *bolt.DB, clock Clock, policy Policy, moderation ModerationConfig, metadata *MetadataService, watch WatchConfig) openapi.NodeAPIServicer {
 	return &NodeAPIServiceImpl{
-		db:    db,
-		clock: clock,
+		db:         db,
+		clock:      clock,
+		policy:     policy,
+		moderation: moderation,
+		metadata:   metadata,
+		watch:      watch,
 	}
 }
 
@@ -33,7 +42,33 @@ func (s *NodeAPIServiceImpl) UpdateNodeBattleVote(ctx context.Context, updateNod
 		return openapi.Response(401, nil), errors.New("unauthorized: user not found in context")
 	}
 
-	vote, err := updateNodeBattleVote(s.db, updateNodeRequest, user.ID)
+	// hidden topics look the same as missing ones
+	visible, err := topicVisible(s.db, updateNodeRequest.Topic, user.ID)
+	if err != nil || !visible {
+		return openapi.Response(404, nil), errors.New("topic not found")
+	}
+
+	userDetails, err := getUserForTopic(s.db, user.ID, updateNodeRequest.Topic)
+	if err != nil {
+		return openapi.Response(401, nil), err
+	}
+
+	err = s.policy.check(s.db, s.clock, KeyActionVote, userDetails, PolicyTarget{TopicId: updateNodeRequest.Topic})
+	if err != nil {
+		return openapi.Response(401, nil), err
+	}
+
+	err = checkLock(s.db, s.clock, userDetails, updateNodeRequest.Topic, true, updateNodeRequest.Id)
+	if err != nil {
+		return openapi.Response(423, nil), err
+	}
+
+	vote, err := updateNodeBattleVote(s.db, s.clock, updateNodeRequest, user.ID)
+	if err != nil {
+		return openapi.Response(400, nil), err
+	}
+
+	err = applyAutoHide(s.db, s.clock, s.moderation, updateNodeRequest.Topic, updateNodeRequest.Id)
 	if err != nil {
 		return openapi.Response(400, nil), err
 	}
@@ -48,7 +83,13 @@ func (s *NodeAPIServiceImpl) UpdateNodeTitle(ctx context.Context, updateNodeRequ
 		return openapi.Response(401, nil), errors.New("unauthorized: user not found in context")
 	}
 
-	userDetails, err := getUser(s.db, user.ID)
+	// hidden topics look the same as missing ones
+	visible, err := topicVisible(s.db, updateNodeRequest.Topic, user.ID)
+	if err != nil || !visible {
+		return openapi.Response(404, nil), errors.New("topic not found")
+	}
+
+	userDetails, err := getUserForTopic(s.db, user.ID, updateNodeRequest.Topic)
 	if err != nil {
 		return openapi.Response(401, nil), err
 	}
@@ -59,14 +100,19 @@ func (s *NodeAPIServiceImpl) UpdateNodeTitle(ctx context.Context, updateNodeRequ
 		return openapi.Response(404, nil), err
 	}
 
-	// Check if user is the creator and within 15 minutes of creation
-	isCreator := node.CreatedBy.Id == userDetails.Id
-	creationTime := updateNodeRequest.Id
-	withinEditWindow := time.Since(creationTime) <= 15*time.Minute
+	// the creator can edit for a while after making the node
+	err = s.policy.check(s.db, s.clock, KeyActionEditTitle, userDetails, PolicyTarget{
+		TopicId:   updateNodeRequest.Topic,
+		CreatorId: node.CreatedBy.Id,
+		CreatedAt: node.Id,
+	})
+	if err != nil {
+		return openapi.Response(401, nil), err
+	}
 
-	// Allow edit if user is creator and within edit window, or has sufficient reputation
-	if !(isCreator && withinEditWindow) && userDetails.Role != KeyAdmin && userDetails.Reputation < KeyReputationEditor {
-		return openapi.Response(401, nil), errors.New("unauthorized: user is not an admin or has low reputation(Editor)")
+	err = checkLock(s.db, s.clock, userDetails, updateNodeRequest.Topic, false, node.Id)
+	if err != nil {
+		return openapi.Response(423, nil), err
 	}
 
 	editorAdded, err := updateNodeTitle(s.db, updateNodeRequest, userDetails)
@@ -84,17 +130,65 @@ func (s *NodeAPIServiceImpl) UpdateNodeVideoEdit(ctx context.Context, updateNode
 		return openapi.Response(401, nil), errors.New("unauthorized: user not found in context")
 	}
 
-	userDetails, err := getUser(s.db, user.ID)
+	// hidden topics look the same as missing ones
+	visible, err := topicVisible(s.db, updateNodeRequest.Topic, user.ID)
+	if err != nil || !visible {
+		return openapi.Response(404, nil), errors.New("topic not found")
+	}
+
+	userDetails, err := getUserForTopic(s.db, user.ID, updateNodeRequest.Topic)
 	if err != nil {
 		return openapi.Response(401, nil), err
 	}
 
-	err = updateNodeVideoEdit(s.db, s.clock, updateNodeRequest, userDetails)
+	request, err := resourceRequest(updateNodeRequest)
 	if err != nil {
 		return openapi.Response(400, nil), err
 	}
 
-	return openapi.Response(200, nil), nil
+	// anyone can add a video, the one who added it can take it back for a while and then editors can
+	action := KeyActionAddVideo
+	target := PolicyTarget{TopicId: updateNodeRequest.Topic}
+	if request.Resources[0].Votes <= 0 {
+		action = KeyActionRemoveVideo
+
+		node, err := getNode(s.db, updateNodeRequest.Id.Format(time.RFC3339Nano), updateNodeRequest.Topic)
+		if err != nil {
+			return openapi.Response(404, nil), err
+		}
+
+		for _, resource := range node.Resources {
+			if areSameResource(resource.Link, request.Resources[0].Link) {
+				target.CreatorId = resource.AddedBy.Id
+				target.CreatedAt = resource.DateAdded
+			}
+		}
+	}
+
+	err = s.policy.check(s.db, s.clock, action, userDetails, target)
+	if err != nil {
+		return openapi.Response(401, nil), err
+	}
+
+	err = checkLock(s.db, s.clock, userDetails, updateNodeRequest.Topic, false, updateNodeRequest.Id)
+	if err != nil {
+		return openapi.Response(423, nil), err
+	}
+
+	// metadata is fetched before the node is written, a resource without it is still added
+	if request.Resources[0].Votes > 0 {
+		_, err = s.metadata.Lookup(ctx, request.Resources[0].Link)
+		if err != nil {
+			lgr.Printf("INFO no metadata for %s: %v", request.Resources[0].Link, err)
+		}
+	}
+
+	duplicates, err := updateNodeVideoEdit(s.db, s.clock, updateNodeRequest, userDetails)
+	if err != nil {
+		return openapi.Response(400, nil), err
+	}
+
+	return openapi.Response(200, duplicates), nil
 }
 
 // UpdateNode - Update an node
@@ -104,7 +198,33 @@ func (s *NodeAPIServiceImpl) UpdateNodeVideoVote(ctx context.Context, updateNode
 		return openapi.Response(401, nil), errors.New("unauthorized: user not found in context")
 	}
 
-	vote, err := updateNodeVideoVote(s.db, updateNodeRequest, user.ID)
+	// hidden topics look the same as missing ones
+	visible, err := topicVisible(s.db, updateNodeRequest.Topic, user.ID)
+	if err != nil || !visible {
+		return openapi.Response(404, nil), errors.New("topic not found")
+	}
+
+	userDetails, err := getUserForTopic(s.db, user.ID, updateNodeRequest.Topic)
+	if err != nil {
+		return openapi.Response(401, nil), err
+	}
+
+	err = s.policy.check(s.db, s.clock, KeyActionVote, userDetails, PolicyTarget{TopicId: updateNodeRequest.Topic})
+	if err != nil {
+		return openapi.Response(401, nil), err
+	}
+
+	err = checkLock(s.db, s.clock, userDetails, updateNodeRequest.Topic, true, updateNodeRequest.Id)
+	if err != nil {
+		return openapi.Response(423, nil), err
+	}
+
+	vote, err := updateNodeVideoVote(s.db, s.clock, updateNodeRequest, user.ID)
+	if err != nil {
+		return openapi.Response(400, nil), err
+	}
+
+	err = applyAutoHide(s.db, s.clock, s.moderation, updateNodeRequest.Topic, updateNodeRequest.Id)
 	if err != nil {
 		return openapi.Response(400, nil), err
 	}
@@ -114,12 +234,49 @@ func (s *NodeAPIServiceImpl) UpdateNodeVideoVote(ctx context.Context, updateNode
 
 // UpdateNode - Update an node
 func (s *NodeAPIServiceImpl) UpdateNodeFlag(ctx context.Context, updateNodeRequest openapi.NodeData) (openapi.ImplResponse, error) {
-	_, ok := ctx.Value(userInfoKey).(token.User)
+	user, ok := ctx.Value(userInfoKey).(token.User)
 	if !ok {
 		return openapi.Response(401, nil), errors.New("unauthorized: user not found in context")
 	}
 
-	err := updateNodeFlag(s.db, updateNodeRequest)
+	// hidden topics look the same as missing ones
+	visible, err := topicVisible(s.db, updateNodeRequest.Topic, user.ID)
+	if err != nil || !visible {
+		return openapi.Response(404, nil), errors.New("topic not found")
+	}
+
+	node, err := getNode(s.db, updateNodeRequest.Id.Format(time.RFC3339Nano), updateNodeRequest.Topic)
+	if err != nil {
+		return openapi.Response(404, nil), err
+	}
+
+	userDetails, err := getUserForTopic(s.db, user.ID, updateNodeRequest.Topic)
+	if err != nil {
+		return openapi.Response(401, nil), err
+	}
+
+	// flagging is open to more people than clearing a flag
+	action := KeyActionFlag
+	if node.IsFlagged {
+		action = KeyActionUnflag
+	}
+
+	err = s.policy.check(s.db, s.clock, action, userDetails, PolicyTarget{TopicId: updateNodeRequest.Topic})
+	if err != nil {
+		return openapi.Response(403, nil), err
+	}
+
+	// a flag without a reason goes into the moderation queue like any other report
+	if node.IsFlagged {
+		err = clearNodeFlag(s.db, s.clock, updateNodeRequest.Topic, node.Id, user.ID)
+	} else {
+		_, err = reportFlag(s.db, s.clock, s.moderation, openapi.FlagReport{
+			Topic:      updateNodeRequest.Topic,
+			NodeId:     node.Id,
+			Reason:     KeyReasonOther,
+			ReporterId: user.ID,
+		})
+	}
 	if err != nil {
 		return openapi.Response(400, nil), err
 	}
@@ -134,7 +291,28 @@ func (s *NodeAPIServiceImpl) UpdateNodeFreshVote(ctx context.Context, updateNode
 		return openapi.Response(401, nil), errors.New("unauthorized: user not found in context")
 	}
 
-	vote, err := updateNodeFreshVote(s.db, updateNodeRequest, user.ID)
+	// hidden topics look the same as missing ones
+	visible, err := topicVisible(s.db, updateNodeRequest.Topic, user.ID)
+	if err != nil || !visible {
+		return openapi.Response(404, nil), errors.New("topic not found")
+	}
+
+	userDetails, err := getUserForTopic(s.db, user.ID, updateNodeRequest.Topic)
+	if err != nil {
+		return openapi.Response(401, nil), err
+	}
+
+	err = s.policy.check(s.db, s.clock, KeyActionVote, userDetails, PolicyTarget{TopicId: updateNodeRequest.Topic})
+	if err != nil {
+		return openapi.Response(401, nil), err
+	}
+
+	err = checkLock(s.db, s.clock, userDetails, updateNodeRequest.Topic, true, updateNodeRequest.Id)
+	if err != nil {
+		return openapi.Response(423, nil), err
+	}
+
+	vote, err := updateNodeFreshVote(s.db, s.clock, updateNodeRequest, user.ID)
 	if err != nil {
 		return openapi.Response(400, nil), err
 	}
@@ -143,19 +321,65 @@ func (s *NodeAPIServiceImpl) UpdateNodeFreshVote(ctx context.Context, updateNode
 }
 
 // GetNode - get wiki node
-func (s *NodeAPIServiceImpl) GetNode(ctx context.Context, nodeId string, tid string) (openapi.ImplResponse, error) {
+func (s *NodeAPIServiceImpl) GetNode(ctx context.Context, nodeId string, tid string, ranking string) (openapi.ImplResponse, error) {
+	// nobody is logged in when there is no user
+	user, _ := ctx.Value(userInfoKey).(token.User)
+
+	// hidden topics look the same as missing ones
+	visible, err := topicVisible(s.db, tid, user.ID)
+	if err != nil || !visible {
+		return openapi.Response(404, nil), errors.New("topic not found")
+	}
+
 	node, err := getNode(s.db, nodeId, tid)
 	if err != nil {
 		return openapi.Response(404, nil), err
 	}
 
+	// hidden content is only shown to moderators and its author
+	moderator := s.policy.checkUser(s.db, s.clock, KeyActionModerate, user.ID, PolicyTarget{TopicId: tid}) == nil
+
+	node, visible = hideNodeContent(node, user.ID, moderator)
+	if !visible {
+		return openapi.Response(404, nil), errors.New("node not found")
+	}
+
+	// only the videos the viewer sees suggest other nodes
+	node.RelatedNodes, err = getRelatedNodes(s.db, tid, node)
+	if err != nil {
+		return openapi.Response(404, nil), err
+	}
+
+	node.Resources, err = rankResources(node.Resources, ranking, s.clock.Now())
+	if err != nil {
+		return openapi.Response(400, nil), err
+	}
+	node.YoutubeLinks = youTubeResources(node.Resources)
+
+	if user.ID != "" {
+		watches, err := getWatchesByLink(s.db, user.ID)
+		if err != nil {
+			return openapi.Response(400, nil), err
+		}
+		node = applyWatches(node, watches, s.watch.CompleteAt)
+	}
+
 	return openapi.Response(200, node), nil
 
 }
 
 // GetNodeNextBattleTested - get next top battle tested ID
 func (s *NodeAPIServiceImpl) GetNodeNextBattleTested(ctx context.Context, nodeId string, tid string) (openapi.ImplResponse, error) {
-	nodeId, err := getNextNode(s.db, nodeId, tid, "battleTested")
+	// nobody is logged in when there is no user
+	user, _ := ctx.Value(userInfoKey).(token.User)
+
+	// hidden topics look the same as missing ones
+	visible, err := topicVisible(s.db, tid, user.ID)
+	if err != nil || !visible {
+		return openapi.Response(404, nil), errors.New("topic not found")
+	}
+
+	nodeId, err = getNextNode(s.db, nodeId, tid, "battleTested")
 	if err != nil {
 		return openapi.Response(404, nil), err
 	}
@@ -165,7 +389,16 @@ func (s *NodeAPIServiceImpl) GetNodeNextBattleTested(ctx context.Context, nodeId
 
 // GetNodeNextFresh - get next top fresh ID
 func (s *NodeAPIServiceImpl) GetNodeNextFresh(ctx context.Context, nodeId string, tid string) (openapi.ImplResponse, error) {
-	nodeId, err := getNextNode(s.db, nodeId, tid, "fresh")
+	// nobody is logged in when there is no user
+	user, _ := ctx.Value(userInfoKey).(token.User)
+
+	// hidden topics look the same as missing ones
+	visible, err := topicVisible(s.db, tid, user.ID)
+	if err != nil || !visible {
+		return openapi.Response(404, nil), errors.New("topic not found")
+	}
+
+	nodeId, err = getNextNode(s.db, nodeId, tid, "fresh")
 	if err != nil {
 		return openapi.Response(404, nil), err
 	}
@@ -179,13 +412,25 @@ func (s *NodeAPIServiceImpl) AddNode(ctx context.Context, nodeData openapi.NodeD
 	if !ok {
 		return openapi.Response(401, nil), errors.New("unauthorized: user not found in context")
 	}
-	userDetails, err := getUser(s.db, user.ID)
+
+	// hidden topics look the same as missing ones
+	visible, err := topicVisible(s.db, nodeData.Topic, user.ID)
+	if err != nil || !visible {
+		return openapi.Response(404, nil), errors.New("topic not found")
+	}
+	userDetails, err := getUserForTopic(s.db, user.ID, nodeData.Topic)
 	if err != nil {
 		return openapi.Response(401, nil), err
 	}
 
-	if userDetails.Role != KeyAdmin && userDetails.Reputation < KeyReputationContributor {
-		return openapi.Response(401, nil), errors.New("unauthorized: user is not an admin or has low reputation(Contributor)")
+	err = s.policy.check(s.db, s.clock, KeyActionAddNode, userDetails, PolicyTarget{TopicId: nodeData.Topic})
+	if err != nil {
+		return openapi.Response(401, nil), err
+	}
+
+	err = checkLock(s.db, s.clock, userDetails, nodeData.Topic, false)
+	if err != nil {
+		return openapi.Response(423, nil), err
 	}
 
 	nodeData.CreatedBy = openapi.UserIdentifier{
@@ -209,7 +454,13 @@ func (s *NodeAPIServiceImpl) DeleteNode(ctx context.Context, nodeId string, tid
 		return openapi.Response(401, nil), errors.New("unauthorized: user not found in context")
 	}
 
-	userDetails, err := getUser(s.db, user.ID)
+	// hidden topics look the same as missing ones
+	visible, err := topicVisible(s.db, tid, user.ID)
+	if err != nil || !visible {
+		return openapi.Response(404, nil), errors.New("topic not found")
+	}
+
+	userDetails, err := getUserForTopic(s.db, user.ID, tid)
 	if err != nil {
 		return openapi.Response(401, nil), err
 	}
@@ -220,14 +471,19 @@ func (s *NodeAPIServiceImpl) DeleteNode(ctx context.Context, nodeId string, tid
 		return openapi.Response(404, nil), err
 	}
 
-	// Check if user is the creator and within 15 minutes of creation
-	isCreator := node.CreatedBy.Id == userDetails.Id
-	creationTime := node.Id
-	withinEditWindow := time.Since(creationTime) <= 15*time.Minute
+	// the creator can delete for a while after making the node
+	err = s.policy.check(s.db, s.clock, KeyActionDeleteNode, userDetails, PolicyTarget{
+		TopicId:   tid,
+		CreatorId: node.CreatedBy.Id,
+		CreatedAt: node.Id,
+	})
+	if err != nil {
+		return openapi.Response(401, nil), err
+	}
 
-	// Allow edit if user is creator and within edit window, or has sufficient reputation
-	if !(isCreator && withinEditWindow) && userDetails.Role != KeyAdmin && userDetails.Reputation < KeyReputationDeleter {
-		return openapi.Response(401, nil), errors.New("unauthorized: user is not an admin or has low reputation(Deleter)")
+	err = checkLock(s.db, s.clock, userDetails, tid, false, node.Id)
+	if err != nil {
+		return openapi.Response(423, nil), err
 	}
 
 	err = deleteNode(s.db, nodeId, tid)
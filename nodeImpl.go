@@ -11,11 +11,6 @@ import (
 	bolt "go.etcd.io/bbolt"
 )
 
-// areSameYouTubeVideo returns true if two YouTube URLs point to the same video.
-func areSameYouTubeVideo(link1, link2 string) bool {
-	return link1 == link2
-}
-
 func getNode(db *bolt.DB, nodeId, topicId string) (response openapi.NodeData, err error) {
 	err = db.View(func(tx *bolt.Tx) error {
 		response, err = getNodeRx(tx, nodeId, topicId)
@@ -64,6 +59,11 @@ func getNextNodeTx(tx *bolt.Tx, nodeId, topicId, search string) (Id string, err
 			return Id, err
 		}
 
+		// hidden nodes are never suggested as the next one
+		if node.IsHidden {
+			continue
+		}
+
 		if search == "battleTested" {
 			if node.BattleTested > highestScore {
 				highestScore = node.BattleTested
@@ -101,6 +101,7 @@ func getNodeRx(tx *bolt.Tx, nodeId, topicId string) (response openapi.NodeData,
 	id := newTime
 
 	response.Id = id
+	response.YoutubeLinks = youTubeResources(response.Resources)
 
 	return
 }
@@ -170,6 +171,16 @@ func postNodeTx(tx *bolt.Tx, clock Clock, node openapi.NodeData) (response opena
 		return
 	}
 
+	err = refreshAchievementsTx(tx, clock, node.CreatedBy.Id, true)
+	if err != nil {
+		return
+	}
+
+	err = addContributionTx(tx, clock.Now(), node.Topic, node.CreatedBy.Id, openapi.LeaderboardEntry{Nodes: 1}, true)
+	if err != nil {
+		return
+	}
+
 	response.SourceId = node.Id
 	response.TargetId = id
 
@@ -193,10 +204,10 @@ func userNodeCreatedTx(tx *bolt.Tx, userId string, node openapi.NodeData) error
 		return err
 	}
 
-	// Check for duplicates before appending
+	// Check for duplicates before appending, node ids are only unique within a topic
 	isDuplicate := false
 	for _, created := range user.Created {
-		if created.NodeId == node.Id {
+		if created.NodeId == node.Id && created.Topic == node.Topic {
 			isDuplicate = true
 			break
 		}
@@ -302,22 +313,16 @@ func removeNodeFromAllUsersTx(tx *bolt.Tx, nodeId string, topicId string) error
 
 		// Check video interactions
 		// We need to check if any of the node's videos are in the user's video votes
-		for _, video := range node.YoutubeLinks {
-			for _, userVideo := range user.VideoUp {
-				if areSameYouTubeVideo(userVideo, video.Link) {
-					userIds[string(k)] = true
-				}
-			}
-
-			for _, userVideo := range user.VideoDown {
-				if areSameYouTubeVideo(userVideo, video.Link) {
+		for _, video := range node.Resources {
+			for _, vote := range user.VideoVotes {
+				if vote.NodeId.Format(time.RFC3339Nano) == nodeIdStr && areSameResource(vote.Link, video.Link) {
 					userIds[string(k)] = true
 				}
 			}
 
 			// Check linked videos
 			for _, linked := range user.Linked {
-				if areSameYouTubeVideo(linked.Link, video.Link) {
+				if areSameResource(linked.Link, video.Link) {
 					userIds[string(k)] = true
 				}
 			}
@@ -326,7 +331,7 @@ func removeNodeFromAllUsersTx(tx *bolt.Tx, nodeId string, topicId string) error
 
 	// Process each user
 	for userId := range userIds {
-		err = removeNodeFromUserTx(tx, userId, nodeId, topicId, node.Topic, node.YoutubeLinks)
+		err = removeNodeFromUserTx(tx, userId, nodeId, topicId, node.Topic, node.Resources)
 		if err != nil {
 			return err
 		}
@@ -388,27 +393,18 @@ func removeNodeFromUserTx(tx *bolt.Tx, userId string, nodeId string, topicId str
 		}
 	}
 
-	// Remove video interactions
-	for _, video := range videos {
-		// Remove from video up votes
-		for i, link := range user.VideoUp {
-			if areSameYouTubeVideo(link, video.Link) {
-				user.VideoUp = append(user.VideoUp[:i], user.VideoUp[i+1:]...)
-				break
-			}
-		}
-
-		// Remove from video down votes
-		for i, link := range user.VideoDown {
-			if areSameYouTubeVideo(link, video.Link) {
-				user.VideoDown = append(user.VideoDown[:i], user.VideoDown[i+1:]...)
-				break
-			}
+	// Remove the video votes on this node
+	for _, vote := range user.VideoVotes {
+		if vote.Topic == topicId && vote.NodeId.Format(time.RFC3339Nano) == nodeId {
+			setVideoVote(&user, vote.Topic, vote.NodeId, vote.Link, 0)
 		}
+	}
 
+	// Remove video interactions
+	for _, video := range videos {
 		// Remove from linked videos
 		for i, linked := range user.Linked {
-			if areSameYouTubeVideo(linked.Link, video.Link) {
+			if areSameResource(linked.Link, video.Link) {
 				user.Linked = append(user.Linked[:i], user.Linked[i+1:]...)
 				break
 			}
@@ -467,6 +463,11 @@ func deleteNodeTx(tx *bolt.Tx, nodeId, topicId string) (err error) {
 		return err
 	}
 
+	err = unindexNodeTx(tx, topicId, nodeId)
+	if err != nil {
+		return err
+	}
+
 	edgesBucket := topicBucket.Bucket([]byte(KeyEdges))
 	c := edgesBucket.Cursor()
 	for k, _ := c.First(); k != nil; k, _ = c.Next() {
@@ -641,16 +642,16 @@ func nodeDataFinderTx(tx *bolt.Tx, TopicId, NodeId string) (nodesBucket *bolt.Bu
 	return
 }
 
-func updateNodeBattleVote(db *bolt.DB, request openapi.NodeData, userId string) (vote int32, err error) {
+func updateNodeBattleVote(db *bolt.DB, clock Clock, request openapi.NodeData, userId string) (vote int32, err error) {
 	err = db.Update(func(tx *bolt.Tx) error {
-		vote, err = updateNodeBattleVoteTx(tx, request, userId)
+		vote, err = updateNodeBattleVoteTx(tx, clock, request, userId)
 		return err
 	})
 
 	return
 }
 
-func updateNodeBattleVoteTx(tx *bolt.Tx, request openapi.NodeData, userId string) (vote int32, err error) {
+func updateNodeBattleVoteTx(tx *bolt.Tx, clock Clock, request openapi.NodeData, userId string) (vote int32, err error) {
 	nodesBucket, nodeData, err := nodeDataFinderTx(tx, request.Topic, request.Id.Format(time.RFC3339Nano))
 	if err != nil {
 		return
@@ -674,7 +675,14 @@ func updateNodeBattleVoteTx(tx *bolt.Tx, request openapi.NodeData, userId string
 		if vote != 0 && node.CreatedBy.Id != "" {
 			// Only update reputation if the voter is not the creator
 			if node.CreatedBy.Id != userId {
-				err = updateCreatorReputation(tx, node.CreatedBy.Id, vote)
+				err = voteReputationTx(tx, clock, openapi.VoteRecord{
+					VoterId:   userId,
+					CreatorId: node.CreatedBy.Id,
+					Topic:     request.Topic,
+					NodeId:    request.Id,
+					Kind:      KeyVoteBattle,
+					Value:     vote,
+				})
 				if err != nil {
 					return vote, err
 				}
@@ -831,16 +839,21 @@ func removeNodeAll(list []openapi.ResponseUserInfoInner, id time.Time) []openapi
 // want to add a video to a node
 //
 // if votes are greater than zero then trying to add a video
-func updateNodeVideoEdit(db *bolt.DB, clock Clock, request openapi.NodeData, user openapi.User) (err error) {
+func updateNodeVideoEdit(db *bolt.DB, clock Clock, request openapi.NodeData, user openapi.User) (duplicates []openapi.NodeReference, err error) {
 	err = db.Update(func(tx *bolt.Tx) error {
-		err = updateNodeVideoEditTx(tx, clock, request, user)
+		duplicates, err = updateNodeVideoEditTx(tx, clock, request, user)
 		return err
 	})
 
 	return
 }
 
-func updateNodeVideoEditTx(tx *bolt.Tx, clock Clock, request openapi.NodeData, user openapi.User) (err error) {
+// updateNodeVideoEditTx adds or removes a resource, the other nodes of the topic that already have an added video are returned as a warning
+func updateNodeVideoEditTx(tx *bolt.Tx, clock Clock, request openapi.NodeData, user openapi.User) (duplicates []openapi.NodeReference, err error) {
+	request, err = resourceRequest(request)
+	if err != nil {
+		return
+	}
 
 	nodesBucket, nodeData, err := nodeDataFinderTx(tx, request.Topic, request.Id.Format(time.RFC3339Nano))
 	if err != nil {
@@ -854,43 +867,58 @@ func updateNodeVideoEditTx(tx *bolt.Tx, clock Clock, request openapi.NodeData, u
 		return
 	}
 
-	for i, item := range node.YoutubeLinks {
+	for i, item := range node.Resources {
 
-		if areSameYouTubeVideo(item.Link, request.YoutubeLinks[0].Link) { // Check if ID matches
+		if areSameResource(item.Link, request.Resources[0].Link) { // Check if ID matches
 
-			if request.YoutubeLinks[0].Votes > 0 {
-				return fmt.Errorf("this video is already added")
+			if request.Resources[0].Votes > 0 {
+				return nil, fmt.Errorf("this video is already added")
 			} else {
-				node.YoutubeLinks = append(node.YoutubeLinks[:i], node.YoutubeLinks[i+1:]...) //subtract video because votes are less than zero
+				node.Resources = append(node.Resources[:i], node.Resources[i+1:]...) //subtract video because votes are less than zero
 			}
 
 			marshal, err := json.Marshal(node)
 			if err != nil {
-				return err
+				return nil, err
 			}
 
 			err = nodesBucket.Put([]byte(request.Id.Format(time.RFC3339Nano)), marshal)
 			if err != nil {
-				return err
+				return nil, err
 			}
 
-			err = userVideoEditTx(tx, clock, item.AddedBy.Id, request) //remove the video from the user that added it linked field
+			err = unindexVideoTx(tx, request.Topic, node, item)
 			if err != nil {
-				return err
+				return nil, err
 			}
-			err = removeVideoFromUsersVotersTx(tx, request.YoutubeLinks[0].Link) //remove the video from every user that voted on it
+
+			err = userVideoEditTx(tx, clock, item.AddedBy.Id, request) //remove the video from the user that added it linked field
 			if err != nil {
-				return err
+				return nil, err
 			}
+			err = removeVideoFromUsersVotersTx(tx, request.Topic, request.Id, request.Resources[0].Link) //remove the video from every user that voted on it here
 
-			return err
+			return nil, err
 		}
 	}
 
-	if request.YoutubeLinks[0].Votes > 0 { //video was not found and you want to add
-		node.YoutubeLinks = append(node.YoutubeLinks, openapi.LinkData{
-			Link:  request.YoutubeLinks[0].Link,
-			Votes: 0,
+	if request.Resources[0].Votes > 0 { //video was not found and you want to add
+		request.Resources[0] = withMetadataRx(tx, request.Resources[0])
+
+		node.Resources = append(node.Resources, openapi.LinkData{
+			Link:      request.Resources[0].Link,
+			VideoId:   request.Resources[0].VideoId,
+			EmbedUrl:  request.Resources[0].EmbedUrl,
+			Type:      request.Resources[0].Type,
+			Title:     request.Resources[0].Title,
+			Channel:   request.Resources[0].Channel,
+			Duration:  request.Resources[0].Duration,
+			Provider:  request.Resources[0].Provider,
+			Thumbnail: request.Resources[0].Thumbnail,
+			Start:     request.Resources[0].Start,
+			End:       request.Resources[0].End,
+			Playlist:  request.Resources[0].Playlist,
+			Votes:     0,
 			AddedBy: openapi.UserIdentifier{
 				Id:       user.Id,
 				Username: user.Username,
@@ -898,7 +926,7 @@ func updateNodeVideoEditTx(tx *bolt.Tx, clock Clock, request openapi.NodeData, u
 			DateAdded: clock.Now(),
 		})
 	} else {
-		return fmt.Errorf("could not find that video to delete")
+		return nil, fmt.Errorf("could not find that video to delete")
 	}
 
 	marshal, err := json.Marshal(node)
@@ -911,12 +939,43 @@ func updateNodeVideoEditTx(tx *bolt.Tx, clock Clock, request openapi.NodeData, u
 		return
 	}
 
+	// the video is still added when other nodes have it, the adder is only warned
+	duplicates = duplicateNodesRx(tx, request.Topic, request.Id.Format(time.RFC3339Nano), request.Resources[0])
+
+	err = indexVideoTx(tx, request.Topic, request.Id.Format(time.RFC3339Nano), request.Resources[0])
+	if err != nil {
+		return
+	}
+
 	err = userVideoEditTx(tx, clock, user.Id, request) //add the video to the user that added it linked field
 
 	return
 }
 
-func removeVideoFromUsersVotersTx(tx *bolt.Tx, videoLink string) (err error) {
+// resourceRequest puts the resource of an add, vote or remove in its canonical form so every way of linking it matches,
+// clients from before resources send it as a YouTube link. The caller's links are left alone
+func resourceRequest(request openapi.NodeData) (openapi.NodeData, error) {
+	resources := request.Resources
+	if len(resources) == 0 {
+		resources = request.YoutubeLinks
+	}
+
+	if len(resources) == 0 {
+		return request, fmt.Errorf("a resource is needed")
+	}
+
+	resource, err := resourceData(resources[0])
+	if err != nil {
+		return request, err
+	}
+
+	request.Resources = []openapi.LinkData{resource}
+	request.YoutubeLinks = nil
+
+	return request, nil
+}
+
+func removeVideoFromUsersVotersTx(tx *bolt.Tx, topicId string, nodeId time.Time, videoLink string) (err error) {
 	usersBucket := tx.Bucket([]byte(KeyUsers))
 	if usersBucket == nil {
 		return fmt.Errorf("users bucket not found")
@@ -931,21 +990,11 @@ func removeVideoFromUsersVotersTx(tx *bolt.Tx, videoLink string) (err error) {
 			return err
 		}
 
-		// Remove from video up votes
-		for i, link := range user.VideoUp {
-			if areSameYouTubeVideo(link, videoLink) {
-				user.VideoUp = append(user.VideoUp[:i], user.VideoUp[i+1:]...)
-				break
-			}
-		}
-
-		// Remove from video down votes
-		for i, link := range user.VideoDown {
-			if areSameYouTubeVideo(link, videoLink) {
-				user.VideoDown = append(user.VideoDown[:i], user.VideoDown[i+1:]...)
-				break
-			}
+		// Remove the vote on the video of the node, votes on it in other nodes stay
+		if videoVote(user, topicId, nodeId, videoLink) == 0 {
+			continue
 		}
+		setVideoVote(&user, topicId, nodeId, videoLink, 0)
 
 		// Marshal back to JSON
 		marshaled, err := json.Marshal(user)
@@ -969,9 +1018,9 @@ func userVideoEditTx(tx *bolt.Tx, clock Clock, userId string, request openapi.No
 	}
 
 	for i, item := range user.Linked {
-		if areSameYouTubeVideo(item.Link, request.YoutubeLinks[0].Link) {
+		if areSameResource(item.Link, request.Resources[0].Link) {
 
-			if request.YoutubeLinks[0].Votes > 0 {
+			if request.Resources[0].Votes > 0 {
 				//already added
 				return nil
 			} else {
@@ -989,10 +1038,21 @@ func userVideoEditTx(tx *bolt.Tx, clock Clock, userId string, request openapi.No
 		}
 	}
 
-	if request.YoutubeLinks[0].Votes > 0 {
+	if request.Resources[0].Votes > 0 {
 		user.Linked = append(user.Linked, openapi.LinkData{
-			Link:  request.YoutubeLinks[0].Link,
-			Votes: 0,
+			Link:      request.Resources[0].Link,
+			VideoId:   request.Resources[0].VideoId,
+			EmbedUrl:  request.Resources[0].EmbedUrl,
+			Type:      request.Resources[0].Type,
+			Title:     request.Resources[0].Title,
+			Channel:   request.Resources[0].Channel,
+			Duration:  request.Resources[0].Duration,
+			Provider:  request.Resources[0].Provider,
+			Thumbnail: request.Resources[0].Thumbnail,
+			Start:     request.Resources[0].Start,
+			End:       request.Resources[0].End,
+			Playlist:  request.Resources[0].Playlist,
+			Votes:     0,
 			AddedBy: openapi.UserIdentifier{
 				Id:       user.Id,
 				Username: user.Username,
@@ -1006,8 +1066,11 @@ func userVideoEditTx(tx *bolt.Tx, clock Clock, userId string, request openapi.No
 		}
 
 		err = usersBucket.Put([]byte(userId), marshal)
+		if err != nil {
+			return err
+		}
 
-		return err
+		return refreshAchievementsTx(tx, clock, userId, true)
 
 	} else {
 		return fmt.Errorf("could not find the video to remove on the user")
@@ -1017,16 +1080,21 @@ func userVideoEditTx(tx *bolt.Tx, clock Clock, userId string, request openapi.No
 // vote on a video
 //
 // if votes are greater than zero then trying to add a vote
-func updateNodeVideoVote(db *bolt.DB, request openapi.NodeData, userId string) (vote int32, err error) {
+func updateNodeVideoVote(db *bolt.DB, clock Clock, request openapi.NodeData, userId string) (vote int32, err error) {
 	err = db.Update(func(tx *bolt.Tx) error {
-		vote, err = updateNodeVideoVoteTx(tx, request, userId)
+		vote, err = updateNodeVideoVoteTx(tx, clock, request, userId)
 		return err
 	})
 
 	return
 }
 
-func updateNodeVideoVoteTx(tx *bolt.Tx, request openapi.NodeData, userId string) (vote int32, err error) {
+func updateNodeVideoVoteTx(tx *bolt.Tx, clock Clock, request openapi.NodeData, userId string) (vote int32, err error) {
+	request, err = resourceRequest(request)
+	if err != nil {
+		return
+	}
+
 	nodesBucket, nodeData, err := nodeDataFinderTx(tx, request.Topic, request.Id.Format(time.RFC3339Nano))
 	if err != nil {
 		return
@@ -1040,8 +1108,8 @@ func updateNodeVideoVoteTx(tx *bolt.Tx, request openapi.NodeData, userId string)
 
 	// Find the video link
 	var videoIndex = -1
-	for i, video := range node.YoutubeLinks {
-		if areSameYouTubeVideo(video.Link, request.YoutubeLinks[0].Link) {
+	for i, video := range node.Resources {
+		if areSameResource(video.Link, request.Resources[0].Link) {
 			videoIndex = i
 			break
 		}
@@ -1062,172 +1130,40 @@ func updateNodeVideoVoteTx(tx *bolt.Tx, request openapi.NodeData, userId string)
 		nodeTitle = "Untitled"
 	}
 
-	// Calculate reputation changes
-	var reputationChange int32 = 0
-
-	// Handle upvote
-	if request.YoutubeLinks[0].Votes > 0 {
-		// Check if user already upvoted
-		for i, item := range user.VideoUp {
-			if areSameYouTubeVideo(item, request.YoutubeLinks[0].Link) {
-				// Remove upvote
-				user.VideoUp = append(user.VideoUp[:i], user.VideoUp[i+1:]...)
-				node.YoutubeLinks[videoIndex].Votes--
-
-				// Update reputation of video creator (if not the voter)
-				if node.YoutubeLinks[videoIndex].AddedBy.Id != "" && node.YoutubeLinks[videoIndex].AddedBy.Id != userId {
-					reputationChange = -1
-				}
-
-				marshal, err := json.Marshal(user)
-				if err != nil {
-					return vote, err
-				}
-				err = usersBucket.Put([]byte(userId), marshal)
-				if err != nil {
-					return vote, err
-				}
-				vote = node.YoutubeLinks[videoIndex].Votes
-
-				// Update creator reputation
-				if reputationChange != 0 {
-					updateCreatorReputation(tx, node.YoutubeLinks[videoIndex].AddedBy.Id, reputationChange)
-				}
-
-				marshal, err = json.Marshal(node)
-				if err != nil {
-					return vote, err
-				}
-				return vote, nodesBucket.Put([]byte(request.Id.Format(time.RFC3339Nano)), marshal)
-			}
-		}
-
-		// Check if user already downvoted
-		for i, item := range user.VideoDown {
-			if areSameYouTubeVideo(item, request.YoutubeLinks[0].Link) {
-				// Remove downvote and add upvote (switch vote)
-				user.VideoDown = append(user.VideoDown[:i], user.VideoDown[i+1:]...)
-				node.YoutubeLinks[videoIndex].Votes += 2 // +1 for removing downvote, +1 for adding upvote
-
-				// Update reputation
-				if node.YoutubeLinks[videoIndex].AddedBy.Id != "" && node.YoutubeLinks[videoIndex].AddedBy.Id != userId {
-					reputationChange = 2 // +1 for removing downvote, +1 for adding upvote
-				}
-
-				user.VideoUp = append(user.VideoUp, request.YoutubeLinks[0].Link)
-
-				marshal, err := json.Marshal(user)
-				if err != nil {
-					return vote, err
-				}
-				err = usersBucket.Put([]byte(userId), marshal)
-				if err != nil {
-					return vote, err
-				}
-				vote = node.YoutubeLinks[videoIndex].Votes
-
-				// Update creator reputation
-				if reputationChange != 0 {
-					updateCreatorReputation(tx, node.YoutubeLinks[videoIndex].AddedBy.Id, reputationChange)
-				}
-
-				marshal, err = json.Marshal(node)
-				if err != nil {
-					return vote, err
-				}
-				return vote, nodesBucket.Put([]byte(request.Id.Format(time.RFC3339Nano)), marshal)
-			}
-		}
-
-		// New upvote
-		user.VideoUp = append(user.VideoUp, request.YoutubeLinks[0].Link)
-		node.YoutubeLinks[videoIndex].Votes++
-
-		// Update reputation
-		if node.YoutubeLinks[videoIndex].AddedBy.Id != "" && node.YoutubeLinks[videoIndex].AddedBy.Id != userId {
-			reputationChange = 1
-		}
-	} else if request.YoutubeLinks[0].Votes < 0 {
-		// Handle downvote
-		for i, item := range user.VideoDown {
-			if areSameYouTubeVideo(item, request.YoutubeLinks[0].Link) {
-				// Remove downvote
-				user.VideoDown = append(user.VideoDown[:i], user.VideoDown[i+1:]...)
-				node.YoutubeLinks[videoIndex].Votes++
-
-				// Update reputation
-				if node.YoutubeLinks[videoIndex].AddedBy.Id != "" && node.YoutubeLinks[videoIndex].AddedBy.Id != userId {
-					reputationChange = 1
-				}
-
-				marshal, err := json.Marshal(user)
-				if err != nil {
-					return vote, err
-				}
-				err = usersBucket.Put([]byte(userId), marshal)
-				if err != nil {
-					return vote, err
-				}
-				vote = node.YoutubeLinks[videoIndex].Votes
-
-				// Update creator reputation
-				if reputationChange != 0 {
-					updateCreatorReputation(tx, node.YoutubeLinks[videoIndex].AddedBy.Id, reputationChange)
-				}
-
-				marshal, err = json.Marshal(node)
-				if err != nil {
-					return vote, err
-				}
-				return vote, nodesBucket.Put([]byte(request.Id.Format(time.RFC3339Nano)), marshal)
-			}
-		}
-
-		// Check if user already upvoted
-		for i, item := range user.VideoUp {
-			if areSameYouTubeVideo(item, request.YoutubeLinks[0].Link) {
-				// Remove upvote and add downvote (switch vote)
-				user.VideoUp = append(user.VideoUp[:i], user.VideoUp[i+1:]...)
-				node.YoutubeLinks[videoIndex].Votes -= 2 // -1 for removing upvote, -1 for adding downvote
-
-				// Update reputation
-				if node.YoutubeLinks[videoIndex].AddedBy.Id != "" && node.YoutubeLinks[videoIndex].AddedBy.Id != userId {
-					reputationChange = -2 // -1 for removing upvote, -1 for adding downvote
-				}
-
-				user.VideoDown = append(user.VideoDown, request.YoutubeLinks[0].Link)
-
-				marshal, err := json.Marshal(user)
-				if err != nil {
-					return vote, err
-				}
-				err = usersBucket.Put([]byte(userId), marshal)
-				if err != nil {
-					return vote, err
-				}
-				vote = node.YoutubeLinks[videoIndex].Votes
-
-				// Update creator reputation
-				if reputationChange != 0 {
-					updateCreatorReputation(tx, node.YoutubeLinks[videoIndex].AddedBy.Id, reputationChange)
-				}
+	// a vote the user already made on the video of this node is taken back, a vote the other way is switched
+	previous := videoVote(user, request.Topic, request.Id, node.Resources[videoIndex].Link)
+	value := previous
+	switch {
+	case request.Resources[0].Votes > 0 && previous != 1:
+		value = 1
+	case request.Resources[0].Votes < 0 && previous != -1:
+		value = -1
+	case request.Resources[0].Votes != 0:
+		value = 0
+	}
+	setVideoVote(&user, request.Topic, request.Id, node.Resources[videoIndex].Link, value)
 
-				marshal, err = json.Marshal(node)
-				if err != nil {
-					return vote, err
-				}
-				return vote, nodesBucket.Put([]byte(request.Id.Format(time.RFC3339Nano)), marshal)
-			}
-		}
+	resource := &node.Resources[videoIndex]
+	resource.Votes += value - previous
+	if previous > 0 {
+		resource.Up--
+	} else if previous < 0 {
+		resource.Down--
+	}
+	if value > 0 {
+		resource.Up++
+	} else if value < 0 {
+		resource.Down++
+	}
 
-		// New downvote
-		user.VideoDown = append(user.VideoDown, request.YoutubeLinks[0].Link)
-		node.YoutubeLinks[videoIndex].Votes--
+	// counts from before votes were kept by node can be short
+	resource.Up = max(resource.Up, 0)
+	resource.Down = max(resource.Down, 0)
 
-		// Update reputation
-		if node.YoutubeLinks[videoIndex].AddedBy.Id != "" && node.YoutubeLinks[videoIndex].AddedBy.Id != userId {
-			reputationChange = -1
-		}
+	// Calculate reputation changes
+	var reputationChange int32 = 0
+	if resource.AddedBy.Id != "" && resource.AddedBy.Id != userId {
+		reputationChange = value - previous
 	}
 
 	marshal, err := json.Marshal(user)
@@ -1241,7 +1177,18 @@ func updateNodeVideoVoteTx(tx *bolt.Tx, request openapi.NodeData, userId string)
 
 	// Update creator reputation
 	if reputationChange != 0 {
-		updateCreatorReputation(tx, node.YoutubeLinks[videoIndex].AddedBy.Id, reputationChange)
+		err = voteReputationTx(tx, clock, openapi.VoteRecord{
+			VoterId:   userId,
+			CreatorId: node.Resources[videoIndex].AddedBy.Id,
+			Topic:     request.Topic,
+			NodeId:    request.Id,
+			Link:      node.Resources[videoIndex].Link,
+			Kind:      KeyVoteVideo,
+			Value:     reputationChange,
+		})
+		if err != nil {
+			return vote, err
+		}
 	}
 
 	marshal, err = json.Marshal(node)
@@ -1249,20 +1196,79 @@ func updateNodeVideoVoteTx(tx *bolt.Tx, request openapi.NodeData, userId string)
 		return
 	}
 	err = nodesBucket.Put([]byte(request.Id.Format(time.RFC3339Nano)), marshal)
-	vote = node.YoutubeLinks[videoIndex].Votes
+	vote = node.Resources[videoIndex].Votes
 
 	return
 }
 
+// videoVote is the vote of the user on the video of the node, 0 when they didn't vote on it there
+func videoVote(user openapi.User, topicId string, nodeId time.Time, link string) int32 {
+	for _, vote := range user.VideoVotes {
+		if vote.Topic == topicId && vote.NodeId.Equal(nodeId) && areSameResource(vote.Link, link) {
+			return vote.Value
+		}
+	}
+
+	return 0
+}
+
+// setVideoVote keeps the vote of the user on the video of the node, 0 takes it back.
+// VideoUp and VideoDown list the link while the user has a vote that way on any node
+func setVideoVote(user *openapi.User, topicId string, nodeId time.Time, link string, value int32) {
+	votes := make([]openapi.VideoVote, 0, len(user.VideoVotes)+1)
+	for _, vote := range user.VideoVotes {
+		if vote.Topic != topicId || !vote.NodeId.Equal(nodeId) || !areSameResource(vote.Link, link) {
+			votes = append(votes, vote)
+		}
+	}
+	if value != 0 {
+		votes = append(votes, openapi.VideoVote{Topic: topicId, NodeId: nodeId, Link: link, Value: value})
+	}
+	user.VideoVotes = votes
+
+	listVideoVote(user, link)
+}
+
+// listVideoVote puts the link in VideoUp and VideoDown or takes it out of them from the votes of the user on it
+func listVideoVote(user *openapi.User, link string) {
+	var up, down bool
+	for _, vote := range user.VideoVotes {
+		if areSameResource(vote.Link, link) {
+			up = up || vote.Value > 0
+			down = down || vote.Value < 0
+		}
+	}
+
+	user.VideoUp = listLink(user.VideoUp, link, up)
+	user.VideoDown = listLink(user.VideoDown, link, down)
+}
+
+func listLink(list []string, link string, listed bool) []string {
+	for i, item := range list {
+		if areSameResource(item, link) {
+			if !listed {
+				return append(list[:i], list[i+1:]...)
+			}
+			return list
+		}
+	}
+
+	if listed {
+		list = append(list, link)
+	}
+
+	return list
+}
+
 func userVideoVoteTx(tx *bolt.Tx, userId string, request openapi.NodeData) (vote int32, err error) {
 	usersBucket, user, err := getUserAndBucketRx(tx, userId)
 	if err != nil {
 		return
 	}
 
-	if request.YoutubeLinks[0].Votes > 0 {
+	if request.Resources[0].Votes > 0 {
 		for i, item := range user.VideoUp {
-			if areSameYouTubeVideo(item, request.YoutubeLinks[0].Link) {
+			if areSameResource(item, request.Resources[0].Link) {
 				user.VideoUp = append(user.VideoUp[:i], user.VideoUp[i+1:]...)
 				vote--
 				marshal, err := json.Marshal(user)
@@ -1276,12 +1282,12 @@ func userVideoVoteTx(tx *bolt.Tx, userId string, request openapi.NodeData) (vote
 			}
 		}
 
-		user.VideoUp = append(user.VideoUp, request.YoutubeLinks[0].Link)
+		user.VideoUp = append(user.VideoUp, request.Resources[0].Link)
 
 		vote++
 
 		for i, item := range user.VideoDown {
-			if areSameYouTubeVideo(item, request.YoutubeLinks[0].Link) {
+			if areSameResource(item, request.Resources[0].Link) {
 				user.VideoDown = append(user.VideoDown[:i], user.VideoDown[i+1:]...)
 				vote++
 				break
@@ -1290,7 +1296,7 @@ func userVideoVoteTx(tx *bolt.Tx, userId string, request openapi.NodeData) (vote
 
 	} else {
 		for i, item := range user.VideoDown {
-			if areSameYouTubeVideo(item, request.YoutubeLinks[0].Link) {
+			if areSameResource(item, request.Resources[0].Link) {
 				user.VideoDown = append(user.VideoDown[:i], user.VideoDown[i+1:]...)
 				vote++
 				marshal, err := json.Marshal(user)
@@ -1304,12 +1310,12 @@ func userVideoVoteTx(tx *bolt.Tx, userId string, request openapi.NodeData) (vote
 			}
 		}
 
-		user.VideoDown = append(user.VideoDown, request.YoutubeLinks[0].Link)
+		user.VideoDown = append(user.VideoDown, request.Resources[0].Link)
 
 		vote--
 
 		for i, item := range user.VideoUp {
-			if areSameYouTubeVideo(item, request.YoutubeLinks[0].Link) {
+			if areSameResource(item, request.Resources[0].Link) {
 				user.VideoUp = append(user.VideoUp[:i], user.VideoUp[i+1:]...)
 				vote--
 				break
@@ -1365,9 +1371,9 @@ func updateNodeFlagTx(tx *bolt.Tx, request openapi.NodeData) (err error) {
 	return
 }
 
-func updateNodeFreshVote(db *bolt.DB, request openapi.NodeData, userId string) (vote int32, err error) {
+func updateNodeFreshVote(db *bolt.DB, clock Clock, request openapi.NodeData, userId string) (vote int32, err error) {
 	err = db.Update(func(tx *bolt.Tx) error {
-		vote, err = updateNodeFreshVoteTx(tx, request, userId)
+		vote, err = updateNodeFreshVoteTx(tx, clock, request, userId)
 		return err
 	})
 
@@ -1375,7 +1381,7 @@ func updateNodeFreshVote(db *bolt.DB, request openapi.NodeData, userId string) (
 }
 
 // updates the title and description
-func updateNodeFreshVoteTx(tx *bolt.Tx, request openapi.NodeData, userId string) (vote int32, err error) {
+func updateNodeFreshVoteTx(tx *bolt.Tx, clock Clock, request openapi.NodeData, userId string) (vote int32, err error) {
 	nodesBucket, nodeData, err := nodeDataFinderTx(tx, request.Topic, request.Id.Format(time.RFC3339Nano))
 	if err != nil {
 		return
@@ -1399,7 +1405,17 @@ func updateNodeFreshVoteTx(tx *bolt.Tx, request openapi.NodeData, userId string)
 		if vote != 0 && node.CreatedBy.Id != "" {
 			// Only update reputation if the voter is not the creator
 			if node.CreatedBy.Id != userId {
-				updateCreatorReputation(tx, node.CreatedBy.Id, vote)
+				err = voteReputationTx(tx, clock, openapi.VoteRecord{
+					VoterId:   userId,
+					CreatorId: node.CreatedBy.Id,
+					Topic:     request.Topic,
+					NodeId:    request.Id,
+					Kind:      KeyVoteFresh,
+					Value:     vote,
+				})
+				if err != nil {
+					return vote, err
+				}
 			}
 		}
 	}
@@ -1593,8 +1609,38 @@ func updateUserNodeTitleTx(tx *bolt.Tx, nodeId time.Time, topic string, newTitle
 	return nil
 }
 
-// updateCreatorReputation updates the reputation of a node creator based on votes
-func updateCreatorReputation(tx *bolt.Tx, creatorId string, voteValue int32) error {
+// updateCreatorReputation updates the reputation of a node creator based on votes and records why it changed
+//
+// votes in a topic that belongs to an organization only count towards the reputation in that organization
+func updateCreatorReputation(tx *bolt.Tx, clock Clock, event openapi.ReputationEvent) error {
+	info, err := getTopicInfoRx(tx, event.Topic)
+	if err != nil {
+		return err
+	}
+
+	event.OrganizationId = info.OrganizationId
+
+	return changeReputationTx(tx, clock, event)
+}
+
+// changeReputationTx changes the reputation in the organization of the event, or the global one when it has none
+func changeReputationTx(tx *bolt.Tx, clock Clock, event openapi.ReputationEvent) (err error) {
+	creatorId := event.UserId
+
+	if event.OrganizationId != "" {
+		err = updateOrganizationReputationTx(tx, event.OrganizationId, creatorId, event.Delta)
+		if err != nil {
+			return err
+		}
+
+		_, err = addReputationEventTx(tx, clock, event)
+		if err != nil {
+			return err
+		}
+
+		return refreshAchievementsTx(tx, clock, creatorId, false)
+	}
+
 	usersBucket, creator, err := getUserAndBucketRx(tx, creatorId)
 	if err != nil {
 		return err
@@ -1603,7 +1649,7 @@ func updateCreatorReputation(tx *bolt.Tx, creatorId string, voteValue int32) err
 	// Use the actual vote value for reputation change
 	// This allows for -2 when switching from upvote to downvote
 	// and +2 when switching from downvote to upvote
-	creator.Reputation += voteValue
+	creator.Reputation += event.Delta
 
 	// Save updated user
 	marshal, err := json.Marshal(creator)
@@ -1625,5 +1671,10 @@ func updateCreatorReputation(tx *bolt.Tx, creatorId string, voteValue int32) err
 		log.Printf("Failed to re-unmarshal user after save: %v", err)
 	}
 
-	return nil
+	_, err = addReputationEventTx(tx, clock, event)
+	if err != nil {
+		return err
+	}
+
+	return refreshAchievementsTx(tx, clock, creatorId, false)
 }
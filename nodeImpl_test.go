@@ -132,7 +132,7 @@ func TestUpdateBattleVoteUpImpl(t *testing.T) {
 		BattleTested: 1,
 	}
 
-	_, err = updateNodeBattleVote(db, battleUp, users[0]) // should cause +1
+	_, err = updateNodeBattleVote(db, &clock, battleUp, users[0]) // should cause +1
 	require.Nil(t, err)
 
 	upNode, err := getNode(db, nodesAndEdges[0].SourceId.Format(time.RFC3339Nano), topics[0])
@@ -145,7 +145,7 @@ func TestUpdateBattleVoteUpImpl(t *testing.T) {
 
 	require.Equal(t, len(upUser.BattleTestedUp), 1)
 
-	_, err = updateNodeBattleVote(db, battleUp, users[0]) // should cause -1
+	_, err = updateNodeBattleVote(db, &clock, battleUp, users[0]) // should cause -1
 	require.Nil(t, err)
 
 	upNode, err = getNode(db, nodesAndEdges[0].SourceId.Format(time.RFC3339Nano), topics[0])
@@ -175,7 +175,7 @@ func TestUpdateBattleVoteDownImpl(t *testing.T) {
 		BattleTested: -1,
 	}
 
-	_, err = updateNodeBattleVote(db, battleDown, users[0])
+	_, err = updateNodeBattleVote(db, &clock, battleDown, users[0])
 	require.Nil(t, err)
 
 	downNode, err := getNode(db, nodesAndEdges[0].SourceId.Format(time.RFC3339Nano), topics[0])
@@ -188,7 +188,7 @@ func TestUpdateBattleVoteDownImpl(t *testing.T) {
 
 	require.Equal(t, len(DownUser.BattleTestedDown), 1)
 
-	_, err = updateNodeBattleVote(db, battleDown, users[0])
+	_, err = updateNodeBattleVote(db, &clock, battleDown, users[0])
 	require.Nil(t, err)
 
 	downNode, err = getNode(db, nodesAndEdges[0].SourceId.Format(time.RFC3339Nano), topics[0])
@@ -218,7 +218,7 @@ func TestUpdateBattleVoteUpDownImpl(t *testing.T) {
 		BattleTested: 1,
 	}
 
-	_, err = updateNodeBattleVote(db, battleUp, users[0])
+	_, err = updateNodeBattleVote(db, &clock, battleUp, users[0])
 	require.Nil(t, err)
 
 	upNode, err := getNode(db, nodesAndEdges[0].SourceId.Format(time.RFC3339Nano), topics[0])
@@ -237,7 +237,7 @@ func TestUpdateBattleVoteUpDownImpl(t *testing.T) {
 		BattleTested: -1,
 	}
 
-	_, err = updateNodeBattleVote(db, battleDown, users[0])
+	_, err = updateNodeBattleVote(db, &clock, battleDown, users[0])
 	require.Nil(t, err)
 
 	upNode, err = getNode(db, nodesAndEdges[0].SourceId.Format(time.RFC3339Nano), topics[0])
@@ -268,7 +268,7 @@ func TestUpdateBattleVoteDownUpImpl(t *testing.T) {
 		BattleTested: -1,
 	}
 
-	_, err = updateNodeBattleVote(db, battleUp, users[0])
+	_, err = updateNodeBattleVote(db, &clock, battleUp, users[0])
 	require.Nil(t, err)
 
 	upNode, err := getNode(db, nodesAndEdges[0].SourceId.Format(time.RFC3339Nano), topics[0])
@@ -287,7 +287,7 @@ func TestUpdateBattleVoteDownUpImpl(t *testing.T) {
 		BattleTested: 1,
 	}
 
-	_, err = updateNodeBattleVote(db, battleDown, users[0])
+	_, err = updateNodeBattleVote(db, &clock, battleDown, users[0])
 	require.Nil(t, err)
 
 	upNode, err = getNode(db, nodesAndEdges[0].SourceId.Format(time.RFC3339Nano), topics[0])
@@ -318,7 +318,7 @@ func TestUpdateFreshVoteUpImpl(t *testing.T) {
 		Fresh: 1,
 	}
 
-	_, err = updateNodeFreshVote(db, freshUp, users[0]) // should cause +1
+	_, err = updateNodeFreshVote(db, &clock, freshUp, users[0]) // should cause +1
 	require.Nil(t, err)
 
 	upNode, err := getNode(db, nodesAndEdges[0].SourceId.Format(time.RFC3339Nano), topics[0])
@@ -331,7 +331,7 @@ func TestUpdateFreshVoteUpImpl(t *testing.T) {
 
 	require.Equal(t, len(upUser.FreshUp), 1)
 
-	_, err = updateNodeFreshVote(db, freshUp, users[0]) // should cause -1
+	_, err = updateNodeFreshVote(db, &clock, freshUp, users[0]) // should cause -1
 	require.Nil(t, err)
 
 	upNode, err = getNode(db, nodesAndEdges[0].SourceId.Format(time.RFC3339Nano), topics[0])
@@ -361,7 +361,7 @@ func TestUpdateFreshVoteDownImpl(t *testing.T) {
 		Fresh: -1,
 	}
 
-	_, err = updateNodeFreshVote(db, freshDown, users[0])
+	_, err = updateNodeFreshVote(db, &clock, freshDown, users[0])
 	require.Nil(t, err)
 
 	downNode, err := getNode(db, nodesAndEdges[0].SourceId.Format(time.RFC3339Nano), topics[0])
@@ -374,7 +374,7 @@ func TestUpdateFreshVoteDownImpl(t *testing.T) {
 
 	require.Equal(t, len(DownUser.FreshDown), 1)
 
-	_, err = updateNodeFreshVote(db, freshDown, users[0])
+	_, err = updateNodeFreshVote(db, &clock, freshDown, users[0])
 	require.Nil(t, err)
 
 	downNode, err = getNode(db, nodesAndEdges[0].SourceId.Format(time.RFC3339Nano), topics[0])
@@ -404,7 +404,7 @@ func TestUpdateFreshVoteUpDownImpl(t *testing.T) {
 		Fresh: 1,
 	}
 
-	_, err = updateNodeFreshVote(db, freshUp, users[0])
+	_, err = updateNodeFreshVote(db, &clock, freshUp, users[0])
 	require.Nil(t, err)
 
 	upNode, err := getNode(db, nodesAndEdges[0].SourceId.Format(time.RFC3339Nano), topics[0])
@@ -423,7 +423,7 @@ func TestUpdateFreshVoteUpDownImpl(t *testing.T) {
 		Fresh: -1,
 	}
 
-	_, err = updateNodeFreshVote(db, freshDown, users[0])
+	_, err = updateNodeFreshVote(db, &clock, freshDown, users[0])
 	require.Nil(t, err)
 
 	upNode, err = getNode(db, nodesAndEdges[0].SourceId.Format(time.RFC3339Nano), topics[0])
@@ -454,7 +454,7 @@ func TestUpdateFreshVoteDownUpImpl(t *testing.T) {
 		Fresh: -1,
 	}
 
-	_, err = updateNodeFreshVote(db, freshUp, users[0])
+	_, err = updateNodeFreshVote(db, &clock, freshUp, users[0])
 	require.Nil(t, err)
 
 	upNode, err := getNode(db, nodesAndEdges[0].SourceId.Format(time.RFC3339Nano), topics[0])
@@ -473,7 +473,7 @@ func TestUpdateFreshVoteDownUpImpl(t *testing.T) {
 		Fresh: 1,
 	}
 
-	_, err = updateNodeFreshVote(db, freshDown, users[0])
+	_, err = updateNodeFreshVote(db, &clock, freshDown, users[0])
 	require.Nil(t, err)
 
 	upNode, err = getNode(db, nodesAndEdges[0].SourceId.Format(time.RFC3339Nano), topics[0])
@@ -510,10 +510,10 @@ func TestUpdateNodeVideoVoteUpImpl(t *testing.T) {
 	user, err := getUser(db, users[0])
 	require.Nil(t, err)
 
-	err = updateNodeVideoEdit(db, &clock, vidUp, user)
+	_, err = updateNodeVideoEdit(db, &clock, vidUp, user)
 	require.Nil(t, err)
 
-	_, err = updateNodeVideoVote(db, vidUp, users[0])
+	_, err = updateNodeVideoVote(db, &clock, vidUp, users[0])
 	require.Nil(t, err)
 
 	upNode, err := getNode(db, nodesAndEdges[0].SourceId.Format(time.RFC3339Nano), topics[0])
@@ -526,7 +526,7 @@ func TestUpdateNodeVideoVoteUpImpl(t *testing.T) {
 
 	require.Equal(t, len(upUser.VideoUp), 1)
 
-	_, err = updateNodeVideoVote(db, vidUp, users[0]) // should cause -1
+	_, err = updateNodeVideoVote(db, &clock, vidUp, users[0]) // should cause -1
 	require.Nil(t, err)
 
 	upNode, err = getNode(db, nodesAndEdges[0].SourceId.Format(time.RFC3339Nano), topics[0])
@@ -562,7 +562,7 @@ func TestUpdateNodeVideoVoteDownImpl(t *testing.T) {
 	user, err := getUser(db, users[0])
 	require.Nil(t, err)
 
-	err = updateNodeVideoEdit(db, &clock, vidUp, user)
+	_, err = updateNodeVideoEdit(db, &clock, vidUp, user)
 	require.Nil(t, err)
 
 	vidDown := openapi.NodeData{
@@ -574,7 +574,7 @@ func TestUpdateNodeVideoVoteDownImpl(t *testing.T) {
 		}},
 	}
 
-	_, err = updateNodeVideoVote(db, vidDown, users[0])
+	_, err = updateNodeVideoVote(db, &clock, vidDown, users[0])
 	require.Nil(t, err)
 
 	upNode, err := getNode(db, nodesAndEdges[0].SourceId.Format(time.RFC3339Nano), topics[0])
@@ -587,7 +587,7 @@ func TestUpdateNodeVideoVoteDownImpl(t *testing.T) {
 
 	require.Equal(t, len(upUser.VideoDown), 1)
 
-	_, err = updateNodeVideoVote(db, vidDown, users[0]) // should cause -1
+	_, err = updateNodeVideoVote(db, &clock, vidDown, users[0]) // should cause -1
 	require.Nil(t, err)
 
 	upNode, err = getNode(db, nodesAndEdges[0].SourceId.Format(time.RFC3339Nano), topics[0])
@@ -623,7 +623,7 @@ func TestUpdateNodeVideoVoteUpDownImpl(t *testing.T) {
 	user, err := getUser(db, users[0])
 	require.Nil(t, err)
 
-	err = updateNodeVideoEdit(db, &clock, vidUp, user)
+	_, err = updateNodeVideoEdit(db, &clock, vidUp, user)
 	require.Nil(t, err)
 
 	vidDown := openapi.NodeData{
@@ -635,7 +635,7 @@ func TestUpdateNodeVideoVoteUpDownImpl(t *testing.T) {
 		}},
 	}
 
-	_, err = updateNodeVideoVote(db, vidUp, users[0])
+	_, err = updateNodeVideoVote(db, &clock, vidUp, users[0])
 	require.Nil(t, err)
 
 	upNode, err := getNode(db, nodesAndEdges[0].SourceId.Format(time.RFC3339Nano), topics[0])
@@ -648,7 +648,7 @@ func TestUpdateNodeVideoVoteUpDownImpl(t *testing.T) {
 
 	require.Equal(t, len(upUser.VideoUp), 1)
 
-	_, err = updateNodeVideoVote(db, vidDown, users[0]) // should cause -1
+	_, err = updateNodeVideoVote(db, &clock, vidDown, users[0]) // should cause -1
 	require.Nil(t, err)
 
 	upNode, err = getNode(db, nodesAndEdges[0].SourceId.Format(time.RFC3339Nano), topics[0])
@@ -685,7 +685,7 @@ func TestUpdateNodeVideoVoteDownUpImpl(t *testing.T) {
 	user, err := getUser(db, users[0])
 	require.Nil(t, err)
 
-	err = updateNodeVideoEdit(db, &clock, vidUp, user)
+	_, err = updateNodeVideoEdit(db, &clock, vidUp, user)
 	require.Nil(t, err)
 
 	vidDown := openapi.NodeData{
@@ -697,7 +697,7 @@ func TestUpdateNodeVideoVoteDownUpImpl(t *testing.T) {
 		}},
 	}
 
-	_, err = updateNodeVideoVote(db, vidDown, users[0])
+	_, err = updateNodeVideoVote(db, &clock, vidDown, users[0])
 	require.Nil(t, err)
 
 	upNode, err := getNode(db, nodesAndEdges[0].SourceId.Format(time.RFC3339Nano), topics[0])
@@ -710,7 +710,7 @@ func TestUpdateNodeVideoVoteDownUpImpl(t *testing.T) {
 
 	require.Equal(t, len(upUser.VideoDown), 1)
 
-	_, err = updateNodeVideoVote(db, vidUp, users[0]) // should cause -1
+	_, err = updateNodeVideoVote(db, &clock, vidUp, users[0]) // should cause -1
 	require.Nil(t, err)
 
 	upNode, err = getNode(db, nodesAndEdges[0].SourceId.Format(time.RFC3339Nano), topics[0])
@@ -780,7 +780,7 @@ func TestUpdateNodeVideoEditImpl(t *testing.T) {
 	user, err := getUser(db, users[0])
 	require.Nil(t, err)
 
-	err = updateNodeVideoEdit(db, &clock, vidAdd, user)
+	_, err = updateNodeVideoEdit(db, &clock, vidAdd, user)
 	require.Nil(t, err)
 
 	upNode, err := getNode(db, nodesAndEdges[0].SourceId.Format(time.RFC3339Nano), topics[0])
@@ -791,7 +791,7 @@ func TestUpdateNodeVideoEditImpl(t *testing.T) {
 	upUser, err := getUser(db, users[0])
 	require.Nil(t, err)
 
-	err = updateNodeVideoEdit(db, &clock, vidAdd, upUser)
+	_, err = updateNodeVideoEdit(db, &clock, vidAdd, upUser)
 	require.NotNil(t, err)
 
 	require.Equal(t, upUser.Linked[0].Link, vidAdd.YoutubeLinks[0].Link)
@@ -805,7 +805,7 @@ func TestUpdateNodeVideoEditImpl(t *testing.T) {
 		}},
 	}
 
-	err = updateNodeVideoEdit(db, &clock, vidSub, upUser)
+	_, err = updateNodeVideoEdit(db, &clock, vidSub, upUser)
 	require.Nil(t, err)
 
 	upNode, err = getNode(db, nodesAndEdges[0].SourceId.Format(time.RFC3339Nano), topics[0])
@@ -818,7 +818,7 @@ func TestUpdateNodeVideoEditImpl(t *testing.T) {
 
 	require.Zero(t, len(upUser.VideoDown))
 
-	err = updateNodeVideoEdit(db, &clock, vidSub, upUser)
+	_, err = updateNodeVideoEdit(db, &clock, vidSub, upUser)
 	require.NotNil(t, err)
 
 }
@@ -854,7 +854,7 @@ func TestVideoDeletedFromAnotherUser(t *testing.T) {
 		}},
 	}
 
-	err = updateNodeVideoEdit(db, &clock, addVideo, userB)
+	_, err = updateNodeVideoEdit(db, &clock, addVideo, userB)
 	require.Nil(t, err)
 
 	// Verify video added
@@ -874,7 +874,7 @@ func TestVideoDeletedFromAnotherUser(t *testing.T) {
 		}},
 	}
 
-	_, err = updateNodeVideoVote(db, upvoteVideo, userAId)
+	_, err = updateNodeVideoVote(db, &clock, upvoteVideo, userAId)
 	require.Nil(t, err)
 
 	// User A deletes the video
@@ -890,7 +890,7 @@ func TestVideoDeletedFromAnotherUser(t *testing.T) {
 	userA, err := getUser(db, userAId)
 	require.Nil(t, err)
 
-	err = updateNodeVideoEdit(db, &clock, deleteVideo, userA)
+	_, err = updateNodeVideoEdit(db, &clock, deleteVideo, userA)
 	require.Nil(t, err)
 
 	// Verify video is removed from node
@@ -931,11 +931,11 @@ func TestUpdateNodeVideoVoteReputationImpl(t *testing.T) {
 	initialReputation := user1.Reputation
 
 	// User1 adds a video
-	err = updateNodeVideoEdit(db, &clock, vidUp, user1)
+	_, err = updateNodeVideoEdit(db, &clock, vidUp, user1)
 	require.Nil(t, err)
 
 	// User2 upvotes the video
-	_, err = updateNodeVideoVote(db, vidUp, users[1])
+	_, err = updateNodeVideoVote(db, &clock, vidUp, users[1])
 	require.Nil(t, err)
 
 	// Check that user1's reputation increased
@@ -944,7 +944,7 @@ func TestUpdateNodeVideoVoteReputationImpl(t *testing.T) {
 	require.Equal(t, initialReputation+1, updatedUser1.Reputation, "Reputation should increase by 1 after upvote")
 
 	// User2 removes upvote
-	_, err = updateNodeVideoVote(db, vidUp, users[1])
+	_, err = updateNodeVideoVote(db, &clock, vidUp, users[1])
 	require.Nil(t, err)
 
 	// Check that user1's reputation decreased back
@@ -961,7 +961,7 @@ func TestUpdateNodeVideoVoteReputationImpl(t *testing.T) {
 			Votes: -1,
 		}},
 	}
-	_, err = updateNodeVideoVote(db, vidDown, users[1])
+	_, err = updateNodeVideoVote(db, &clock, vidDown, users[1])
 	require.Nil(t, err)
 
 	// Check that user1's reputation decreased
@@ -970,7 +970,7 @@ func TestUpdateNodeVideoVoteReputationImpl(t *testing.T) {
 	require.Equal(t, initialReputation-1, updatedUser1AfterDownvote.Reputation, "Reputation should decrease by 1 after downvote")
 
 	// User2 removes downvote
-	_, err = updateNodeVideoVote(db, vidDown, users[1])
+	_, err = updateNodeVideoVote(db, &clock, vidDown, users[1])
 	require.Nil(t, err)
 
 	// Check that user1's reputation inreased
@@ -979,7 +979,7 @@ func TestUpdateNodeVideoVoteReputationImpl(t *testing.T) {
 	require.Equal(t, initialReputation, updatedUser1AfterDownvote.Reputation, "Reputation should decrease by 1 after downvote")
 
 	// User2 upvotes the video
-	_, err = updateNodeVideoVote(db, vidUp, users[1])
+	_, err = updateNodeVideoVote(db, &clock, vidUp, users[1])
 	require.Nil(t, err)
 
 	// Check that user1's reputation increased
@@ -988,7 +988,7 @@ func TestUpdateNodeVideoVoteReputationImpl(t *testing.T) {
 	require.Equal(t, initialReputation+1, updatedUser1.Reputation, "Reputation should increase by 1 after upvote")
 
 	// User2 downvotes the video
-	_, err = updateNodeVideoVote(db, vidDown, users[1])
+	_, err = updateNodeVideoVote(db, &clock, vidDown, users[1])
 	require.Nil(t, err)
 
 	// Check that user1's reputation is now -1 from initial (after switching from upvote to downvote)
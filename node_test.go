@@ -411,7 +411,7 @@ func TestUpdateNodeVideoVote(t *testing.T) {
 		},
 	}
 
-	err = updateNodeVideoEdit(db, &clock, nodeWithVideo, user)
+	_, err = updateNodeVideoEdit(db, &clock, nodeWithVideo, user)
 	require.Nil(t, err)
 
 	client := &http.Client{}
@@ -760,47 +760,69 @@ func TestUpdateNodeFlag(t *testing.T) {
 
 	// Test that only moderators can unflag
 	// First, flag the node again
-	// ****************currently anyone can unflag***********************
-	// marshal, err = json.Marshal(flagNode)
-	// require.Nil(t, err)
-
-	// req, _ = http.NewRequest(http.MethodPut,
-	// 	"http://127.0.0.1:8088/api/v1/node/flag",
-	// 	bytes.NewBuffer(marshal))
-
-	// resp, err = client.Do(req)
-	// require.Nil(t, err)
-	// defer resp.Body.Close()
-	// require.NotNil(t, resp)
-	// require.Equal(t, 200, resp.StatusCode)
-
-	// // Set up a non-moderator user
-	// UpdateUserRoleAndReputation(db, users[1], false, 0)
-	// SetTestLoginUser(users[1])
-
-	// // Try to unflag as non-moderator
-	// marshal, err = json.Marshal(unflagNode)
-	// require.Nil(t, err)
-
-	// req, _ = http.NewRequest(http.MethodPut,
-	// 	"http://127.0.0.1:8088/api/v1/node/flag",
-	// 	bytes.NewBuffer(marshal))
-
-	// resp, err = client.Do(req)
-	// require.Nil(t, err)
-	// defer resp.Body.Close()
-
-	// // This should either fail with 403 or the node should remain flagged
-	// updatedNode, err = getNode(db, nodesAndEdges[0].SourceId.Format(time.RFC3339Nano), topics[0])
-	// require.Nil(t, err)
-
-	// if resp.StatusCode == 200 {
-	// 	// If the API allows the request but doesn't actually unflag
-	// 	require.True(t, updatedNode.IsFlagged, "Non-moderator should not be able to unflag content")
-	// } else {
-	// 	// If the API rejects the request
-	// 	require.Equal(t, 403, resp.StatusCode, "Non-moderator should get 403 when trying to unflag")
-	// }
+	marshal, err = json.Marshal(flagNode)
+	require.Nil(t, err)
+
+	req, _ = http.NewRequest(http.MethodPut,
+		"http://127.0.0.1:8088/api/v1/node/flag",
+		bytes.NewBuffer(marshal))
+
+	resp, err = client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.NotNil(t, resp)
+	require.Equal(t, 200, resp.StatusCode)
+
+	// Set up a non-moderator user, the creator of the topic owns it so pick the other one
+	info, err := getTopicInfo(db, topics[0])
+	require.Nil(t, err)
+	outsider := users[1]
+	if info.CreatedBy == outsider {
+		outsider = users[0]
+	}
+	UpdateUserRoleAndReputation(db, outsider, false, 0)
+	SetTestLoginUser(outsider)
+
+	// Try to unflag as non-moderator
+	marshal, err = json.Marshal(unflagNode)
+	require.Nil(t, err)
+
+	req, _ = http.NewRequest(http.MethodPut,
+		"http://127.0.0.1:8088/api/v1/node/flag",
+		bytes.NewBuffer(marshal))
+
+	resp, err = client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+
+	// This should either fail with 403 or the node should remain flagged
+	updatedNode, err = getNode(db, nodesAndEdges[0].SourceId.Format(time.RFC3339Nano), topics[0])
+	require.Nil(t, err)
+
+	if resp.StatusCode == 200 {
+		// If the API allows the request but doesn't actually unflag
+		require.True(t, updatedNode.IsFlagged, "Non-moderator should not be able to unflag content")
+	} else {
+		// If the API rejects the request
+		require.Equal(t, 403, resp.StatusCode, "Non-moderator should get 403 when trying to unflag")
+	}
+
+	// A moderator of the topic can unflag
+	_, err = updateTopicRole(db, topics[0], openapi.TopicRole{UserId: outsider, Role: KeyTopicModerator})
+	require.Nil(t, err)
+
+	req, _ = http.NewRequest(http.MethodPut,
+		"http://127.0.0.1:8088/api/v1/node/flag",
+		bytes.NewBuffer(marshal))
+
+	resp, err = client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 200, resp.StatusCode)
+
+	updatedNode, err = getNode(db, nodesAndEdges[0].SourceId.Format(time.RFC3339Nano), topics[0])
+	require.Nil(t, err)
+	require.False(t, updatedNode.IsFlagged)
 }
 
 func TestUpdateNodeVideoEdit(t *testing.T) {
@@ -808,7 +830,7 @@ func TestUpdateNodeVideoEdit(t *testing.T) {
 	db, tearDown := FullStartTestServer("updateNodeVideoEdit", 8088, "")
 	defer tearDown()
 
-	users, topics, nodesAndEdges, err := CreateTestData(db, &clock, 2, 1, 1)
+	users, topics, nodesAndEdges, err := CreateTestData(db, &clock, 3, 1, 1)
 	require.Nil(t, err)
 
 	// Set up the first user as logged in
@@ -927,6 +949,33 @@ func TestUpdateNodeVideoEdit(t *testing.T) {
 	require.Nil(t, err)
 	require.Equal(t, 1, len(updatedNode.YoutubeLinks))
 	require.Equal(t, secondVideoLink, updatedNode.YoutubeLinks[0].Link)
+
+	// a newcomer can't remove a video someone else added, the creator of the topic owns it so pick another one
+	info, err := getTopicInfo(db, topics[0])
+	require.Nil(t, err)
+	newcomer := users[1]
+	if info.CreatedBy == newcomer {
+		newcomer = users[2]
+	}
+	UpdateUserRoleAndReputation(db, newcomer, false, 0)
+	SetTestLoginUser(newcomer)
+
+	removeVideo.YoutubeLinks[0].Link = secondVideoLink
+	marshal, err = json.Marshal(removeVideo)
+	require.Nil(t, err)
+
+	req, _ = http.NewRequest(http.MethodPut,
+		"http://127.0.0.1:8088/api/v1/node/videoEdit",
+		bytes.NewBuffer(marshal))
+
+	resp, err = client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 401, resp.StatusCode)
+
+	updatedNode, err = getNode(db, nodesAndEdges[0].SourceId.Format(time.RFC3339Nano), topics[0])
+	require.Nil(t, err)
+	require.Equal(t, 1, len(updatedNode.YoutubeLinks))
 }
 
 // test GetNodeNextBattleTested
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	openapi "github.com/SpyLime/flowBackend/go"
+	bolt "go.etcd.io/bbolt"
+)
+
+// every user has their own bucket of notifications, like the reputation history
+func addNotificationTx(tx *bolt.Tx, clock Clock, userId string, notification openapi.Notification) (err error) {
+	notificationsBucket, err := tx.CreateBucketIfNotExists([]byte(KeyNotifications))
+	if err != nil {
+		return
+	}
+
+	userBucket, err := notificationsBucket.CreateBucketIfNotExists([]byte(userId))
+	if err != nil {
+		return
+	}
+
+	sequence, err := userBucket.NextSequence()
+	if err != nil {
+		return
+	}
+
+	notification.Id = strconv.FormatUint(sequence, 10)
+	notification.Read = false
+	notification.CreatedAt = clock.Now()
+
+	marshal, err := json.Marshal(notification)
+	if err != nil {
+		return
+	}
+
+	err = userBucket.Put([]byte(fmt.Sprintf("%020d", sequence)), marshal)
+
+	return
+}
+
+// getNotifications returns the notifications of the user, newest first
+func getNotifications(db *bolt.DB, userId string) (response []openapi.Notification, err error) {
+	err = db.View(func(tx *bolt.Tx) error {
+		response, err = getNotificationsRx(tx, userId)
+		return err
+	})
+
+	return
+}
+
+func getNotificationsRx(tx *bolt.Tx, userId string) (response []openapi.Notification, err error) {
+	response = make([]openapi.Notification, 0)
+
+	notificationsBucket := tx.Bucket([]byte(KeyNotifications))
+	if notificationsBucket == nil {
+		return
+	}
+
+	userBucket := notificationsBucket.Bucket([]byte(userId))
+	if userBucket == nil {
+		return
+	}
+
+	c := userBucket.Cursor()
+	for k, v := c.Last(); k != nil; k, v = c.Prev() {
+		var notification openapi.Notification
+		err = json.Unmarshal(v, &notification)
+		if err != nil {
+			return
+		}
+
+		response = append(response, notification)
+	}
+
+	return
+}
+
+// markNotificationsRead marks every notification of the user as read
+func markNotificationsRead(db *bolt.DB, userId string) (err error) {
+	err = db.Update(func(tx *bolt.Tx) error {
+		err = markNotificationsReadTx(tx, userId)
+		return err
+	})
+
+	return
+}
+
+func markNotificationsReadTx(tx *bolt.Tx, userId string) (err error) {
+	notificationsBucket := tx.Bucket([]byte(KeyNotifications))
+	if notificationsBucket == nil {
+		return
+	}
+
+	userBucket := notificationsBucket.Bucket([]byte(userId))
+	if userBucket == nil {
+		return
+	}
+
+	// collect first, bolt doesn't allow changing a bucket while going over it with ForEach
+	unread := make(map[string]openapi.Notification)
+	err = userBucket.ForEach(func(k, v []byte) error {
+		var notification openapi.Notification
+		err := json.Unmarshal(v, &notification)
+		if err != nil {
+			return err
+		}
+
+		if !notification.Read {
+			notification.Read = true
+			unread[string(k)] = notification
+		}
+
+		return nil
+	})
+	if err != nil {
+		return
+	}
+
+	for key, notification := range unread {
+		marshal, err := json.Marshal(notification)
+		if err != nil {
+			return err
+		}
+
+		err = userBucket.Put([]byte(key), marshal)
+		if err != nil {
+			return err
+		}
+	}
+
+	return
+}
+
+// the notifications go with the user
+func deleteNotificationsTx(tx *bolt.Tx, userId string) (err error) {
+	notificationsBucket := tx.Bucket([]byte(KeyNotifications))
+	if notificationsBucket == nil || notificationsBucket.Bucket([]byte(userId)) == nil {
+		return
+	}
+
+	return notificationsBucket.DeleteBucket([]byte(userId))
+}
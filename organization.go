@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"errors"
+
+	openapi "github.com/SpyLime/flowBackend/go"
+	"github.com/go-pkgz/auth/token"
+	bolt "go.etcd.io/bbolt"
+)
+
+// OrganizationAPIServiceImpl is a service that implements the logic for the OrganizationAPIServicer
+// This service should implement the business logic for every endpoint for the OrganizationAPI API.
+// Include any external packages or services that will be required by this service.
+type OrganizationAPIServiceImpl struct {
+	db    *bolt.DB
+	clock Clock
+}
+
+// NewOrganizationAPIService creates a default api service
+func NewOrganizationAPIServiceImpl(db *bolt.DB, clock Clock) openapi.OrganizationAPIServicer {
+	return &OrganizationAPIServiceImpl{
+		db:    db,
+		clock: clock,
+	}
+}
+
+// GetOrganizations - get organizations, global admins get every organization
+func (s *OrganizationAPIServiceImpl) GetOrganizations(ctx context.Context) (openapi.ImplResponse, error) {
+	user, ok := ctx.Value(userInfoKey).(token.User)
+	if !ok {
+		return openapi.Response(401, nil), errors.New("unauthorized: user not found in context")
+	}
+
+	response, err := getOrganizations(s.db, user.ID)
+	if err != nil {
+		return openapi.Response(400, nil), err
+	}
+
+	return openapi.Response(200, response), nil
+}
+
+// UpdateOrganizationMember - invite a user to an organization, accept an invite or change if a member is an admin
+func (s *OrganizationAPIServiceImpl) UpdateOrganizationMember(ctx context.Context, organizationId string, organizationMember openapi.OrganizationMember) (openapi.ImplResponse, error) {
+	user, ok := ctx.Value(userInfoKey).(token.User)
+	if !ok {
+		return openapi.Response(401, nil), errors.New("unauthorized: user not found in context")
+	}
+
+	// users accept an invite by adding themselves
+	if organizationMember.UserId != user.ID {
+		err := s.checkOrganizationAdmin(ctx, organizationId)
+		if err != nil {
+			return openapi.Response(401, nil), err
+		}
+	}
+
+	response, err := updateOrganizationMember(s.db, organizationId, organizationMember, user.ID)
+	if err != nil {
+		return openapi.Response(400, nil), err
+	}
+
+	return openapi.Response(200, response), nil
+}
+
+// DeleteOrganizationMember - remove a member from an organization
+func (s *OrganizationAPIServiceImpl) DeleteOrganizationMember(ctx context.Context, organizationId string, userId string) (openapi.ImplResponse, error) {
+	err := s.checkOrganizationAdmin(ctx, organizationId)
+	if err != nil {
+		return openapi.Response(401, nil), err
+	}
+
+	err = deleteOrganizationMember(s.db, organizationId, userId)
+	if err != nil {
+		return openapi.Response(400, nil), err
+	}
+
+	return openapi.Response(204, nil), nil
+}
+
+// only admins of the organization or global admins can change its members
+func (s *OrganizationAPIServiceImpl) checkOrganizationAdmin(ctx context.Context, organizationId string) error {
+	user, ok := ctx.Value(userInfoKey).(token.User)
+	if !ok {
+		return errors.New("unauthorized: user not found in context")
+	}
+
+	userDetails, err := getUserForOrganization(s.db, user.ID, organizationId)
+	if err != nil {
+		return err
+	}
+
+	if userDetails.Role != KeyAdmin {
+		return errors.New("unauthorized: user is not an admin of the organization")
+	}
+
+	return nil
+}
@@ -0,0 +1,493 @@
+package main
+
+import (
+	"crypto/sha1"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	openapi "github.com/SpyLime/flowBackend/go"
+	"github.com/go-pkgz/auth/provider"
+	"github.com/go-pkgz/auth/token"
+	bolt "go.etcd.io/bbolt"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// schoolCredentials is what is saved in the credentials bucket for users that log in with a password
+type schoolCredentials struct {
+	UserId       string
+	PasswordHash []byte
+}
+
+func postSchool(db *bolt.DB, clock Clock, request NewSchoolRequest) (response NewSchoolResponse, err error) {
+	err = db.Update(func(tx *bolt.Tx) error {
+		response, err = postSchoolTx(tx, clock, request)
+		return err
+	})
+
+	return
+}
+
+// creates the organization and its first admin, the admin logs in with the returned password
+func postSchoolTx(tx *bolt.Tx, clock Clock, request NewSchoolRequest) (response NewSchoolResponse, err error) {
+	email := strings.ToLower(strings.TrimSpace(request.Email))
+	if request.School == "" || email == "" {
+		return response, fmt.Errorf("a school and email are required")
+	}
+
+	organizationsBucket, err := tx.CreateBucketIfNotExists([]byte(KeyOrganizations))
+	if err != nil {
+		return
+	}
+
+	credentialsBucket, err := tx.CreateBucketIfNotExists([]byte(KeyCredentials))
+	if err != nil {
+		return
+	}
+
+	usersBucket, err := tx.CreateBucketIfNotExists([]byte(KeyUsers))
+	if err != nil {
+		return
+	}
+
+	if credentialsBucket.Get([]byte(email)) != nil {
+		return response, fmt.Errorf("an account already uses %s", email)
+	}
+
+	// same id the direct auth provider gives the user when they log in
+	adminId := KeySchoolProvider + "_" + token.HashID(sha1.New(), email)
+	if usersBucket.Get([]byte(adminId)) != nil {
+		return response, fmt.Errorf("an account already uses %s", email)
+	}
+
+	organizationId := RandomString(8)
+	for organizationsBucket.Bucket([]byte(organizationId)) != nil {
+		organizationId = RandomString(8)
+	}
+
+	organizationBucket, err := organizationsBucket.CreateBucket([]byte(organizationId))
+	if err != nil {
+		return
+	}
+
+	_, err = organizationBucket.CreateBucket([]byte(KeyReputation))
+	if err != nil {
+		return
+	}
+
+	organization := openapi.Organization{
+		Id:        organizationId,
+		Name:      request.School,
+		City:      request.City,
+		Zip:       int32(request.Zip),
+		Admins:    []string{adminId},
+		CreatedAt: clock.Now(),
+	}
+
+	err = putOrganizationTx(tx, organization)
+	if err != nil {
+		return
+	}
+
+	admin := openapi.User{
+		Id:             adminId,
+		Username:       strings.TrimSpace(request.FirstName + " " + request.LastName),
+		FirstName:      request.FirstName,
+		LastName:       request.LastName,
+		Email:          email,
+		Location:       request.City,
+		Provider:       KeySchoolProvider,
+		Role:           KeyUser,
+		OrganizationId: organizationId,
+		CreatedAt:      clock.Now(),
+		UpdatedAt:      clock.Now(),
+	}
+
+	marshal, err := json.Marshal(admin)
+	if err != nil {
+		return
+	}
+
+	err = usersBucket.Put([]byte(adminId), marshal)
+	if err != nil {
+		return
+	}
+
+	password := RandomPassword(12)
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return
+	}
+
+	marshal, err = json.Marshal(schoolCredentials{UserId: adminId, PasswordHash: hash})
+	if err != nil {
+		return
+	}
+
+	err = credentialsBucket.Put([]byte(email), marshal)
+	if err != nil {
+		return
+	}
+
+	response = NewSchoolResponse{
+		AdminPassword:  password,
+		AdminId:        adminId,
+		OrganizationId: organizationId,
+	}
+
+	return
+}
+
+// schoolCredChecker checks the email and password of users made with a school
+func schoolCredChecker(db *bolt.DB, clock Clock) provider.CredCheckerFunc {
+	return func(user, password string) (ok bool, err error) {
+		// banned users are turned away like a wrong password
+		userId := KeySchoolProvider + "_" + token.HashID(sha1.New(), strings.ToLower(strings.TrimSpace(user)))
+		if checkSanction(db, clock, userId, true) != nil {
+			return false, nil
+		}
+
+		err = db.View(func(tx *bolt.Tx) error {
+			credentialsBucket := tx.Bucket([]byte(KeyCredentials))
+			if credentialsBucket == nil {
+				return nil
+			}
+
+			credentialsData := credentialsBucket.Get([]byte(strings.ToLower(strings.TrimSpace(user))))
+			if credentialsData == nil {
+				return nil
+			}
+
+			var credentials schoolCredentials
+			err := json.Unmarshal(credentialsData, &credentials)
+			if err != nil {
+				return err
+			}
+
+			ok = bcrypt.CompareHashAndPassword(credentials.PasswordHash, []byte(password)) == nil
+			return nil
+		})
+
+		return
+	}
+}
+
+func getOrganizations(db *bolt.DB, userId string) (response []openapi.Organization, err error) {
+	err = db.View(func(tx *bolt.Tx) error {
+		response, err = getOrganizationsRx(tx, userId)
+		return err
+	})
+
+	return
+}
+
+// global admins get every organization, everyone else gets their own and the ones that invited them
+func getOrganizationsRx(tx *bolt.Tx, userId string) (response []openapi.Organization, err error) {
+	response = []openapi.Organization{}
+
+	user, err := getUserRx(tx, userId)
+	if err != nil {
+		return
+	}
+
+	organizationsBucket := tx.Bucket([]byte(KeyOrganizations))
+	if organizationsBucket == nil {
+		return
+	}
+
+	c := organizationsBucket.Cursor()
+	for k, _ := c.First(); k != nil; k, _ = c.Next() {
+		organization, err := getOrganizationRx(tx, string(k))
+		if err != nil {
+			return response, err
+		}
+
+		if user.Role == KeyAdmin || organization.Id == user.OrganizationId || contains(organization.Invites, userId) {
+			response = append(response, organization)
+		}
+	}
+
+	return
+}
+
+func getOrganizationBucketRx(tx *bolt.Tx, organizationId string) (organizationBucket *bolt.Bucket, err error) {
+	organizationsBucket := tx.Bucket([]byte(KeyOrganizations))
+	if organizationsBucket == nil {
+		return nil, fmt.Errorf("can't find organizations bucket")
+	}
+
+	organizationBucket = organizationsBucket.Bucket([]byte(organizationId))
+	if organizationBucket == nil {
+		return nil, fmt.Errorf("can't find organization %s", organizationId)
+	}
+
+	return
+}
+
+func getOrganizationRx(tx *bolt.Tx, organizationId string) (organization openapi.Organization, err error) {
+	organizationBucket, err := getOrganizationBucketRx(tx, organizationId)
+	if err != nil {
+		return
+	}
+
+	err = json.Unmarshal(organizationBucket.Get([]byte(KeyInfo)), &organization)
+
+	return
+}
+
+func putOrganizationTx(tx *bolt.Tx, organization openapi.Organization) (err error) {
+	organizationBucket, err := getOrganizationBucketRx(tx, organization.Id)
+	if err != nil {
+		return
+	}
+
+	marshal, err := json.Marshal(organization)
+	if err != nil {
+		return
+	}
+
+	return organizationBucket.Put([]byte(KeyInfo), marshal)
+}
+
+func isOrganizationAdminRx(tx *bolt.Tx, organizationId, userId string) bool {
+	if organizationId == "" || userId == "" {
+		return false
+	}
+
+	organization, err := getOrganizationRx(tx, organizationId)
+	if err != nil {
+		return false
+	}
+
+	return contains(organization.Admins, userId)
+}
+
+func isOrganizationAdmin(db *bolt.DB, organizationId, userId string) (isAdmin bool) {
+	_ = db.View(func(tx *bolt.Tx) error {
+		isAdmin = isOrganizationAdminRx(tx, organizationId, userId)
+		return nil
+	})
+
+	return
+}
+
+func updateOrganizationMember(db *bolt.DB, organizationId string, member openapi.OrganizationMember, byId string) (response openapi.Organization, err error) {
+	err = db.Update(func(tx *bolt.Tx) error {
+		response, err = updateOrganizationMemberTx(tx, organizationId, member, byId)
+		return err
+	})
+
+	return
+}
+
+// admins of the organization invite users and change the members they have, an invited user joins by adding themselves.
+// Global admins add users directly, a user can only be in one organization at a time
+func updateOrganizationMemberTx(tx *bolt.Tx, organizationId string, member openapi.OrganizationMember, byId string) (response openapi.Organization, err error) {
+	response, err = getOrganizationRx(tx, organizationId)
+	if err != nil {
+		return
+	}
+
+	by, err := getUserRx(tx, byId)
+	if err != nil {
+		return
+	}
+
+	usersBucket, user, err := getUserAndBucketRx(tx, member.UserId)
+	if err != nil {
+		return
+	}
+
+	if user.OrganizationId != "" && user.OrganizationId != organizationId {
+		return response, fmt.Errorf("user %s already belongs to another organization", member.UserId)
+	}
+
+	switch {
+	case by.Role == KeyAdmin:
+	case isOrganizationAdminRx(tx, organizationId, byId):
+		if user.OrganizationId != organizationId {
+			if !contains(response.Invites, member.UserId) {
+				response.Invites = append(response.Invites, member.UserId)
+			}
+			err = putOrganizationTx(tx, response)
+			return
+		}
+	case byId != member.UserId || user.OrganizationId == organizationId:
+		return response, fmt.Errorf("only admins of %s can change its members", organizationId)
+	case !contains(response.Invites, member.UserId):
+		return response, fmt.Errorf("user %s wasn't invited to %s", member.UserId, organizationId)
+	default:
+		// joining doesn't make anyone an admin
+		member.IsAdmin = false
+	}
+
+	user.OrganizationId = organizationId
+	response.Invites = removeString(response.Invites, member.UserId)
+
+	marshal, err := json.Marshal(user)
+	if err != nil {
+		return
+	}
+
+	err = usersBucket.Put([]byte(user.Id), marshal)
+	if err != nil {
+		return
+	}
+
+	admins := removeString(response.Admins, member.UserId)
+	if member.IsAdmin {
+		admins = append(admins, member.UserId)
+	}
+	response.Admins = admins
+
+	err = putOrganizationTx(tx, response)
+
+	return
+}
+
+func deleteOrganizationMember(db *bolt.DB, organizationId, userId string) (err error) {
+	err = db.Update(func(tx *bolt.Tx) error {
+		err = deleteOrganizationMemberTx(tx, organizationId, userId)
+		return err
+	})
+
+	return
+}
+
+// removes the user from the organization, the reputation they earned there is kept in case they come back
+func deleteOrganizationMemberTx(tx *bolt.Tx, organizationId, userId string) (err error) {
+	organization, err := getOrganizationRx(tx, organizationId)
+	if err != nil {
+		return
+	}
+
+	usersBucket, user, err := getUserAndBucketRx(tx, userId)
+	if err != nil {
+		return
+	}
+
+	if user.OrganizationId != organizationId {
+		return fmt.Errorf("user %s is not a member of %s", userId, organizationId)
+	}
+
+	user.OrganizationId = ""
+
+	marshal, err := json.Marshal(user)
+	if err != nil {
+		return
+	}
+
+	err = usersBucket.Put([]byte(userId), marshal)
+	if err != nil {
+		return
+	}
+
+	organization.Admins = removeString(organization.Admins, userId)
+
+	return putOrganizationTx(tx, organization)
+}
+
+func removeString(slice []string, item string) []string {
+	remaining := make([]string, 0, len(slice))
+	for _, s := range slice {
+		if s != item {
+			remaining = append(remaining, s)
+		}
+	}
+
+	return remaining
+}
+
+func getOrganizationReputationRx(tx *bolt.Tx, organizationId, userId string) (reputation int32) {
+	organizationBucket, err := getOrganizationBucketRx(tx, organizationId)
+	if err != nil {
+		return
+	}
+
+	reputationBucket := organizationBucket.Bucket([]byte(KeyReputation))
+	if reputationBucket == nil {
+		return
+	}
+
+	_ = json.Unmarshal(reputationBucket.Get([]byte(userId)), &reputation)
+
+	return
+}
+
+func updateOrganizationReputationTx(tx *bolt.Tx, organizationId, userId string, change int32) (err error) {
+	organizationBucket, err := getOrganizationBucketRx(tx, organizationId)
+	if err != nil {
+		return
+	}
+
+	reputationBucket, err := organizationBucket.CreateBucketIfNotExists([]byte(KeyReputation))
+	if err != nil {
+		return
+	}
+
+	reputation := getOrganizationReputationRx(tx, organizationId, userId) + change
+
+	marshal, err := json.Marshal(reputation)
+	if err != nil {
+		return
+	}
+
+	return reputationBucket.Put([]byte(userId), marshal)
+}
+
+func getUserForTopic(db *bolt.DB, userId, topicId string) (response openapi.User, err error) {
+	err = db.View(func(tx *bolt.Tx) error {
+		response, err = getUserForTopicRx(tx, userId, topicId)
+		return err
+	})
+
+	return
+}
+
+// returns the user as they are seen inside the topic, see scopeUserRx
+func getUserForTopicRx(tx *bolt.Tx, userId, topicId string) (response openapi.User, err error) {
+	response, err = getUserRx(tx, userId)
+	if err != nil {
+		return
+	}
+
+	// a missing topic is reported by whatever is done with it next
+	info, infoErr := getTopicInfoRx(tx, topicId)
+	if infoErr != nil {
+		return
+	}
+
+	response = scopeUserRx(tx, response, info.OrganizationId)
+
+	return
+}
+
+func getUserForOrganization(db *bolt.DB, userId, organizationId string) (response openapi.User, err error) {
+	err = db.View(func(tx *bolt.Tx) error {
+		response, err = getUserRx(tx, userId)
+		if err != nil {
+			return err
+		}
+
+		response = scopeUserRx(tx, response, organizationId)
+		return nil
+	})
+
+	return
+}
+
+// inside an organization its admins act as admins and only the reputation earned there counts
+//
+// global admins are left alone
+func scopeUserRx(tx *bolt.Tx, user openapi.User, organizationId string) openapi.User {
+	if organizationId == "" || user.Role == KeyAdmin {
+		return user
+	}
+
+	user.Reputation = getOrganizationReputationRx(tx, organizationId, user.Id)
+	if isOrganizationAdminRx(tx, organizationId, user.Id) {
+		user.Role = KeyAdmin
+	}
+
+	return user
+}
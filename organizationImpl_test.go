@@ -0,0 +1,194 @@
+package main
+
+import (
+	"testing"
+
+	openapi "github.com/SpyLime/flowBackend/go"
+	"github.com/go-pkgz/lgr"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostSchoolImpl(t *testing.T) {
+
+	lgr.Printf("INFO TestPostSchoolImpl")
+	t.Log("INFO TestPostSchoolImpl")
+	clock := TestClock{}
+	db, dbTearDown := OpenTestDB("PostSchoolImpl")
+	defer dbTearDown()
+
+	request := NewSchoolRequest{
+		School:    "Central High",
+		FirstName: "Ann",
+		LastName:  "Lee",
+		Email:     "Ann@Central.edu",
+		City:      "Austin",
+		Zip:       78701,
+	}
+
+	response, err := postSchool(db, &clock, request)
+	require.Nil(t, err)
+	require.NotEmpty(t, response.AdminPassword)
+
+	admin, err := getUser(db, response.AdminId)
+	require.Nil(t, err)
+	require.Equal(t, response.OrganizationId, admin.OrganizationId)
+	require.Equal(t, int32(KeyUser), admin.Role)
+	require.True(t, isOrganizationAdmin(db, response.OrganizationId, admin.Id))
+
+	checker := schoolCredChecker(db, &clock)
+
+	ok, err := checker("ann@central.edu", response.AdminPassword)
+	require.Nil(t, err)
+	require.True(t, ok)
+
+	ok, err = checker("ann@central.edu", "wrong")
+	require.Nil(t, err)
+	require.False(t, ok)
+
+	ok, err = checker("nobody@central.edu", response.AdminPassword)
+	require.Nil(t, err)
+	require.False(t, ok)
+
+	_, err = postSchool(db, &clock, request)
+	require.NotNil(t, err)
+
+	_, err = postSchool(db, &clock, NewSchoolRequest{Email: "x@y.z"})
+	require.NotNil(t, err)
+}
+
+func TestOrganizationTenancyImpl(t *testing.T) {
+
+	lgr.Printf("INFO TestOrganizationTenancyImpl")
+	t.Log("INFO TestOrganizationTenancyImpl")
+	clock := TestClock{}
+	db, dbTearDown := OpenTestDB("OrganizationTenancyImpl")
+	defer dbTearDown()
+
+	users, topics, _, err := CreateTestData(db, &clock, 3, 1, 0)
+	require.Nil(t, err)
+
+	for _, id := range users {
+		err = UpdateUserRoleAndReputation(db, id, false, 0)
+		require.Nil(t, err)
+	}
+	globalAdmin := users[2]
+	err = UpdateUserRoleAndReputation(db, globalAdmin, true, 0)
+	require.Nil(t, err)
+
+	school, err := postSchool(db, &clock, NewSchoolRequest{School: "Central High", Email: "ann@central.edu"})
+	require.Nil(t, err)
+
+	member, outsider := users[0], users[1]
+
+	// the member joins once invited, the outsider wasn't invited
+	_, err = updateOrganizationMember(db, school.OrganizationId, openapi.OrganizationMember{UserId: outsider}, outsider)
+	require.NotNil(t, err)
+
+	invited, err := updateOrganizationMember(db, school.OrganizationId, openapi.OrganizationMember{UserId: member}, school.AdminId)
+	require.Nil(t, err)
+	require.Equal(t, []string{member}, invited.Invites)
+
+	invitations, err := getOrganizations(db, member)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(invitations))
+
+	_, err = updateOrganizationMember(db, school.OrganizationId, openapi.OrganizationMember{UserId: member, IsAdmin: true}, member)
+	require.Nil(t, err)
+
+	joined, err := getOrganizations(db, member)
+	require.Nil(t, err)
+	require.Empty(t, joined[0].Invites)
+	require.NotContains(t, joined[0].Admins, member)
+
+	schoolAdmin, err := getUser(db, school.AdminId)
+	require.Nil(t, err)
+
+	clock.Tick()
+	schoolTopic, err := postTopic(db, &clock, openapi.Topic{Title: "school topic"}, schoolAdmin)
+	require.Nil(t, err)
+	require.Equal(t, school.OrganizationId, schoolTopic.Topic.OrganizationId)
+
+	for _, viewer := range []string{school.AdminId, member, globalAdmin} {
+		visible, err := topicVisible(db, "school topic", viewer)
+		require.Nil(t, err)
+		require.True(t, visible)
+
+		listed, err := getVisibleTopics(db, viewer)
+		require.Nil(t, err)
+		require.Equal(t, 2, len(listed))
+	}
+
+	for _, viewer := range []string{outsider, ""} {
+		visible, err := topicVisible(db, "school topic", viewer)
+		require.Nil(t, err)
+		require.False(t, visible)
+
+		listed, err := getVisibleTopics(db, viewer)
+		require.Nil(t, err)
+		require.Equal(t, 1, len(listed))
+		require.Equal(t, topics[0], listed[0].Title)
+	}
+
+	// the school admin acts as an admin in school topics but not anywhere else
+	scoped, err := getUserForTopic(db, school.AdminId, "school topic")
+	require.Nil(t, err)
+	require.Equal(t, int32(KeyAdmin), scoped.Role)
+
+	scoped, err = getUserForTopic(db, school.AdminId, topics[0])
+	require.Nil(t, err)
+	require.Equal(t, int32(KeyUser), scoped.Role)
+
+	// votes in a school topic only count towards the reputation in the school
+	node, err := postNode(db, &clock, openapi.NodeData{
+		Id:        schoolTopic.NodeData.Id,
+		Topic:     "school topic",
+		Title:     "first",
+		CreatedBy: openapi.UserIdentifier{Id: member},
+	})
+	require.Nil(t, err)
+
+	_, err = updateNodeBattleVote(db, &clock, openapi.NodeData{Id: node.TargetId, Topic: "school topic", BattleTested: 1}, school.AdminId)
+	require.Nil(t, err)
+
+	memberDetails, err := getUser(db, member)
+	require.Nil(t, err)
+	require.Equal(t, int32(0), memberDetails.Reputation)
+
+	scoped, err = getUserForTopic(db, member, "school topic")
+	require.Nil(t, err)
+	require.Equal(t, int32(1), scoped.Reputation)
+
+	// what the school topic has isn't given away to outsiders
+	lecture := "https://www.youtube.com/watch?v=dQw4w9WgXcQ"
+	_, err = updateNodeVideoEdit(db, &clock, openapi.NodeData{Id: node.TargetId, Topic: "school topic", Resources: []openapi.LinkData{{Link: lecture, Votes: 1}}}, schoolAdmin)
+	require.Nil(t, err)
+
+	_, err = putWatchProgress(db, &clock, member, openapi.WatchProgress{Link: lecture, Percent: 50}, DefaultWatchConfig().FinishAt)
+	require.Nil(t, err)
+
+	_, err = putWatchProgress(db, &clock, outsider, openapi.WatchProgress{Link: lecture, Percent: 50}, DefaultWatchConfig().FinishAt)
+	require.NotNil(t, err)
+
+	// a user can only be in one school
+	other, err := postSchool(db, &clock, NewSchoolRequest{School: "North High", Email: "bob@north.edu"})
+	require.Nil(t, err)
+
+	_, err = updateOrganizationMember(db, other.OrganizationId, openapi.OrganizationMember{UserId: member}, globalAdmin)
+	require.NotNil(t, err)
+
+	organizations, err := getOrganizations(db, globalAdmin)
+	require.Nil(t, err)
+	require.Equal(t, 2, len(organizations))
+
+	organizations, err = getOrganizations(db, member)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(organizations))
+
+	err = deleteOrganizationMember(db, school.OrganizationId, member)
+	require.Nil(t, err)
+
+	visible, err := topicVisible(db, "school topic", member)
+	require.Nil(t, err)
+	require.False(t, visible)
+
+}
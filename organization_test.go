@@ -0,0 +1,138 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	openapi "github.com/SpyLime/flowBackend/go"
+	"github.com/go-pkgz/auth/token"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSchoolHandler(t *testing.T) {
+	clock := TestClock{}
+	db, tearDown := OpenTestDB("NewSchoolHandler")
+	defer tearDown()
+
+	users, _, _, err := CreateTestData(db, &clock, 2, 0, 0)
+	require.Nil(t, err)
+
+	err = UpdateUserRoleAndReputation(db, users[1], false, 1000)
+	require.Nil(t, err)
+
+	marshal, err := json.Marshal(NewSchoolRequest{School: "Central High", Email: "ann@central.edu"})
+	require.Nil(t, err)
+
+	handler := newSchoolHandler(db, &clock)
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/school", bytes.NewBuffer(marshal))
+	req = req.WithContext(context.WithValue(req.Context(), userInfoKey, token.User{ID: users[1]}))
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+	require.Equal(t, 401, resp.Code)
+
+	req = httptest.NewRequest(http.MethodPost, "/admin/school", bytes.NewBuffer(marshal))
+	req = req.WithContext(context.WithValue(req.Context(), userInfoKey, token.User{ID: users[0]}))
+	resp = httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+	require.Equal(t, 200, resp.Code)
+
+	var data NewSchoolResponse
+	err = json.NewDecoder(resp.Body).Decode(&data)
+	require.Nil(t, err)
+	require.NotEmpty(t, data.AdminPassword)
+	require.True(t, isOrganizationAdmin(db, data.OrganizationId, data.AdminId))
+}
+
+func TestUpdateOrganizationMember(t *testing.T) {
+	clock := TestClock{}
+	db, tearDown := FullStartTestServer("UpdateOrganizationMember", 8088, "")
+	defer tearDown()
+
+	users, _, _, err := CreateTestData(db, &clock, 2, 0, 0)
+	require.Nil(t, err)
+
+	for _, id := range users {
+		err = UpdateUserRoleAndReputation(db, id, false, 0)
+		require.Nil(t, err)
+	}
+
+	school, err := postSchool(db, &clock, NewSchoolRequest{School: "Central High", Email: "ann@central.edu"})
+	require.Nil(t, err)
+
+	client := &http.Client{}
+
+	marshal, err := json.Marshal(openapi.OrganizationMember{UserId: users[1], IsAdmin: true})
+	require.Nil(t, err)
+
+	// someone outside of the school can't add members
+	SetTestLoginUser(users[0])
+
+	req, _ := http.NewRequest(http.MethodPut, "http://127.0.0.1:8088/api/v1/organization/"+school.OrganizationId+"/member", bytes.NewBuffer(marshal))
+
+	resp, err := client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 401, resp.StatusCode)
+
+	SetTestLoginUser(school.AdminId)
+
+	req, _ = http.NewRequest(http.MethodPut, "http://127.0.0.1:8088/api/v1/organization/"+school.OrganizationId+"/member", bytes.NewBuffer(marshal))
+
+	resp, err = client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 200, resp.StatusCode)
+
+	// the school admin only invites the user
+	var organization openapi.Organization
+	err = json.NewDecoder(resp.Body).Decode(&organization)
+	require.Nil(t, err)
+	require.Contains(t, organization.Invites, users[1])
+	require.NotContains(t, organization.Admins, users[1])
+
+	member, err := getUser(db, users[1])
+	require.Nil(t, err)
+	require.Empty(t, member.OrganizationId)
+
+	// the user accepts by adding themselves
+	SetTestLoginUser(users[1])
+
+	req, _ = http.NewRequest(http.MethodPut, "http://127.0.0.1:8088/api/v1/organization/"+school.OrganizationId+"/member", bytes.NewBuffer(marshal))
+
+	resp, err = client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 200, resp.StatusCode)
+
+	SetTestLoginUser(school.AdminId)
+
+	req, _ = http.NewRequest(http.MethodPut, "http://127.0.0.1:8088/api/v1/organization/"+school.OrganizationId+"/member", bytes.NewBuffer(marshal))
+
+	resp, err = client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 200, resp.StatusCode)
+
+	var promoted openapi.Organization
+	err = json.NewDecoder(resp.Body).Decode(&promoted)
+	require.Nil(t, err)
+	require.Contains(t, promoted.Admins, users[1])
+	require.Empty(t, promoted.Invites)
+
+	req, _ = http.NewRequest(http.MethodDelete, "http://127.0.0.1:8088/api/v1/organization/"+school.OrganizationId+"/member/"+url.PathEscape(users[1]), nil)
+
+	resp, err = client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 204, resp.StatusCode)
+
+	member, err = getUser(db, users[1])
+	require.Nil(t, err)
+	require.Empty(t, member.OrganizationId)
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// OutboundClient makes every request the server sends to other sites on behalf of users.
+// Only allowed hosts are asked, addresses inside our network are refused after their name is resolved
+// and every redirect is checked again
+type OutboundClient struct {
+	client  *http.Client
+	config  OutboundConfig
+	blocked func(ip net.IP) bool
+}
+
+// ranges that aren't on the public internet, on top of the ones net.IP knows about
+var reservedNetworks = parseNetworks(
+	"0.0.0.0/8",       // this network
+	"100.64.0.0/10",   // carrier grade nat
+	"192.0.0.0/24",    // protocol assignments
+	"192.0.2.0/24",    // documentation
+	"198.18.0.0/15",   // benchmarking
+	"198.51.100.0/24", // documentation
+	"203.0.113.0/24",  // documentation
+	"240.0.0.0/4",     // reserved
+	"64:ff9b::/96",    // nat64 reaches ipv4 addresses
+	"2001:db8::/32",   // documentation
+)
+
+func parseNetworks(cidrs ...string) (networks []*net.IPNet) {
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks = append(networks, network)
+	}
+
+	return
+}
+
+// blockedIP is true for loopback, private, link local and every other address that isn't public
+func blockedIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() {
+		return true
+	}
+
+	for _, network := range reservedNetworks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func NewOutboundClient(config OutboundConfig) *OutboundClient {
+	c := &OutboundClient{
+		config:  config,
+		blocked: blockedIP,
+	}
+
+	dialer := &net.Dialer{
+		Timeout: config.Timeout,
+		// the address is checked as it is connected to so a name can't resolve to a public address first and a private one later
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+
+			ip := net.ParseIP(host)
+			if ip == nil || c.blocked(ip) {
+				return fmt.Errorf("address %s isn't allowed", host)
+			}
+
+			return nil
+		},
+	}
+
+	c.client = &http.Client{
+		Timeout: config.Timeout,
+		// a proxy would connect for us and skip the address check
+		Transport: &http.Transport{
+			Proxy:                 nil,
+			DialContext:           dialer.DialContext,
+			TLSHandshakeTimeout:   config.Timeout,
+			ResponseHeaderTimeout: config.Timeout,
+			MaxIdleConns:          10,
+			IdleConnTimeout:       90 * time.Second,
+		},
+		CheckRedirect: func(request *http.Request, via []*http.Request) error {
+			if len(via) > config.MaxRedirects {
+				return fmt.Errorf("too many redirects")
+			}
+
+			return c.allowed(request.URL)
+		},
+	}
+
+	return c
+}
+
+// allowed checks the scheme and the host, a host in the allow list also allows its subdomains and * allows every host
+func (c *OutboundClient) allowed(link *url.URL) error {
+	if link.Scheme != "http" && link.Scheme != "https" {
+		return fmt.Errorf("only http and https links can be fetched")
+	}
+
+	host := strings.TrimSuffix(strings.ToLower(link.Hostname()), ".")
+	for _, allowed := range c.config.AllowHosts {
+		allowed = strings.ToLower(allowed)
+		if allowed == "*" || host == allowed || strings.HasSuffix(host, "."+allowed) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("host %s isn't allowed", host)
+}
+
+// Do sends the request once its link is allowed, the caller closes the body
+func (c *OutboundClient) Do(request *http.Request) (response *http.Response, err error) {
+	err = c.allowed(request.URL)
+	if err != nil {
+		return
+	}
+
+	return c.client.Do(request)
+}
+
+// Get returns at most MaxBytes of the answer, anything but 200 is an error
+func (c *OutboundClient) Get(ctx context.Context, link, accept string) (body []byte, err error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, link, nil)
+	if err != nil {
+		return
+	}
+	request.Header.Set("User-Agent", KeyOutboundUserAgent)
+	request.Header.Set("Accept", accept)
+
+	response, err := c.Do(request)
+	if err != nil {
+		return
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s returned status %d", link, response.StatusCode)
+	}
+
+	return io.ReadAll(io.LimitReader(response.Body, c.config.MaxBytes))
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockedIP(t *testing.T) {
+	for _, address := range []string{"127.0.0.1", "10.1.2.3", "172.16.0.1", "192.168.1.1", "169.254.169.254", "0.0.0.0", "100.64.0.1", "::1", "fe80::1", "fc00::1", "::ffff:127.0.0.1", "224.0.0.1"} {
+		require.True(t, blockedIP(net.ParseIP(address)), address)
+	}
+
+	for _, address := range []string{"8.8.8.8", "142.250.74.46", "2a00:1450:4001:82b::200e"} {
+		require.False(t, blockedIP(net.ParseIP(address)), address)
+	}
+}
+
+func TestOutboundClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/away":
+			http.Redirect(w, r, "http://example.org/", http.StatusFound)
+		case "/loop":
+			http.Redirect(w, r, "/loop", http.StatusFound)
+		case "/home":
+			http.Redirect(w, r, "/ok", http.StatusFound)
+		default:
+			fmt.Fprint(w, "ok")
+		}
+	}))
+	defer server.Close()
+
+	parsed, err := url.Parse(server.URL)
+	require.Nil(t, err)
+
+	// loopback is refused after the name is resolved even when the host is allowed
+	config := DefaultOutboundConfig()
+	config.AllowHosts = []string{"127.0.0.1", "localhost"}
+	client := NewOutboundClient(config)
+
+	_, err = client.Get(context.Background(), server.URL+"/ok", "text/html")
+	require.NotNil(t, err)
+
+	_, err = client.Get(context.Background(), "http://localhost:"+parsed.Port()+"/ok", "text/html")
+	require.NotNil(t, err)
+
+	// hosts that aren't allowed and other schemes are never asked
+	client = newStandInClient(server, DefaultOutboundConfig())
+
+	_, err = client.Get(context.Background(), "http://metadata.internal/latest", "text/html")
+	require.NotNil(t, err)
+
+	_, err = client.Get(context.Background(), "file:///etc/passwd", "text/html")
+	require.NotNil(t, err)
+
+	body, err := client.Get(context.Background(), server.URL+"/ok", "text/html")
+	require.Nil(t, err)
+	require.Equal(t, "ok", string(body))
+
+	// redirects are checked like the first request
+	body, err = client.Get(context.Background(), server.URL+"/home", "text/html")
+	require.Nil(t, err)
+	require.Equal(t, "ok", string(body))
+
+	_, err = client.Get(context.Background(), server.URL+"/away", "text/html")
+	require.NotNil(t, err)
+
+	_, err = client.Get(context.Background(), server.URL+"/loop", "text/html")
+	require.NotNil(t, err)
+
+	// youtube.com allows its subdomains but not hosts that only end the same
+	client = NewOutboundClient(DefaultOutboundConfig())
+	for _, link := range []string{"https://www.youtube.com/watch", "https://m.youtube.com/", "https://youtu.be/x"} {
+		parsed, _ := url.Parse(link)
+		require.Nil(t, client.allowed(parsed), link)
+	}
+	for _, link := range []string{"https://notyoutube.com/", "https://youtube.com.example.org/"} {
+		parsed, _ := url.Parse(link)
+		require.NotNil(t, client.allowed(parsed), link)
+	}
+}
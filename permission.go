@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	openapi "github.com/SpyLime/flowBackend/go"
+	"github.com/go-pkgz/auth/token"
+	bolt "go.etcd.io/bbolt"
+)
+
+// PermissionAPIServiceImpl is a service that implements the logic for the PermissionAPIServicer
+// This service should implement the business logic for every endpoint for the PermissionAPI API.
+// Include any external packages or services that will be required by this service.
+type PermissionAPIServiceImpl struct {
+	db     *bolt.DB
+	clock  Clock
+	policy Policy
+}
+
+// NewPermissionAPIService creates a default api service
+func NewPermissionAPIServiceImpl(db *bolt.DB, clock Clock, policy Policy) openapi.PermissionAPIServicer {
+	return &PermissionAPIServiceImpl{
+		db:     db,
+		clock:  clock,
+		policy: policy,
+	}
+}
+
+// GetPermissions - check what the user is allowed to do, every action is checked when no action is given
+func (s *PermissionAPIServiceImpl) GetPermissions(ctx context.Context, action string, topicId string, nodeId string) (openapi.ImplResponse, error) {
+	// nobody is logged in when there is no user, they aren't allowed anything
+	user, _ := ctx.Value(userInfoKey).(token.User)
+
+	actions := s.policy.Actions()
+	if action != "" {
+		if _, ok := s.policy[action]; !ok {
+			return openapi.Response(400, nil), fmt.Errorf("unknown action %s", action)
+		}
+		actions = []string{action}
+	}
+	sort.Strings(actions)
+
+	if nodeId != "" && topicId == "" {
+		return openapi.Response(400, nil), errors.New("a node needs its topic")
+	}
+
+	target := PolicyTarget{TopicId: topicId}
+	var userDetails openapi.User
+
+	if topicId != "" {
+		// hidden topics look the same as missing ones
+		visible, err := topicVisible(s.db, topicId, user.ID)
+		if err != nil || !visible {
+			return openapi.Response(404, nil), errors.New("topic not found")
+		}
+
+		if nodeId != "" {
+			node, err := getNode(s.db, nodeId, topicId)
+			if err != nil {
+				return openapi.Response(404, nil), err
+			}
+
+			target.CreatorId = node.CreatedBy.Id
+			target.CreatedAt = node.Id
+		}
+
+		if user.ID != "" {
+			userDetails, _ = getUserForTopic(s.db, user.ID, topicId)
+		}
+	} else if user.ID != "" {
+		// outside of a topic the user acts for their school like when they add a topic
+		userDetails, _ = getUser(s.db, user.ID)
+		userDetails, _ = getUserForOrganization(s.db, user.ID, userDetails.OrganizationId)
+	}
+
+	response := make([]openapi.Permission, 0, len(actions))
+	for _, action := range actions {
+		err := s.policy.check(s.db, s.clock, action, userDetails, target)
+		response = append(response, openapi.Permission{Action: action, Allowed: err == nil})
+	}
+
+	return openapi.Response(200, response), nil
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	openapi "github.com/SpyLime/flowBackend/go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetPermissions(t *testing.T) {
+	clock := TestClock{}
+	db, tearDown := FullStartTestServerClock("GetPermissions", 8088, "", &clock)
+	defer tearDown()
+
+	users, topics, nodesAndEdges, err := CreateTestData(db, &clock, 2, 1, 0)
+	require.Nil(t, err)
+
+	// the creator owns the topic so check someone else
+	info, err := getTopicInfo(db, topics[0])
+	require.Nil(t, err)
+
+	other := users[0]
+	if other == info.CreatedBy {
+		other = users[1]
+	}
+
+	err = UpdateUserRoleAndReputation(db, other, false, KeyReputationContributor)
+	require.Nil(t, err)
+	SetTestLoginUser(other)
+
+	client := &http.Client{}
+
+	params := url.Values{}
+	params.Add("topicId", topics[0])
+
+	req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("%s?%s", "http://127.0.0.1:8088/api/v1/permission", params.Encode()), nil)
+
+	resp, err := client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 200, resp.StatusCode)
+
+	var permissions []openapi.Permission
+	decoder := json.NewDecoder(resp.Body)
+	err = decoder.Decode(&permissions)
+	require.Nil(t, err)
+	require.Equal(t, len(DefaultPolicy()), len(permissions))
+
+	allowed := map[string]bool{}
+	for _, permission := range permissions {
+		allowed[permission.Action] = permission.Allowed
+	}
+
+	require.True(t, allowed[KeyActionAddNode])
+	require.True(t, allowed[KeyActionAddEdge])
+	require.False(t, allowed[KeyActionDeleteEdge])
+	require.False(t, allowed[KeyActionDeleteTopic])
+
+	// a node the user made just now can still be deleted by them
+	clock.Tick()
+	node, err := postNode(db, &clock, openapi.NodeData{
+		Id:        nodesAndEdges[0].SourceId,
+		Topic:     topics[0],
+		CreatedBy: openapi.UserIdentifier{Id: other},
+	})
+	require.Nil(t, err)
+
+	params.Add("nodeId", node.TargetId.Format(time.RFC3339Nano))
+	params.Add("action", KeyActionDeleteNode)
+
+	req, _ = http.NewRequest(http.MethodGet, fmt.Sprintf("%s?%s", "http://127.0.0.1:8088/api/v1/permission", params.Encode()), nil)
+
+	resp, err = client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 200, resp.StatusCode)
+
+	decoder = json.NewDecoder(resp.Body)
+	err = decoder.Decode(&permissions)
+	require.Nil(t, err)
+	require.Equal(t, []openapi.Permission{{Action: KeyActionDeleteNode, Allowed: true}}, permissions)
+
+	params.Set("action", "fly")
+
+	req, _ = http.NewRequest(http.MethodGet, fmt.Sprintf("%s?%s", "http://127.0.0.1:8088/api/v1/permission", params.Encode()), nil)
+
+	resp, err = client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 400, resp.StatusCode)
+}
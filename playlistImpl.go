@@ -0,0 +1,158 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	openapi "github.com/SpyLime/flowBackend/go"
+	bolt "go.etcd.io/bbolt"
+)
+
+// PlaylistFetcher lists the videos of a YouTube playlist with their titles, tests use a local file
+type PlaylistFetcher interface {
+	Fetch(ctx context.Context, playlistId string) ([]openapi.PlaylistVideo, error)
+}
+
+// the playlist page has its videos in the json it starts with
+var playlistVideoPattern = regexp.MustCompile(`"playlistVideoRenderer":\{"videoId":"([A-Za-z0-9_-]{11})".*?"title":\{"runs":\[\{"text":"((?:[^"\\]|\\.)*)"`)
+
+type httpPlaylistFetcher struct {
+	client *OutboundClient
+	page   string
+}
+
+func NewHTTPPlaylistFetcher(client *OutboundClient) PlaylistFetcher {
+	return &httpPlaylistFetcher{
+		client: client,
+		page:   "https://www.youtube.com/playlist",
+	}
+}
+
+// Fetch reads the first page of the playlist, that is its first hundred or so videos
+func (f *httpPlaylistFetcher) Fetch(ctx context.Context, playlistId string) (videos []openapi.PlaylistVideo, err error) {
+	body, err := f.client.Get(ctx, f.page+"?list="+url.QueryEscape(playlistId), "text/html")
+	if err != nil {
+		return
+	}
+
+	seen := make(map[string]bool)
+	for _, match := range playlistVideoPattern.FindAllSubmatch(body, -1) {
+		id := string(match[1])
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		var title string
+		err = json.Unmarshal([]byte(`"`+string(match[2])+`"`), &title)
+		if err != nil {
+			return nil, err
+		}
+
+		videos = append(videos, openapi.PlaylistVideo{Link: VideoRef{Id: id}.Canonical(), Title: title})
+	}
+
+	if len(videos) == 0 {
+		return nil, fmt.Errorf("can't find the videos of playlist %s", playlistId)
+	}
+
+	return
+}
+
+func importPlaylist(db *bolt.DB, clock Clock, topicId string, source time.Time, playlistId string, videos []openapi.PlaylistVideo, user openapi.User) (response openapi.PlaylistImportResult, err error) {
+	err = db.Update(func(tx *bolt.Tx) error {
+		response, err = importPlaylistTx(tx, clock, topicId, source, playlistId, videos, user)
+		return err
+	})
+
+	return
+}
+
+// importPlaylistTx chains a node for every video after the source node, each titled after its video and with it attached.
+// The videos remember the playlist they came from, nothing is kept when a video can't be added
+func importPlaylistTx(tx *bolt.Tx, clock Clock, topicId string, source time.Time, playlistId string, videos []openapi.PlaylistVideo, user openapi.User) (response openapi.PlaylistImportResult, err error) {
+	if len(videos) == 0 {
+		return response, fmt.Errorf("no videos to import")
+	}
+
+	if len(videos) > KeyPlaylistImportLimit {
+		return response, fmt.Errorf("a playlist can have at most %d videos", KeyPlaylistImportLimit)
+	}
+
+	for _, video := range videos {
+		if strings.TrimSpace(video.Title) == "" {
+			return response, fmt.Errorf("%s has no title", video.Link)
+		}
+
+		resource, err := resourceData(openapi.LinkData{Link: video.Link})
+		if err != nil {
+			return response, err
+		}
+
+		if resource.Type != KeyResourceVideo {
+			return response, fmt.Errorf("%s isn't a video", video.Link)
+		}
+	}
+
+	_, err = getNodeRx(tx, source.Format(time.RFC3339Nano), topicId)
+	if err != nil {
+		return
+	}
+
+	response.Nodes = make([]openapi.NodeReference, 0, len(videos))
+	response.Duplicates = make([]openapi.NodeReference, 0)
+
+	imported := make(map[string]bool)
+	reported := make(map[string]bool)
+	previous := source
+	for _, video := range videos {
+		clock.Tick()
+
+		title := strings.TrimSpace(video.Title)
+		created, err := postNodeTx(tx, clock, openapi.NodeData{
+			Id:    previous,
+			Topic: topicId,
+			Title: title,
+			CreatedBy: openapi.UserIdentifier{
+				Id:       user.Id,
+				Username: user.Username,
+			},
+		})
+		if err != nil {
+			return response, err
+		}
+
+		// nodes are keyed by when they are made, a clock that didn't move would write over the last one
+		if !created.TargetId.After(previous) {
+			return response, fmt.Errorf("nodes were made too fast, try again")
+		}
+		imported[created.TargetId.Format(time.RFC3339Nano)] = true
+
+		duplicates, err := updateNodeVideoEditTx(tx, clock, openapi.NodeData{
+			Id:        created.TargetId,
+			Topic:     topicId,
+			Resources: []openapi.LinkData{{Link: video.Link, Title: title, Playlist: playlistId, Votes: 1}},
+		}, user)
+		if err != nil {
+			return response, err
+		}
+
+		for _, duplicate := range duplicates {
+			nodeId := duplicate.Id.Format(time.RFC3339Nano)
+			if !imported[nodeId] && !reported[nodeId] {
+				reported[nodeId] = true
+				response.Duplicates = append(response.Duplicates, duplicate)
+			}
+		}
+
+		response.Nodes = append(response.Nodes, openapi.NodeReference{Id: created.TargetId, Title: title})
+		previous = created.TargetId
+	}
+
+	return
+}
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	openapi "github.com/SpyLime/flowBackend/go"
+	"github.com/go-pkgz/auth/token"
+	"github.com/go-pkgz/lgr"
+	"github.com/stretchr/testify/require"
+)
+
+// a stand-in that reads a playlist from the json file named after it
+type filePlaylistFetcher struct {
+	dir string
+}
+
+func (f filePlaylistFetcher) Fetch(_ context.Context, playlistId string) (videos []openapi.PlaylistVideo, err error) {
+	data, err := os.ReadFile(filepath.Join(f.dir, playlistId+".json"))
+	if err != nil {
+		return
+	}
+
+	err = json.Unmarshal(data, &videos)
+	return
+}
+
+// a clock that doesn't move when ticked, like a fast real one
+type frozenClock struct {
+	TestClock
+}
+
+func (c *frozenClock) Tick() {}
+
+func TestPlaylistImpl(t *testing.T) {
+
+	lgr.Printf("INFO TestPlaylistImpl")
+	t.Log("INFO TestPlaylistImpl")
+	clock := TestClock{}
+	db, dbTearDown := OpenTestDB("PlaylistImpl")
+	defer dbTearDown()
+
+	users, topics, nodesAndEdges, err := CreateTestData(db, &clock, 2, 1, 1)
+	require.Nil(t, err)
+
+	importer, err := getUser(db, users[0])
+	require.Nil(t, err)
+
+	root, existing := nodesAndEdges[0].SourceId, nodesAndEdges[1].TargetId
+	_, err = updateNodeVideoEdit(db, &clock, openapi.NodeData{Id: existing, Topic: topics[0], Resources: []openapi.LinkData{{Link: "https://youtu.be/9bZkp7q19f0", Votes: 1}}}, importer)
+	require.Nil(t, err)
+
+	videos := []openapi.PlaylistVideo{
+		{Link: "https://www.youtube.com/watch?v=dQw4w9WgXcQ", Title: "closed guard"},
+		{Link: "https://www.youtube.com/watch?v=9bZkp7q19f0", Title: "arm bar"},
+		{Link: "https://vimeo.com/76979871", Title: " triangle "},
+	}
+
+	// nothing is kept when the list can't be imported
+	for _, wrong := range [][]openapi.PlaylistVideo{
+		nil,
+		{videos[0], {Link: videos[1].Link}},
+		{videos[0], {Link: "https://example.org/blog/ninjas", Title: "ninjas"}},
+	} {
+		_, err = importPlaylist(db, &clock, topics[0], root, "", wrong, importer)
+		require.NotNil(t, err)
+	}
+
+	_, err = importPlaylist(db, &clock, topics[0], time.Now(), "", videos, importer)
+	require.NotNil(t, err)
+
+	_, err = importPlaylist(db, &frozenClock{clock}, topics[0], root, "", videos, importer)
+	require.NotNil(t, err)
+
+	before, err := getMapById(db, topics[0])
+	require.Nil(t, err)
+	require.Equal(t, 2, len(before.Nodes))
+
+	result, err := importPlaylist(db, &clock, topics[0], root, "PLFgquLnL59alCl_2TQvOiD5Vgm1hCaGSI", videos, importer)
+	require.Nil(t, err)
+	require.Equal(t, 3, len(result.Nodes))
+	require.Equal(t, "triangle", result.Nodes[2].Title)
+	require.Equal(t, 1, len(result.Duplicates))
+	require.Equal(t, existing, result.Duplicates[0].Id)
+
+	// the nodes are chained in the order of the list
+	after, err := getMapById(db, topics[0])
+	require.Nil(t, err)
+	require.Equal(t, 5, len(after.Nodes))
+
+	previous := root
+	for i, created := range result.Nodes {
+		_, err = getEdge(db, topics[0], previous.Format(time.RFC3339Nano)+"-"+created.Id.Format(time.RFC3339Nano))
+		require.Nil(t, err)
+
+		node, err := getNode(db, created.Id.Format(time.RFC3339Nano), topics[0])
+		require.Nil(t, err)
+		require.Equal(t, created.Title, node.Title)
+		require.Equal(t, users[0], node.CreatedBy.Id)
+		require.Equal(t, 1, len(node.Resources))
+		require.Equal(t, KeyResourceVideo, node.Resources[0].Type)
+		require.Equal(t, "PLFgquLnL59alCl_2TQvOiD5Vgm1hCaGSI", node.Resources[0].Playlist)
+		if i == 0 {
+			require.Equal(t, videos[0].Link, node.Resources[0].Link)
+		}
+
+		previous = created.Id
+	}
+}
+
+func TestImportTopicPlaylist(t *testing.T) {
+
+	lgr.Printf("INFO TestImportTopicPlaylist")
+	t.Log("INFO TestImportTopicPlaylist")
+	clock := TestClock{}
+	db, dbTearDown := OpenTestDB("ImportTopicPlaylist")
+	defer dbTearDown()
+
+	users, topics, nodesAndEdges, err := CreateTestData(db, &clock, 2, 1, 0)
+	require.Nil(t, err)
+
+	dir := t.TempDir()
+	playlistId := "PLFgquLnL59alCl_2TQvOiD5Vgm1hCaGSI"
+	marshal, err := json.Marshal([]openapi.PlaylistVideo{
+		{Link: "https://www.youtube.com/watch?v=dQw4w9WgXcQ", Title: "closed guard"},
+		{Link: "https://www.youtube.com/watch?v=9bZkp7q19f0", Title: "arm bar"},
+	})
+	require.Nil(t, err)
+	err = os.WriteFile(filepath.Join(dir, playlistId+".json"), marshal, 0644)
+	require.Nil(t, err)
+
+	service := NewTopicAPIServiceImpl(db, &clock, DefaultPolicy(), filePlaylistFetcher{dir: dir})
+	ctx := context.WithValue(context.Background(), userInfoKey, token.User{ID: users[0]})
+	root := nodesAndEdges[0].SourceId
+
+	response, err := service.ImportTopicPlaylist(ctx, topics[0], openapi.PlaylistImport{Source: root, Playlist: "https://www.youtube.com/playlist?list=" + playlistId})
+	require.Nil(t, err)
+	require.Equal(t, 2, len(response.Body.(openapi.PlaylistImportResult).Nodes))
+
+	response, err = service.ImportTopicPlaylist(ctx, topics[0], openapi.PlaylistImport{Source: root, Videos: []openapi.PlaylistVideo{{Link: "https://youtu.be/kJQP7kiw5Fk", Title: "sweeps"}}})
+	require.Nil(t, err)
+	require.Equal(t, 200, response.Code)
+
+	response, err = service.ImportTopicPlaylist(ctx, topics[0], openapi.PlaylistImport{Source: root, Playlist: "https://example.org/list"})
+	require.NotNil(t, err)
+	require.Equal(t, 400, response.Code)
+
+	response, err = service.ImportTopicPlaylist(ctx, topics[0], openapi.PlaylistImport{Source: time.Now(), Playlist: playlistId})
+	require.NotNil(t, err)
+	require.Equal(t, 404, response.Code)
+
+	// without a fetcher only pasted lists are imported
+	service = NewTopicAPIServiceImpl(db, &clock, DefaultPolicy(), nil)
+	response, err = service.ImportTopicPlaylist(ctx, topics[0], openapi.PlaylistImport{Source: root, Playlist: playlistId})
+	require.NotNil(t, err)
+	require.Equal(t, 400, response.Code)
+}
+
+func TestHTTPPlaylistFetcher(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("list") != "PLninjas12345" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		fmt.Fprint(w, `<html><script>var ytInitialData = {"contents":[`+
+			`{"playlistVideoRenderer":{"videoId":"dQw4w9WgXcQ","thumbnail":{"thumbnails":[{"url":"https://i.ytimg.com/vi/dQw4w9WgXcQ/hqdefault.jpg"}]},"title":{"runs":[{"text":"closed \"guard\" & sweeps"}]}}},`+
+			`{"playlistVideoRenderer":{"videoId":"9bZkp7q19f0","thumbnail":{"thumbnails":[]},"title":{"runs":[{"text":"arm bar"}]}}},`+
+			`{"playlistVideoRenderer":{"videoId":"dQw4w9WgXcQ","thumbnail":{"thumbnails":[]},"title":{"runs":[{"text":"closed guard again"}]}}}`+
+			`]};</script></html>`)
+	}))
+	defer server.Close()
+
+	fetcher := NewHTTPPlaylistFetcher(newStandInClient(server, DefaultOutboundConfig())).(*httpPlaylistFetcher)
+	fetcher.page = server.URL + "/playlist"
+
+	videos, err := fetcher.Fetch(context.Background(), "PLninjas12345")
+	require.Nil(t, err)
+	require.Equal(t, []openapi.PlaylistVideo{
+		{Link: "https://www.youtube.com/watch?v=dQw4w9WgXcQ", Title: `closed "guard" & sweeps`},
+		{Link: "https://www.youtube.com/watch?v=9bZkp7q19f0", Title: "arm bar"},
+	}, videos)
+
+	_, err = fetcher.Fetch(context.Background(), "PLmissing1234")
+	require.NotNil(t, err)
+
+	for value, id := range map[string]string{
+		"PLninjas12345": "PLninjas12345",
+		"https://www.youtube.com/playlist?list=PLninjas12345":        "PLninjas12345",
+		"youtube.com/watch?v=dQw4w9WgXcQ&list=PLninjas12345&index=2": "PLninjas12345",
+		"https://example.org/playlist?list=PLninjas12345":            "",
+		"https://www.youtube.com/watch?v=dQw4w9WgXcQ":                "",
+	} {
+		parsed, err := parsePlaylistId(value)
+		require.Equal(t, id, parsed, value)
+		require.Equal(t, id == "", err != nil, value)
+	}
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	openapi "github.com/SpyLime/flowBackend/go"
+	bolt "go.etcd.io/bbolt"
+)
+
+const (
+	KeyActionAddNode          = "addNode"
+	KeyActionAddEdge          = "addEdge"
+	KeyActionDeleteEdge       = "deleteEdge"
+	KeyActionEditTitle        = "editTitle"
+	KeyActionDeleteNode       = "deleteNode"
+	KeyActionAddTopic         = "addTopic"
+	KeyActionUpdateTopic      = "updateTopic"
+	KeyActionShareTopic       = "shareTopic"
+	KeyActionDeleteTopic      = "deleteTopic"
+	KeyActionManageRoles      = "manageRoles"
+	KeyActionFlag             = "flag"
+	KeyActionUnflag           = "unflag"
+	KeyActionModerate         = "moderate"
+	KeyActionVote             = "vote"
+	KeyActionSanction         = "sanction"
+	KeyActionSuggest          = "suggest"
+	KeyActionReview           = "reviewSuggestion"
+	KeyActionLock             = "lock"
+	KeyActionAddVideo         = "addVideo"
+	KeyActionRemoveVideo      = "removeVideo"
+	KeyActionCreateGroup      = "createGroup"
+	KeyActionVotePatterns     = "votePatterns"
+	KeyActionVoidVotes        = "voidVotes"
+	KeyActionAdjustReputation = "adjustReputation"
+	KeyActionViewAudit        = "viewAudit"
+	KeyPolicyAdmin            = "admin"
+)
+
+// PolicyRule is what a user needs to be allowed to do an action.
+//
+// Admins can always do it, otherwise any one of these is enough:
+// a role in the topic with the right, being the creator within the owner window
+// or having the reputation when the rule is not for admins only
+type PolicyRule struct {
+	Role        string        `yaml:"role"` // admin or empty for everyone
+	Reputation  int32         `yaml:"reputation"`
+	OwnerWindow time.Duration `yaml:"ownerwindow"`
+	TopicRight  string        `yaml:"topicright"`
+}
+
+// Policy maps an action to its rule, an action missing from the policy is only for admins
+type Policy map[string]PolicyRule
+
+// what an action is done to, leave it empty for actions outside of a topic
+type PolicyTarget struct {
+	TopicId   string
+	CreatorId string
+	CreatedAt time.Time
+}
+
+// DefaultPolicy is used for every action flcfg.yml doesn't mention
+func DefaultPolicy() Policy {
+	return Policy{
+		KeyActionAddNode:          {Reputation: KeyReputationContributor, TopicRight: KeyRightEdit},
+		KeyActionAddEdge:          {Reputation: KeyReputationContributor, TopicRight: KeyRightEdge},
+		KeyActionDeleteEdge:       {Reputation: KeyReputationEditor, TopicRight: KeyRightEdge},
+		KeyActionEditTitle:        {Reputation: KeyReputationEditor, OwnerWindow: 15 * time.Minute, TopicRight: KeyRightEdit},
+		KeyActionDeleteNode:       {Reputation: KeyReputationDeleter, OwnerWindow: 15 * time.Minute, TopicRight: KeyRightDelete},
+		KeyActionAddTopic:         {Reputation: KeyReputationDeleter},
+		KeyActionUpdateTopic:      {Reputation: KeyReputationDeleter, TopicRight: KeyRightManage},
+		KeyActionShareTopic:       {Role: KeyPolicyAdmin, TopicRight: KeyRightManage},
+		KeyActionDeleteTopic:      {Role: KeyPolicyAdmin},
+		KeyActionManageRoles:      {Role: KeyPolicyAdmin, TopicRight: KeyRightManage},
+		KeyActionFlag:             {},
+		KeyActionUnflag:           {Role: KeyPolicyAdmin, TopicRight: KeyRightModerate},
+		KeyActionModerate:         {Role: KeyPolicyAdmin, TopicRight: KeyRightModerate},
+		KeyActionVote:             {},
+		KeyActionSanction:         {Role: KeyPolicyAdmin},
+		KeyActionSuggest:          {},
+		KeyActionReview:           {Reputation: KeyReputationEditor, TopicRight: KeyRightEdit},
+		KeyActionLock:             {Role: KeyPolicyAdmin, TopicRight: KeyRightEdit},
+		KeyActionAddVideo:         {},
+		KeyActionRemoveVideo:      {Reputation: KeyReputationEditor, OwnerWindow: 15 * time.Minute, TopicRight: KeyRightEdit},
+		KeyActionCreateGroup:      {Reputation: KeyReputationDeleter},
+		KeyActionVotePatterns:     {Role: KeyPolicyAdmin},
+		KeyActionVoidVotes:        {Role: KeyPolicyAdmin},
+		KeyActionAdjustReputation: {Role: KeyPolicyAdmin},
+		KeyActionViewAudit:        {Role: KeyPolicyAdmin},
+	}
+}
+
+// Actions returns every action the policy knows about
+func (p Policy) Actions() []string {
+	actions := make([]string, 0, len(p))
+	for action := range p {
+		actions = append(actions, action)
+	}
+
+	return actions
+}
+
+// capabilities returns the actions anyone with the reputation can do anywhere, without a role in the topic
+func (p Policy) capabilities(reputation int32) []string {
+	actions := make([]string, 0)
+	for action, rule := range p {
+		if rule.Role == "" && reputation >= rule.Reputation {
+			actions = append(actions, action)
+		}
+	}
+	sort.Strings(actions)
+
+	return actions
+}
+
+func (p Policy) check(db *bolt.DB, clock Clock, action string, user openapi.User, target PolicyTarget) (err error) {
+	_ = db.View(func(tx *bolt.Tx) error {
+		err = p.checkRx(tx, clock, action, user, target)
+		return nil
+	})
+
+	return
+}
+
+// user should already be scoped to the topic, see getUserForTopicRx
+func (p Policy) checkRx(tx *bolt.Tx, clock Clock, action string, user openapi.User, target PolicyTarget) error {
+	if user.Role == KeyAdmin {
+		return nil
+	}
+
+	rule, ok := p[action]
+	if !ok {
+		return fmt.Errorf("unauthorized: only admins can %s", action)
+	}
+
+	if user.Id == "" {
+		return fmt.Errorf("unauthorized: log in to %s", action)
+	}
+
+	if rule.TopicRight != "" && target.TopicId != "" && hasTopicRightRx(tx, target.TopicId, user.Id, rule.TopicRight) {
+		return nil
+	}
+
+	if rule.OwnerWindow > 0 && target.CreatorId == user.Id && clock.Now().Sub(target.CreatedAt) <= rule.OwnerWindow {
+		return nil
+	}
+
+	if rule.Role == KeyPolicyAdmin {
+		return fmt.Errorf("unauthorized: user is not an admin or has no role in the topic to %s", action)
+	}
+
+	if user.Reputation < rule.Reputation {
+		return fmt.Errorf("unauthorized: user is not an admin, has no role in the topic or has low reputation(%d) to %s", rule.Reputation, action)
+	}
+
+	return nil
+}
+
+// checkUser is check for a user that still has to be looked up, as they are seen inside the topic of the target
+func (p Policy) checkUser(db *bolt.DB, clock Clock, action, userId string, target PolicyTarget) error {
+	if userId == "" {
+		return fmt.Errorf("unauthorized: log in to %s", action)
+	}
+
+	userDetails, err := getUserForTopic(db, userId, target.TopicId)
+	if err != nil {
+		return err
+	}
+
+	return p.check(db, clock, action, userDetails, target)
+}
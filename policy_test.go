@@ -0,0 +1,84 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	openapi "github.com/SpyLime/flowBackend/go"
+	"github.com/go-pkgz/lgr"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestPolicyCheck(t *testing.T) {
+
+	lgr.Printf("INFO TestPolicyCheck")
+	t.Log("INFO TestPolicyCheck")
+	clock := TestClock{}
+	db, dbTearDown := OpenTestDB("PolicyCheck")
+	defer dbTearDown()
+
+	users, topics, _, err := CreateTestData(db, &clock, 2, 1, 0)
+	require.Nil(t, err)
+
+	info, err := getTopicInfo(db, topics[0])
+	require.Nil(t, err)
+
+	other := users[0]
+	if other == info.CreatedBy {
+		other = users[1]
+	}
+
+	err = UpdateUserRoleAndReputation(db, other, false, KeyReputationEditor)
+	require.Nil(t, err)
+
+	user, err := getUser(db, other)
+	require.Nil(t, err)
+
+	policy := DefaultPolicy()
+	target := PolicyTarget{TopicId: topics[0]}
+
+	require.Nil(t, policy.check(db, &clock, KeyActionAddNode, user, target))
+	require.Nil(t, policy.check(db, &clock, KeyActionEditTitle, user, target))
+	require.NotNil(t, policy.check(db, &clock, KeyActionDeleteNode, user, target))
+	require.NotNil(t, policy.check(db, &clock, KeyActionDeleteTopic, user, target))
+	require.NotNil(t, policy.check(db, &clock, "unknown", user, target))
+	require.NotNil(t, policy.check(db, &clock, KeyActionVote, openapi.User{}, target))
+
+	// the creator can delete their own node for a while
+	target.CreatorId = other
+	target.CreatedAt = clock.Now()
+	require.Nil(t, policy.check(db, &clock, KeyActionDeleteNode, user, target))
+
+	clock.TickOne(16 * time.Minute)
+	require.NotNil(t, policy.check(db, &clock, KeyActionDeleteNode, user, target))
+
+	// a role in the topic is enough
+	_, err = updateTopicRole(db, topics[0], openapi.TopicRole{UserId: other, Role: KeyTopicMaintainer})
+	require.Nil(t, err)
+	require.Nil(t, policy.check(db, &clock, KeyActionDeleteNode, user, target))
+
+	// admins can do anything
+	admin, err := getUser(db, info.CreatedBy)
+	require.Nil(t, err)
+	require.Nil(t, policy.check(db, &clock, KeyActionDeleteTopic, admin, target))
+}
+
+func TestPolicyConfig(t *testing.T) {
+	config := ServerConfig{Policy: DefaultPolicy()}
+
+	err := yaml.Unmarshal([]byte(`
+policy:
+  addNode:
+    reputation: 10
+  deleteNode:
+    reputation: 500
+    ownerwindow: 1h
+    topicright: delete
+`), &config)
+	require.Nil(t, err)
+
+	require.Equal(t, int32(10), config.Policy[KeyActionAddNode].Reputation)
+	require.Equal(t, time.Hour, config.Policy[KeyActionDeleteNode].OwnerWindow)
+	require.Equal(t, DefaultPolicy()[KeyActionDeleteEdge], config.Policy[KeyActionDeleteEdge])
+}
@@ -0,0 +1,229 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	openapi "github.com/SpyLime/flowBackend/go"
+	bolt "go.etcd.io/bbolt"
+)
+
+// rankResources scores the resources by the ranking and sorts them best first, ties go to the newest.
+// The resources of the caller are left alone
+func rankResources(resources []openapi.LinkData, ranking string, now time.Time) ([]openapi.LinkData, error) {
+	if ranking == "" {
+		ranking = KeyRankingWilson
+	}
+
+	ranked := make([]openapi.LinkData, len(resources))
+	for i, resource := range resources {
+		switch ranking {
+		case KeyRankingWilson:
+			resource.Score = wilsonLowerBound(resource.Up, resource.Down)
+		case KeyRankingHot:
+			resource.Score = wilsonLowerBound(resource.Up, resource.Down) * freshness(resource.DateAdded, now)
+		case KeyRankingVotes:
+			resource.Score = float64(resource.Votes)
+		case KeyRankingNew:
+			resource.Score = freshness(resource.DateAdded, now)
+		default:
+			return resources, fmt.Errorf("invalid ranking %s", ranking)
+		}
+		ranked[i] = resource
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].Score != ranked[j].Score {
+			return ranked[i].Score > ranked[j].Score
+		}
+		return ranked[i].DateAdded.After(ranked[j].DateAdded)
+	})
+
+	return ranked, nil
+}
+
+// wilsonLowerBound is the lowest share of up votes the resource likely has, a few votes are less certain than many
+func wilsonLowerBound(up, down int32) float64 {
+	up, down = max(up, 0), max(down, 0)
+	n := float64(up + down)
+	if n <= 0 {
+		return 0
+	}
+
+	z := KeyRankingConfidence
+	p := float64(up) / n
+
+	return (p + z*z/(2*n) - z*math.Sqrt((p*(1-p)+z*z/(4*n))/n)) / (1 + z*z/n)
+}
+
+// freshness halves every half life from 1 when the resource is added
+func freshness(added, now time.Time) float64 {
+	age := now.Sub(added)
+	if age < 0 {
+		age = 0
+	}
+
+	return math.Pow(0.5, float64(age)/float64(KeyRankingHalfLife))
+}
+
+// countVideoVotesTx sets the up and down votes of every resource from the votes of users, resources only had their difference before
+func countVideoVotesTx(tx *bolt.Tx) (err error) {
+	usersBucket := tx.Bucket([]byte(KeyUsers))
+	topicsBucket := tx.Bucket([]byte(KeyTopics))
+	if usersBucket == nil || topicsBucket == nil {
+		return
+	}
+
+	up := make(map[string]int32)
+	down := make(map[string]int32)
+	err = usersBucket.ForEach(func(_, data []byte) error {
+		var user openapi.User
+		err := json.Unmarshal(data, &user)
+		if err != nil {
+			return err
+		}
+
+		for _, link := range user.VideoUp {
+			up[link]++
+		}
+		for _, link := range user.VideoDown {
+			down[link]++
+		}
+
+		return nil
+	})
+	if err != nil {
+		return
+	}
+
+	return topicsBucket.ForEach(func(topicId, v []byte) error {
+		if v != nil {
+			return nil
+		}
+
+		nodesBucket := topicsBucket.Bucket(topicId).Bucket([]byte(KeyNodes))
+		if nodesBucket == nil {
+			return nil
+		}
+
+		return updateEachTx(nodesBucket, func(data []byte) (interface{}, error) {
+			var node openapi.NodeData
+			err := json.Unmarshal(data, &node)
+			for i, resource := range node.Resources {
+				node.Resources[i].Up = up[resource.Link]
+				node.Resources[i].Down = down[resource.Link]
+			}
+			return node, err
+		})
+	})
+}
+
+// videoVotesByNodeTx gives users a vote on each node of the videos they voted on, their votes only had the link before.
+// A video on more than one node gets the vote on each of them, then the up and down votes of every resource are counted by node
+func videoVotesByNodeTx(tx *bolt.Tx) (err error) {
+	usersBucket := tx.Bucket([]byte(KeyUsers))
+	topicsBucket := tx.Bucket([]byte(KeyTopics))
+	if usersBucket == nil || topicsBucket == nil {
+		return
+	}
+
+	// the nodes every video is on
+	places := make(map[string][]openapi.VideoVote)
+	err = topicsBucket.ForEach(func(topicId, v []byte) error {
+		if v != nil {
+			return nil
+		}
+
+		nodesBucket := topicsBucket.Bucket(topicId).Bucket([]byte(KeyNodes))
+		if nodesBucket == nil {
+			return nil
+		}
+
+		return nodesBucket.ForEach(func(_, data []byte) error {
+			if data == nil {
+				return nil
+			}
+
+			var node openapi.NodeData
+			err := json.Unmarshal(data, &node)
+			if err != nil {
+				return err
+			}
+
+			for _, resource := range node.Resources {
+				places[resource.Link] = append(places[resource.Link], openapi.VideoVote{Topic: string(topicId), NodeId: node.Id, Link: resource.Link})
+			}
+
+			return nil
+		})
+	})
+	if err != nil {
+		return
+	}
+
+	up := make(map[string]int32)
+	down := make(map[string]int32)
+	err = updateEachTx(usersBucket, func(data []byte) (interface{}, error) {
+		var user openapi.User
+		err := json.Unmarshal(data, &user)
+		if err != nil {
+			return user, err
+		}
+
+		if len(user.VideoVotes) == 0 {
+			for _, link := range user.VideoUp {
+				for _, place := range places[link] {
+					setVideoVote(&user, place.Topic, place.NodeId, place.Link, 1)
+				}
+			}
+			for _, link := range user.VideoDown {
+				for _, place := range places[link] {
+					if videoVote(user, place.Topic, place.NodeId, place.Link) == 0 {
+						setVideoVote(&user, place.Topic, place.NodeId, place.Link, -1)
+					}
+				}
+			}
+		}
+
+		for _, vote := range user.VideoVotes {
+			if vote.Value > 0 {
+				up[videoVoteKey(vote.Topic, vote.NodeId, vote.Link)]++
+			} else if vote.Value < 0 {
+				down[videoVoteKey(vote.Topic, vote.NodeId, vote.Link)]++
+			}
+		}
+
+		return user, nil
+	})
+	if err != nil {
+		return
+	}
+
+	return topicsBucket.ForEach(func(topicId, v []byte) error {
+		if v != nil {
+			return nil
+		}
+
+		nodesBucket := topicsBucket.Bucket(topicId).Bucket([]byte(KeyNodes))
+		if nodesBucket == nil {
+			return nil
+		}
+
+		return updateEachTx(nodesBucket, func(data []byte) (interface{}, error) {
+			var node openapi.NodeData
+			err := json.Unmarshal(data, &node)
+			for i, resource := range node.Resources {
+				node.Resources[i].Up = up[videoVoteKey(string(topicId), node.Id, resource.Link)]
+				node.Resources[i].Down = down[videoVoteKey(string(topicId), node.Id, resource.Link)]
+			}
+			return node, err
+		})
+	})
+}
+
+func videoVoteKey(topicId string, nodeId time.Time, link string) string {
+	return topicId + " " + nodeId.Format(time.RFC3339Nano) + " " + link
+}
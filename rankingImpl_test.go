@@ -0,0 +1,222 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	openapi "github.com/SpyLime/flowBackend/go"
+	"github.com/go-pkgz/auth/token"
+	"github.com/go-pkgz/lgr"
+	"github.com/stretchr/testify/require"
+	bolt "go.etcd.io/bbolt"
+)
+
+func TestRankResources(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// a few votes are less certain than many with the same share
+	require.Zero(t, wilsonLowerBound(0, 0))
+	require.Zero(t, wilsonLowerBound(-1, 0))
+	require.Less(t, wilsonLowerBound(1, 0), wilsonLowerBound(95, 5))
+	require.Less(t, wilsonLowerBound(5, 5), wilsonLowerBound(50, 10))
+
+	resources := []openapi.LinkData{
+		{Link: "one vote", Up: 1, Votes: 1, DateAdded: now.Add(-time.Hour)},
+		{Link: "many votes", Up: 95, Down: 5, Votes: 90, DateAdded: now.Add(-365 * 24 * time.Hour)},
+		{Link: "no votes", DateAdded: now},
+	}
+
+	ranked, err := rankResources(resources, "", now)
+	require.Nil(t, err)
+	require.Equal(t, "many votes", ranked[0].Link)
+	require.Equal(t, "one vote", ranked[1].Link)
+	require.Equal(t, "no votes", ranked[2].Link)
+	require.Zero(t, resources[0].Score)
+
+	// a year old link has lost most of its freshness
+	ranked, err = rankResources(resources, KeyRankingHot, now)
+	require.Nil(t, err)
+	require.Equal(t, "one vote", ranked[0].Link)
+
+	ranked, err = rankResources(resources, KeyRankingVotes, now)
+	require.Nil(t, err)
+	require.Equal(t, "many votes", ranked[0].Link)
+	require.Equal(t, float64(90), ranked[0].Score)
+
+	ranked, err = rankResources(resources, KeyRankingNew, now)
+	require.Nil(t, err)
+	require.Equal(t, "no votes", ranked[0].Link)
+	require.Equal(t, float64(1), ranked[0].Score)
+
+	_, err = rankResources(resources, "best", now)
+	require.NotNil(t, err)
+}
+
+func TestRankingImpl(t *testing.T) {
+
+	lgr.Printf("INFO TestRankingImpl")
+	t.Log("INFO TestRankingImpl")
+	clock := TestClock{}
+	db, dbTearDown := OpenTestDB("RankingImpl")
+	defer dbTearDown()
+
+	users, topics, nodesAndEdges, err := CreateTestData(db, &clock, 3, 1, 1)
+	require.Nil(t, err)
+
+	adder, err := getUser(db, users[0])
+	require.Nil(t, err)
+
+	nodeId := nodesAndEdges[1].TargetId
+	older := "https://example.org/blog/ninjas"
+	newer := "https://example.org/blog/pirates"
+	for _, link := range []string{older, newer} {
+		_, err = updateNodeVideoEdit(db, &clock, openapi.NodeData{Id: nodeId, Topic: topics[0], Resources: []openapi.LinkData{{Link: link, Votes: 1}}}, adder)
+		require.Nil(t, err)
+		clock.TickOne(time.Hour)
+	}
+
+	vote := func(link string, up bool, userId string) {
+		votes := int32(-1)
+		if up {
+			votes = 1
+		}
+		_, err := updateNodeVideoVote(db, &clock, openapi.NodeData{Id: nodeId, Topic: topics[0], Resources: []openapi.LinkData{{Link: link, Votes: votes}}}, userId)
+		require.Nil(t, err)
+	}
+
+	// up and down are kept apart through every change of a vote
+	vote(older, true, users[0])
+	vote(older, true, users[1])
+	vote(older, false, users[2])
+	vote(newer, false, users[0])
+	vote(newer, true, users[0])
+	vote(older, false, users[1])
+	vote(older, true, users[1])
+	vote(older, false, users[2])
+
+	node, err := getNode(db, nodeId.Format(time.RFC3339Nano), topics[0])
+	require.Nil(t, err)
+	require.Equal(t, int32(2), node.Resources[0].Up)
+	require.Equal(t, int32(0), node.Resources[0].Down)
+	require.Equal(t, int32(2), node.Resources[0].Votes)
+	require.Equal(t, int32(1), node.Resources[1].Up)
+	require.Equal(t, int32(0), node.Resources[1].Down)
+
+	service := NewNodeAPIServiceImpl(db, &clock, DefaultPolicy(), DefaultModerationConfig(), NewMetadataService(db, &clock, nil, DefaultMetadataConfig()), DefaultWatchConfig())
+	ctx := context.WithValue(context.Background(), userInfoKey, token.User{ID: users[1]})
+
+	response, err := service.GetNode(ctx, nodeId.Format(time.RFC3339Nano), topics[0], "")
+	require.Nil(t, err)
+	ranked := response.Body.(openapi.NodeData).Resources
+	require.Equal(t, older, ranked[0].Link)
+	require.Greater(t, ranked[0].Score, ranked[1].Score)
+
+	response, err = service.GetNode(ctx, nodeId.Format(time.RFC3339Nano), topics[0], KeyRankingNew)
+	require.Nil(t, err)
+	require.Equal(t, newer, response.Body.(openapi.NodeData).Resources[0].Link)
+
+	response, err = service.GetNode(ctx, nodeId.Format(time.RFC3339Nano), topics[0], "best")
+	require.NotNil(t, err)
+	require.Equal(t, 400, response.Code)
+
+	// databases from before up and down count them from the votes of users
+	err = db.Update(func(tx *bolt.Tx) error {
+		nodesBucket, nodeData, err := nodeDataFinderTx(tx, topics[0], nodeId.Format(time.RFC3339Nano))
+		if err != nil {
+			return err
+		}
+
+		var stored openapi.NodeData
+		err = json.Unmarshal(nodeData, &stored)
+		if err != nil {
+			return err
+		}
+
+		for i := range stored.Resources {
+			stored.Resources[i].Up = 0
+			stored.Resources[i].Down = 0
+		}
+
+		marshal, err := json.Marshal(stored)
+		if err != nil {
+			return err
+		}
+
+		return nodesBucket.Put([]byte(nodeId.Format(time.RFC3339Nano)), marshal)
+	})
+	require.Nil(t, err)
+
+	err = db.Update(countVideoVotesTx)
+	require.Nil(t, err)
+
+	counted, err := getNode(db, nodeId.Format(time.RFC3339Nano), topics[0])
+	require.Nil(t, err)
+	require.Equal(t, node.Resources, counted.Resources)
+
+	// the same video on another node has votes of its own
+	root := nodesAndEdges[0].SourceId
+	_, err = updateNodeVideoEdit(db, &clock, openapi.NodeData{Id: root, Topic: topics[0], Resources: []openapi.LinkData{{Link: older, Votes: 1}}}, adder)
+	require.Nil(t, err)
+
+	votes, err := updateNodeVideoVote(db, &clock, openapi.NodeData{Id: root, Topic: topics[0], Resources: []openapi.LinkData{{Link: older, Votes: 1}}}, users[1])
+	require.Nil(t, err)
+	require.Equal(t, int32(1), votes)
+
+	shared, err := getNode(db, root.Format(time.RFC3339Nano), topics[0])
+	require.Nil(t, err)
+	require.Equal(t, int32(1), shared.Resources[0].Up)
+
+	voter, err := getUser(db, users[1])
+	require.Nil(t, err)
+	require.Equal(t, []string{older}, voter.VideoUp)
+	require.Equal(t, 2, len(voter.VideoVotes))
+
+	// taking it back there leaves the vote on the first node
+	votes, err = updateNodeVideoVote(db, &clock, openapi.NodeData{Id: root, Topic: topics[0], Resources: []openapi.LinkData{{Link: older, Votes: 1}}}, users[1])
+	require.Nil(t, err)
+	require.Equal(t, int32(0), votes)
+
+	shared, err = getNode(db, root.Format(time.RFC3339Nano), topics[0])
+	require.Nil(t, err)
+	require.Equal(t, int32(0), shared.Resources[0].Up)
+	require.Equal(t, int32(0), shared.Resources[0].Down)
+
+	counted, err = getNode(db, nodeId.Format(time.RFC3339Nano), topics[0])
+	require.Nil(t, err)
+	require.Equal(t, node.Resources, counted.Resources)
+
+	voter, err = getUser(db, users[1])
+	require.Nil(t, err)
+	require.Equal(t, []string{older}, voter.VideoUp)
+	require.Equal(t, 1, len(voter.VideoVotes))
+
+	// votes from before they were kept by node go to every node with the video
+	err = db.Update(func(tx *bolt.Tx) error {
+		usersBucket, user, err := getUserAndBucketRx(tx, users[1])
+		if err != nil {
+			return err
+		}
+
+		user.VideoVotes = nil
+		marshal, err := json.Marshal(user)
+		if err != nil {
+			return err
+		}
+
+		return usersBucket.Put([]byte(users[1]), marshal)
+	})
+	require.Nil(t, err)
+
+	err = db.Update(videoVotesByNodeTx)
+	require.Nil(t, err)
+
+	shared, err = getNode(db, root.Format(time.RFC3339Nano), topics[0])
+	require.Nil(t, err)
+	require.Equal(t, int32(1), shared.Resources[0].Up)
+
+	counted, err = getNode(db, nodeId.Format(time.RFC3339Nano), topics[0])
+	require.Nil(t, err)
+	require.Equal(t, node.Resources, counted.Resources)
+}
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-pkgz/auth/token"
+	"github.com/gorilla/mux"
+	bolt "go.etcd.io/bbolt"
+)
+
+// the class of every route that is rate limited, the names are the ones the generated router gives its routes
+var rateLimitClasses = map[string]string{
+	"UpdateNodeBattleVote": KeyRateVote,
+	"UpdateNodeVideoVote":  KeyRateVote,
+	"UpdateNodeFreshVote":  KeyRateVote,
+	"UpdateNodeTitle":      KeyRateEdit,
+	"UpdateNodeVideoEdit":  KeyRateEdit,
+	"DeleteNode":           KeyRateEdit,
+	"AddEdge":              KeyRateEdit,
+	"DeleteEdge":           KeyRateEdit,
+	"AddNode":              KeyRateCreate,
+	"AddTopic":             KeyRateCreate,
+	"AddSuggestion":        KeyRateCreate,
+	"ReportNode":           KeyRateCreate,
+	"ImportTopicPlaylist":  KeyRateCreate,
+}
+
+// buckets that are full again are dropped once there are more than this
+const maxRateLimitBuckets = 10000
+
+type tokenBucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// RateLimiter keeps a token bucket for every user and ip in each class, they are only kept in memory
+type RateLimiter struct {
+	clock   Clock
+	config  RateLimitConfig
+	mu      sync.Mutex
+	buckets map[string]*tokenBucket
+}
+
+func NewRateLimiter(clock Clock, config RateLimitConfig) *RateLimiter {
+	return &RateLimiter{
+		clock:   clock,
+		config:  config,
+		buckets: make(map[string]*tokenBucket),
+	}
+}
+
+// take uses a token from the buckets of the user and the ip, it returns how long to wait when one of them is empty
+func (l *RateLimiter) take(class, userId, ip string) (retryAfter time.Duration) {
+	limit, ok := l.config.Classes[class]
+	if !ok || limit.Every <= 0 {
+		return 0
+	}
+
+	type key struct {
+		name string
+		size int
+	}
+
+	keys := make([]key, 0, 2)
+	if limit.User > 0 && userId != "" {
+		keys = append(keys, key{class + "|user|" + userId, limit.User})
+	}
+	if limit.IP > 0 && ip != "" {
+		keys = append(keys, key{class + "|ip|" + ip, limit.IP})
+	}
+
+	now := l.clock.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if len(l.buckets) > maxRateLimitBuckets {
+		l.prune(now)
+	}
+
+	buckets := make([]*tokenBucket, 0, len(keys))
+	for _, k := range keys {
+		bucket, ok := l.buckets[k.name]
+		if !ok {
+			bucket = &tokenBucket{tokens: float64(k.size), updated: now}
+			l.buckets[k.name] = bucket
+		}
+
+		if now.After(bucket.updated) {
+			bucket.tokens = math.Min(float64(k.size), bucket.tokens+float64(now.Sub(bucket.updated))/float64(limit.Every))
+			bucket.updated = now
+		}
+
+		if bucket.tokens < 1 {
+			wait := time.Duration((1 - bucket.tokens) * float64(limit.Every))
+			if wait > retryAfter {
+				retryAfter = wait
+			}
+		}
+
+		buckets = append(buckets, bucket)
+	}
+
+	// nothing is used up when the request is turned down
+	if retryAfter > 0 {
+		return
+	}
+
+	for _, bucket := range buckets {
+		bucket.tokens--
+	}
+
+	return 0
+}
+
+// drops the buckets that would be full by now, they start full when they are made again
+func (l *RateLimiter) prune(now time.Time) {
+	for name, bucket := range l.buckets {
+		class := strings.SplitN(name, "|", 2)[0]
+		limit := l.config.Classes[class]
+
+		size := limit.User
+		if strings.Contains(name, "|ip|") {
+			size = limit.IP
+		}
+
+		if limit.Every <= 0 || bucket.tokens+float64(now.Sub(bucket.updated))/float64(limit.Every) >= float64(size) {
+			delete(l.buckets, name)
+		}
+	}
+}
+
+// the address of the client, the proxy adds it last to X-Forwarded-For.
+// Anything before that came from the client so it can't be trusted
+func (l *RateLimiter) clientIP(r *http.Request) string {
+	if l.config.TrustProxy {
+		if forwarded := strings.Join(r.Header.Values("X-Forwarded-For"), ","); forwarded != "" {
+			hops := strings.Split(forwarded, ",")
+			if ip := strings.TrimSpace(hops[len(hops)-1]); ip != "" {
+				return ip
+			}
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
+
+// buildRateLimitMiddleware turns down requests over the limit of their class with 429, admins are never limited
+//
+// it has to come after the auth middleware so the user is known
+func buildRateLimitMiddleware(db *bolt.DB, limiter *RateLimiter) func(http.Handler) http.Handler {
+	return func(handler http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			route := mux.CurrentRoute(r)
+			if route == nil {
+				handler.ServeHTTP(w, r)
+				return
+			}
+
+			class, ok := rateLimitClasses[route.GetName()]
+			if !ok {
+				handler.ServeHTTP(w, r)
+				return
+			}
+
+			user, _ := r.Context().Value(userInfoKey).(token.User)
+			if user.ID != "" {
+				userDetails, err := getUser(db, user.ID)
+				if err == nil && userDetails.Role == KeyAdmin {
+					handler.ServeHTTP(w, r)
+					return
+				}
+			}
+
+			retryAfter := limiter.take(class, user.ID, limiter.clientIP(r))
+			if retryAfter > 0 {
+				seconds := int(math.Ceil(retryAfter.Seconds()))
+				w.Header().Set("Retry-After", strconv.Itoa(seconds))
+				http.Error(w, fmt.Sprintf("too many requests, try again in %d seconds", seconds), http.StatusTooManyRequests)
+				return
+			}
+
+			handler.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	openapi "github.com/SpyLime/flowBackend/go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiter(t *testing.T) {
+	clock := TestClock{}
+	limiter := NewRateLimiter(&clock, RateLimitConfig{
+		Classes: map[string]RateLimit{
+			KeyRateEdit: {Every: time.Minute, User: 2, IP: 3},
+		},
+	})
+
+	require.Zero(t, limiter.take(KeyRateEdit, "a", "10.0.0.1"))
+	require.Zero(t, limiter.take(KeyRateEdit, "a", "10.0.0.1"))
+	require.Equal(t, time.Minute, limiter.take(KeyRateEdit, "a", "10.0.0.1"))
+
+	// other classes have their own buckets
+	require.Zero(t, limiter.take(KeyRateVote, "a", "10.0.0.1"))
+
+	// the ip is shared by everyone behind it
+	require.Zero(t, limiter.take(KeyRateEdit, "b", "10.0.0.1"))
+	require.Equal(t, time.Minute, limiter.take(KeyRateEdit, "b", "10.0.0.1"))
+	require.Zero(t, limiter.take(KeyRateEdit, "b", "10.0.0.2"))
+
+	// a turned down request doesn't use a token so half the interval is left
+	clock.TickOne(30 * time.Second)
+	require.Equal(t, 30*time.Second, limiter.take(KeyRateEdit, "a", "10.0.0.3"))
+
+	clock.TickOne(30 * time.Second)
+	require.Zero(t, limiter.take(KeyRateEdit, "a", "10.0.0.3"))
+	require.Equal(t, time.Minute, limiter.take(KeyRateEdit, "a", "10.0.0.3"))
+}
+
+func TestClientIP(t *testing.T) {
+	clock := TestClock{}
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.RemoteAddr = "10.0.0.9:1234"
+	r.Header.Set("X-Forwarded-For", "1.2.3.4, 203.0.113.7")
+
+	// without a proxy the header is the client's own
+	require.Equal(t, "10.0.0.9", NewRateLimiter(&clock, RateLimitConfig{}).clientIP(r))
+
+	// a client can put anything in front of what the proxy adds
+	limiter := NewRateLimiter(&clock, RateLimitConfig{TrustProxy: true})
+	require.Equal(t, "203.0.113.7", limiter.clientIP(r))
+
+	r.Header.Add("X-Forwarded-For", "198.51.100.2")
+	require.Equal(t, "198.51.100.2", limiter.clientIP(r))
+}
+
+func TestRateLimit(t *testing.T) {
+	clock := TestClock{}
+	db, tearDown := FullStartTestServerClock("RateLimit", 8088, "", &clock)
+	defer tearDown()
+
+	users, topics, nodesAndEdges, err := CreateTestData(db, &clock, 2, 1, 1)
+	require.Nil(t, err)
+
+	voter, admin := users[0], users[1]
+	err = UpdateUserRoleAndReputation(db, voter, false, 0)
+	require.Nil(t, err)
+
+	marshal, err := json.Marshal(openapi.NodeData{Id: nodesAndEdges[0].SourceId, Topic: topics[0], BattleTested: 1})
+	require.Nil(t, err)
+
+	client := &http.Client{}
+	limit := DefaultRateLimitConfig().Classes[KeyRateVote]
+
+	vote := func() *http.Response {
+		req, _ := http.NewRequest(http.MethodPut, "http://127.0.0.1:8088/api/v1/node/battleVote", bytes.NewBuffer(marshal))
+
+		resp, err := client.Do(req)
+		require.Nil(t, err)
+		resp.Body.Close()
+
+		return resp
+	}
+
+	SetTestLoginUser(voter)
+
+	for i := 0; i < limit.User; i++ {
+		require.NotEqual(t, http.StatusTooManyRequests, vote().StatusCode)
+	}
+
+	resp := vote()
+	require.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	require.Equal(t, "2", resp.Header.Get("Retry-After"))
+
+	// admins are never limited
+	SetTestLoginUser(admin)
+	require.NotEqual(t, http.StatusTooManyRequests, vote().StatusCode)
+
+	SetTestLoginUser(voter)
+	clock.TickOne(limit.Every)
+	require.NotEqual(t, http.StatusTooManyRequests, vote().StatusCode)
+	require.Equal(t, http.StatusTooManyRequests, vote().StatusCode)
+}
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	openapi "github.com/SpyLime/flowBackend/go"
+	bolt "go.etcd.io/bbolt"
+)
+
+// the order the totals are listed in
+var reputationSources = []string{KeyVoteBattle, KeyVoteFresh, KeyVoteVideo, KeySourceSuggestion, KeySourceAdmin, KeySourceVoid}
+
+// every user has their own bucket of changes so their history is read without going through everyone's,
+// the change is counted for the leaderboards as well, the event is returned as it was stored
+func addReputationEventTx(tx *bolt.Tx, clock Clock, event openapi.ReputationEvent) (stored openapi.ReputationEvent, err error) {
+	historyBucket, err := tx.CreateBucketIfNotExists([]byte(KeyReputationHistory))
+	if err != nil {
+		return
+	}
+
+	userBucket, err := historyBucket.CreateBucketIfNotExists([]byte(event.UserId))
+	if err != nil {
+		return
+	}
+
+	// the sequence keeps the changes in the order they happened
+	sequence, err := userBucket.NextSequence()
+	if err != nil {
+		return
+	}
+
+	event.Id = strconv.FormatUint(sequence, 10)
+	event.CreatedAt = clock.Now()
+
+	marshal, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+
+	err = userBucket.Put([]byte(fmt.Sprintf("%020d", sequence)), marshal)
+	if err != nil {
+		return
+	}
+
+	err = countReputationEventTx(tx, event)
+	if err != nil {
+		return
+	}
+
+	err = countAchievementEventTx(tx, event)
+	if err != nil {
+		return
+	}
+
+	return event, nil
+}
+
+// getReputationHistory returns why the reputation of the user changed, newest first, voters are left out unless showVoters
+func getReputationHistory(db *bolt.DB, userId string, showVoters bool) (response openapi.ReputationHistory, err error) {
+	err = db.View(func(tx *bolt.Tx) error {
+		response, err = getReputationHistoryRx(tx, userId, showVoters)
+		return err
+	})
+
+	return
+}
+
+func getReputationHistoryRx(tx *bolt.Tx, userId string, showVoters bool) (response openapi.ReputationHistory, err error) {
+	user, err := getUserRx(tx, userId)
+	if err != nil {
+		return
+	}
+
+	response.Reputation = user.Reputation
+	response.Events = make([]openapi.ReputationEvent, 0)
+	response.Totals = make([]openapi.ReputationTotal, 0)
+
+	historyBucket := tx.Bucket([]byte(KeyReputationHistory))
+	if historyBucket == nil {
+		return
+	}
+
+	userBucket := historyBucket.Bucket([]byte(userId))
+	if userBucket == nil {
+		return
+	}
+
+	totals := make(map[string]openapi.ReputationTotal)
+
+	c := userBucket.Cursor()
+	for k, v := c.Last(); k != nil; k, v = c.Prev() {
+		var event openapi.ReputationEvent
+		err = json.Unmarshal(v, &event)
+		if err != nil {
+			return
+		}
+
+		if !showVoters {
+			event.VoterId = ""
+		}
+
+		total := totals[event.Source]
+		total.Source = event.Source
+		total.Delta += event.Delta
+		total.Count++
+		totals[event.Source] = total
+
+		response.Events = append(response.Events, event)
+	}
+
+	for _, source := range reputationSources {
+		if total, ok := totals[source]; ok {
+			response.Totals = append(response.Totals, total)
+		}
+	}
+
+	return
+}
+
+// adjustReputation lets an admin add to or take from the reputation of a user, it shows in their history and the audit
+func adjustReputation(db *bolt.DB, clock Clock, userId, actorId string, request openapi.ReputationAdjustment) (response openapi.ReputationEvent, err error) {
+	err = db.Update(func(tx *bolt.Tx) error {
+		response, err = adjustReputationTx(tx, clock, userId, actorId, request)
+		return err
+	})
+
+	return
+}
+
+func adjustReputationTx(tx *bolt.Tx, clock Clock, userId, actorId string, request openapi.ReputationAdjustment) (response openapi.ReputationEvent, err error) {
+	if request.Delta == 0 {
+		return response, fmt.Errorf("an adjustment needs a change")
+	}
+
+	if request.Reason == "" {
+		return response, fmt.Errorf("an adjustment needs a reason")
+	}
+
+	usersBucket, user, err := getUserAndBucketRx(tx, userId)
+	if err != nil {
+		return
+	}
+
+	user.Reputation += request.Delta
+
+	marshal, err := json.Marshal(user)
+	if err != nil {
+		return
+	}
+
+	err = usersBucket.Put([]byte(userId), marshal)
+	if err != nil {
+		return
+	}
+
+	response, err = addReputationEventTx(tx, clock, openapi.ReputationEvent{
+		UserId:  userId,
+		Source:  KeySourceAdmin,
+		VoterId: actorId,
+		Delta:   request.Delta,
+		Reason:  request.Reason,
+	})
+	if err != nil {
+		return
+	}
+
+	err = addAuditEntryTx(tx, clock, openapi.AuditEntry{
+		Action:  KeyAuditAdjust,
+		UserId:  userId,
+		ActorId: actorId,
+		Reason:  fmt.Sprintf("%s (%+d reputation)", request.Reason, request.Delta),
+	})
+	if err != nil {
+		return
+	}
+
+	err = refreshAchievementsTx(tx, clock, userId, false)
+
+	return
+}
+
+// the history goes with the user
+func deleteReputationHistoryTx(tx *bolt.Tx, userId string) (err error) {
+	historyBucket := tx.Bucket([]byte(KeyReputationHistory))
+	if historyBucket == nil || historyBucket.Bucket([]byte(userId)) == nil {
+		return
+	}
+
+	return historyBucket.DeleteBucket([]byte(userId))
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"testing"
+
+	openapi "github.com/SpyLime/flowBackend/go"
+	"github.com/go-pkgz/lgr"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReputationHistoryImpl(t *testing.T) {
+
+	lgr.Printf("INFO TestReputationHistoryImpl")
+	t.Log("INFO TestReputationHistoryImpl")
+	clock := TestClock{}
+	db, dbTearDown := OpenTestDB("ReputationHistoryImpl")
+	defer dbTearDown()
+
+	users, topics, nodesAndEdges, err := CreateTestData(db, &clock, 2, 1, 1)
+	require.Nil(t, err)
+
+	info, err := getTopicInfo(db, topics[0])
+	require.Nil(t, err)
+
+	creator, voter := users[0], users[1]
+	if voter == info.CreatedBy {
+		creator, voter = voter, creator
+	}
+
+	history, err := getReputationHistory(db, creator, true)
+	require.Nil(t, err)
+	require.Equal(t, 0, len(history.Events))
+
+	// the root node belongs to the creator of the topic
+	clock.Tick()
+	_, err = updateNodeBattleVote(db, &clock, openapi.NodeData{Id: nodesAndEdges[0].SourceId, Topic: topics[0], BattleTested: 1}, voter)
+	require.Nil(t, err)
+
+	clock.Tick()
+	_, err = updateNodeFreshVote(db, &clock, openapi.NodeData{Id: nodesAndEdges[0].SourceId, Topic: topics[0], Fresh: -1}, voter)
+	require.Nil(t, err)
+
+	_, err = adjustReputation(db, &clock, creator, voter, openapi.ReputationAdjustment{Delta: 5})
+	require.NotNil(t, err)
+
+	_, err = adjustReputation(db, &clock, creator, voter, openapi.ReputationAdjustment{Reason: "nothing"})
+	require.NotNil(t, err)
+
+	clock.Tick()
+	event, err := adjustReputation(db, &clock, creator, voter, openapi.ReputationAdjustment{Delta: 5, Reason: "great maps"})
+	require.Nil(t, err)
+	require.Equal(t, KeySourceAdmin, event.Source)
+
+	history, err = getReputationHistory(db, creator, true)
+	require.Nil(t, err)
+	require.Equal(t, 3, len(history.Events))
+
+	// the response is the event as it was stored
+	require.Equal(t, history.Events[0], event)
+	require.NotEmpty(t, event.Id)
+
+	// newest first
+	require.Equal(t, KeySourceAdmin, history.Events[0].Source)
+	require.Equal(t, KeyVoteFresh, history.Events[1].Source)
+	require.Equal(t, int32(-1), history.Events[1].Delta)
+	require.Equal(t, KeyVoteBattle, history.Events[2].Source)
+	require.Equal(t, voter, history.Events[2].VoterId)
+	require.Equal(t, nodesAndEdges[0].SourceId, history.Events[2].NodeId)
+
+	require.Equal(t, []openapi.ReputationTotal{
+		{Source: KeyVoteBattle, Delta: 1, Count: 1},
+		{Source: KeyVoteFresh, Delta: -1, Count: 1},
+		{Source: KeySourceAdmin, Delta: 5, Count: 1},
+	}, history.Totals)
+
+	// only admins see who voted
+	history, err = getReputationHistory(db, creator, false)
+	require.Nil(t, err)
+	for _, event := range history.Events {
+		require.Empty(t, event.VoterId)
+	}
+
+	audit, err := getAuditEntries(db, creator)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(audit))
+	require.Equal(t, KeyAuditAdjust, audit[0].Action)
+
+	err = deleteUser(db, creator)
+	require.Nil(t, err)
+
+	_, err = getReputationHistory(db, creator, true)
+	require.NotNil(t, err)
+}
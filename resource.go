@@ -0,0 +1,185 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+
+	openapi "github.com/SpyLime/flowBackend/go"
+)
+
+var resourceTypes = map[string]bool{
+	KeyResourceVideo:    true,
+	KeyResourceArticle:  true,
+	KeyResourcePdf:      true,
+	KeyResourceExercise: true,
+	KeyResourceBook:     true,
+}
+
+var vimeoHosts = map[string]bool{
+	"vimeo.com":        true,
+	"www.vimeo.com":    true,
+	"player.vimeo.com": true,
+}
+
+var vimeoIdPattern = regexp.MustCompile(`^[0-9]+$`)
+
+// PeerTube runs on many hosts so only the instances in the config are known, its short ids and uuids are told apart by the path
+var peerTubeHosts = hostSet(DefaultPeerTubeConfig().Hosts)
+
+var peerTubeIdPattern = regexp.MustCompile(`^[A-Za-z0-9-]{8,36}$`)
+
+// setPeerTubeHosts replaces the known PeerTube instances, it is called once before the server starts
+func setPeerTubeHosts(hosts []string) {
+	peerTubeHosts = hostSet(hosts)
+}
+
+func hostSet(hosts []string) map[string]bool {
+	set := make(map[string]bool, len(hosts))
+	for _, host := range hosts {
+		set[strings.ToLower(strings.TrimSpace(host))] = true
+	}
+
+	return set
+}
+
+// parseResourceURL reads a link the way people paste it, without a scheme it is taken as https
+func parseResourceURL(link string) (parsed *url.URL, err error) {
+	link = strings.TrimSpace(link)
+	if !strings.Contains(link, "://") {
+		link = "https://" + link
+	}
+
+	parsed, err = url.Parse(link)
+	if err != nil {
+		return nil, fmt.Errorf("invalid link %s", link)
+	}
+
+	return
+}
+
+// resourceData stores a resource with its link in canonical form and what can be read from the link,
+// the type is taken from the link when it isn't given
+func resourceData(resource openapi.LinkData) (openapi.LinkData, error) {
+	if resource.Type != "" && !resourceTypes[resource.Type] {
+		return resource, fmt.Errorf("invalid resource type %s", resource.Type)
+	}
+
+	if resource.Duration < 0 {
+		return resource, fmt.Errorf("a resource can't have a negative duration")
+	}
+
+	resource.Title = strings.TrimSpace(resource.Title)
+
+	parsed, err := parseResourceURL(resource.Link)
+	if err != nil {
+		resource.Link = strings.TrimSpace(resource.Link)
+		return resource, nil
+	}
+
+	host := strings.ToLower(parsed.Hostname())
+	segments := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+
+	switch {
+	case isYouTubeHost(host):
+		resource, err = videoLinkData(resource)
+		if err != nil {
+			return resource, err
+		}
+		resource.Provider = KeyProviderYouTube
+
+	case resource.Start != 0 || resource.End != 0:
+		return resource, fmt.Errorf("only YouTube videos can have a segment")
+
+	case vimeoHosts[host] && vimeoId(segments) != "":
+		resource.VideoId = vimeoId(segments)
+		resource.Provider = KeyProviderVimeo
+		resource.Link = "https://vimeo.com/" + resource.VideoId
+		resource.EmbedUrl = "https://player.vimeo.com/video/" + resource.VideoId
+
+	case peerTubeHosts[host] && peerTubeId(segments) != "":
+		resource.VideoId = peerTubeId(segments)
+		resource.Provider = KeyProviderPeerTube
+		resource.Link = "https://" + host + "/w/" + resource.VideoId
+		resource.EmbedUrl = "https://" + host + "/videos/embed/" + resource.VideoId
+
+	default:
+		resource.Link = strings.TrimSpace(resource.Link)
+	}
+
+	if resource.Type == "" {
+		resource.Type = resourceType(resource, parsed)
+	}
+
+	return resource, nil
+}
+
+// vimeo.com/ID, vimeo.com/channels/name/ID and player.vimeo.com/video/ID
+func vimeoId(segments []string) string {
+	for _, segment := range segments {
+		if vimeoIdPattern.MatchString(segment) {
+			return segment
+		}
+	}
+
+	return ""
+}
+
+// /w/ID, /videos/watch/ID and /videos/embed/ID on a PeerTube instance
+func peerTubeId(segments []string) (id string) {
+	switch {
+	case len(segments) == 2 && segments[0] == "w":
+		id = segments[1]
+	case len(segments) == 3 && segments[0] == "videos" && (segments[1] == "watch" || segments[1] == "embed"):
+		id = segments[2]
+	}
+
+	if !peerTubeIdPattern.MatchString(id) {
+		return ""
+	}
+
+	return
+}
+
+// videos are known by their provider and pdfs by their name, anything else is read as an article
+func resourceType(resource openapi.LinkData, parsed *url.URL) string {
+	if resource.Provider != "" {
+		return KeyResourceVideo
+	}
+
+	if strings.EqualFold(path.Ext(parsed.Path), ".pdf") {
+		return KeyResourcePdf
+	}
+
+	return KeyResourceArticle
+}
+
+// canonicalResourceLink returns the link a resource is stored with, a link that isn't a valid resource is only trimmed
+func canonicalResourceLink(link string) string {
+	resource, err := resourceData(openapi.LinkData{Link: link})
+	if err != nil {
+		return strings.TrimSpace(link)
+	}
+
+	return resource.Link
+}
+
+// areSameResource returns true if two links are the same resource, however they are written
+func areSameResource(link1, link2 string) bool {
+	return canonicalResourceLink(link1) == canonicalResourceLink(link2)
+}
+
+// youTubeResources are what older clients know as the YouTube links of a node
+func youTubeResources(resources []openapi.LinkData) []openapi.LinkData {
+	var videos []openapi.LinkData
+	for _, resource := range resources {
+		parsed, err := parseResourceURL(resource.Link)
+		if err == nil && isYouTubeHost(strings.ToLower(parsed.Hostname())) {
+			videos = append(videos, resource)
+		}
+	}
+
+	return videos
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	openapi "github.com/SpyLime/flowBackend/go"
+	"github.com/go-pkgz/lgr"
+	"github.com/stretchr/testify/require"
+	bolt "go.etcd.io/bbolt"
+)
+
+func TestResourceData(t *testing.T) {
+	tests := []struct {
+		link     openapi.LinkData
+		resource openapi.LinkData
+	}{
+		{openapi.LinkData{Link: "https://vimeo.com/channels/staffpicks/76979871"}, openapi.LinkData{Link: "https://vimeo.com/76979871", Type: KeyResourceVideo, Provider: KeyProviderVimeo, VideoId: "76979871", EmbedUrl: "https://player.vimeo.com/video/76979871"}},
+		{openapi.LinkData{Link: "player.vimeo.com/video/76979871?h=abc"}, openapi.LinkData{Link: "https://vimeo.com/76979871", Type: KeyResourceVideo, Provider: KeyProviderVimeo, VideoId: "76979871", EmbedUrl: "https://player.vimeo.com/video/76979871"}},
+		{openapi.LinkData{Link: "https://framatube.org/videos/watch/9c9de5e8-0a1e-484a-b099-e80766180a6d"}, openapi.LinkData{Link: "https://framatube.org/w/9c9de5e8-0a1e-484a-b099-e80766180a6d", Type: KeyResourceVideo, Provider: KeyProviderPeerTube, VideoId: "9c9de5e8-0a1e-484a-b099-e80766180a6d", EmbedUrl: "https://framatube.org/videos/embed/9c9de5e8-0a1e-484a-b099-e80766180a6d"}},
+		{openapi.LinkData{Link: "https://framatube.org/w/kkGMgK9ZtnKfYAgnEtQxbv", Title: " Lecture 3 "}, openapi.LinkData{Link: "https://framatube.org/w/kkGMgK9ZtnKfYAgnEtQxbv", Type: KeyResourceVideo, Title: "Lecture 3", Provider: KeyProviderPeerTube, VideoId: "kkGMgK9ZtnKfYAgnEtQxbv", EmbedUrl: "https://framatube.org/videos/embed/kkGMgK9ZtnKfYAgnEtQxbv"}},
+		{openapi.LinkData{Link: "https://tube.example.org/w/kkGMgK9ZtnKfYAgnEtQxbv"}, openapi.LinkData{Link: "https://tube.example.org/w/kkGMgK9ZtnKfYAgnEtQxbv", Type: KeyResourceArticle}},
+		{openapi.LinkData{Link: " https://example.org/notes/Chapter1.PDF "}, openapi.LinkData{Link: "https://example.org/notes/Chapter1.PDF", Type: KeyResourcePdf}},
+		{openapi.LinkData{Link: "https://example.org/blog/ninjas", Duration: 600}, openapi.LinkData{Link: "https://example.org/blog/ninjas", Type: KeyResourceArticle, Duration: 600}},
+		{openapi.LinkData{Link: "https://example.org/quiz", Type: KeyResourceExercise}, openapi.LinkData{Link: "https://example.org/quiz", Type: KeyResourceExercise}},
+		{openapi.LinkData{Link: "https://youtu.be/dQw4w9WgXcQ", Type: KeyResourceBook}, openapi.LinkData{Link: "https://www.youtube.com/watch?v=dQw4w9WgXcQ", Type: KeyResourceBook, Provider: KeyProviderYouTube, VideoId: "dQw4w9WgXcQ", EmbedUrl: "https://www.youtube.com/embed/dQw4w9WgXcQ"}},
+		{openapi.LinkData{Link: "www.youtube.com"}, openapi.LinkData{Link: "www.youtube.com", Type: KeyResourceVideo, Provider: KeyProviderYouTube}},
+	}
+
+	for _, test := range tests {
+		resource, err := resourceData(test.link)
+		require.Nil(t, err, test.link.Link)
+		require.Equal(t, test.resource, resource, test.link.Link)
+	}
+
+	for _, link := range []openapi.LinkData{
+		{Link: "https://example.org/blog/ninjas", Type: "podcast"},
+		{Link: "https://example.org/blog/ninjas", Duration: -1},
+		{Link: "https://vimeo.com/76979871", Start: 30},
+		{Link: "https://youtu.be/dQw4w9WgXcQ", Start: 60, End: 30},
+	} {
+		_, err := resourceData(link)
+		require.NotNil(t, err, link.Link)
+	}
+
+	require.True(t, areSameResource("vimeo.com/76979871", "https://player.vimeo.com/video/76979871"))
+	require.False(t, areSameResource("vimeo.com/76979871", "https://youtu.be/dQw4w9WgXcQ"))
+}
+
+func TestResourcesImpl(t *testing.T) {
+
+	lgr.Printf("INFO TestResourcesImpl")
+	t.Log("INFO TestResourcesImpl")
+	clock := TestClock{}
+	db, dbTearDown := OpenTestDB("ResourcesImpl")
+	defer dbTearDown()
+
+	users, topics, nodesAndEdges, err := CreateTestData(db, &clock, 2, 1, 1)
+	require.Nil(t, err)
+
+	adder, err := getUser(db, users[0])
+	require.Nil(t, err)
+
+	nodeId := nodesAndEdges[1].TargetId
+	article := openapi.LinkData{Link: "https://example.org/blog/ninjas", Title: "How ninjas attack", Votes: 1}
+
+	// any resource goes through the video endpoints, old clients still send YouTube links
+	_, err = updateNodeVideoEdit(db, &clock, openapi.NodeData{Id: nodeId, Topic: topics[0], Resources: []openapi.LinkData{article}}, adder)
+	require.Nil(t, err)
+
+	_, err = updateNodeVideoEdit(db, &clock, openapi.NodeData{Id: nodeId, Topic: topics[0], Resources: []openapi.LinkData{{Link: "https://vimeo.com/76979871", Duration: 95, Votes: 1}}}, adder)
+	require.Nil(t, err)
+
+	_, err = updateNodeVideoEdit(db, &clock, openapi.NodeData{Id: nodeId, Topic: topics[0], YoutubeLinks: []openapi.LinkData{{Link: "https://youtu.be/dQw4w9WgXcQ", Votes: 1}}}, adder)
+	require.Nil(t, err)
+
+	_, err = updateNodeVideoEdit(db, &clock, openapi.NodeData{Id: nodeId, Topic: topics[0], Resources: []openapi.LinkData{{Link: "player.vimeo.com/video/76979871", Votes: 1}}}, adder)
+	require.NotNil(t, err)
+
+	_, err = updateNodeVideoEdit(db, &clock, openapi.NodeData{Id: nodeId, Topic: topics[0]}, adder)
+	require.NotNil(t, err)
+
+	node, err := getNode(db, nodeId.Format(time.RFC3339Nano), topics[0])
+	require.Nil(t, err)
+	require.Equal(t, 3, len(node.Resources))
+	require.Equal(t, KeyResourceArticle, node.Resources[0].Type)
+	require.Equal(t, "How ninjas attack", node.Resources[0].Title)
+	require.Equal(t, users[0], node.Resources[0].AddedBy.Id)
+	require.Equal(t, int32(95), node.Resources[1].Duration)
+	require.Equal(t, KeyProviderVimeo, node.Resources[1].Provider)
+	require.Equal(t, 1, len(node.YoutubeLinks))
+	require.Equal(t, "https://www.youtube.com/watch?v=dQw4w9WgXcQ", node.YoutubeLinks[0].Link)
+
+	// resources are voted on and attributed like videos
+	votes, err := updateNodeVideoVote(db, &clock, openapi.NodeData{Id: nodeId, Topic: topics[0], Resources: []openapi.LinkData{{Link: article.Link, Votes: 1}}}, users[1])
+	require.Nil(t, err)
+	require.Equal(t, int32(1), votes)
+
+	voter, err := getUser(db, users[1])
+	require.Nil(t, err)
+	require.Equal(t, []string{article.Link}, voter.VideoUp)
+
+	adder, err = getUser(db, users[0])
+	require.Nil(t, err)
+	require.Equal(t, 3, len(adder.Linked))
+	require.Equal(t, KeyResourceArticle, adder.Linked[0].Type)
+
+	history, err := getReputationHistory(db, users[0], false)
+	require.Nil(t, err)
+	links := make([]string, 0)
+	for _, event := range history.Events {
+		links = append(links, event.Link)
+	}
+	require.Contains(t, links, article.Link)
+
+	// removing a resource takes it off the voters too
+	_, err = updateNodeVideoEdit(db, &clock, openapi.NodeData{Id: nodeId, Topic: topics[0], Resources: []openapi.LinkData{{Link: article.Link, Votes: -1}}}, adder)
+	require.Nil(t, err)
+
+	voter, err = getUser(db, users[1])
+	require.Nil(t, err)
+	require.Empty(t, voter.VideoUp)
+
+	// nodes from before resources have their YouTube links moved over
+	err = db.Update(func(tx *bolt.Tx) error {
+		nodesBucket, nodeData, err := nodeDataFinderTx(tx, topics[0], nodeId.Format(time.RFC3339Nano))
+		if err != nil {
+			return err
+		}
+
+		var stored openapi.NodeData
+		err = json.Unmarshal(nodeData, &stored)
+		if err != nil {
+			return err
+		}
+
+		stored.YoutubeLinks = []openapi.LinkData{{Link: "youtube.com/shorts/dQw4w9WgXcQ", Votes: 4}, {Link: "https://youtu.be/aaaaaaaaaaa", Votes: 2}}
+
+		marshal, err := json.Marshal(stored)
+		if err != nil {
+			return err
+		}
+
+		return nodesBucket.Put([]byte(nodeId.Format(time.RFC3339Nano)), marshal)
+	})
+	require.Nil(t, err)
+
+	err = runMigrations(db, &clock)
+	require.Nil(t, err)
+
+	node, err = getNode(db, nodeId.Format(time.RFC3339Nano), topics[0])
+	require.Nil(t, err)
+	require.Equal(t, 3, len(node.Resources))
+	require.Equal(t, int32(4), node.Resources[1].Votes)
+	require.Equal(t, KeyResourceVideo, node.Resources[2].Type)
+	require.Equal(t, "aaaaaaaaaaa", node.Resources[2].VideoId)
+	require.Equal(t, 2, len(node.YoutubeLinks))
+}
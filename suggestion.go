@@ -0,0 +1,158 @@
+package main
+
+import (
+	"context"
+	"errors"
+
+	openapi "github.com/SpyLime/flowBackend/go"
+	"github.com/go-pkgz/auth/token"
+	bolt "go.etcd.io/bbolt"
+)
+
+// SuggestionAPIServiceImpl is a service that implements the logic for the SuggestionAPIServicer
+// This service should implement the business logic for every endpoint for the SuggestionAPI API.
+// Include any external packages or services that will be required by this service.
+type SuggestionAPIServiceImpl struct {
+	db     *bolt.DB
+	clock  Clock
+	policy Policy
+}
+
+// NewSuggestionAPIService creates a default api service
+func NewSuggestionAPIServiceImpl(db *bolt.DB, clock Clock, policy Policy) openapi.SuggestionAPIServicer {
+	return &SuggestionAPIServiceImpl{
+		db:     db,
+		clock:  clock,
+		policy: policy,
+	}
+}
+
+// GetSuggestions - get the suggestions of a topic, oldest first
+func (s *SuggestionAPIServiceImpl) GetSuggestions(ctx context.Context, topicId string, status string) (openapi.ImplResponse, error) {
+	user, ok := ctx.Value(userInfoKey).(token.User)
+	if !ok {
+		return openapi.Response(401, nil), errors.New("unauthorized: user not found in context")
+	}
+
+	// hidden topics look the same as missing ones
+	visible, err := topicVisible(s.db, topicId, user.ID)
+	if err != nil || !visible {
+		return openapi.Response(404, nil), errors.New("topic not found")
+	}
+
+	suggestions, err := getSuggestions(s.db, topicId, status)
+	if err != nil {
+		return openapi.Response(400, nil), err
+	}
+
+	// editors review every suggestion, everyone else only follows their own
+	if s.policy.checkUser(s.db, s.clock, KeyActionReview, user.ID, PolicyTarget{TopicId: topicId}) == nil {
+		return openapi.Response(200, suggestions), nil
+	}
+
+	response := make([]openapi.Suggestion, 0)
+	for _, suggestion := range suggestions {
+		if suggestion.AuthorId == user.ID {
+			response = append(response, suggestion)
+		}
+	}
+
+	return openapi.Response(200, response), nil
+}
+
+// AddSuggestion - suggest a new title, description or video for a node
+func (s *SuggestionAPIServiceImpl) AddSuggestion(ctx context.Context, suggestion openapi.Suggestion) (openapi.ImplResponse, error) {
+	user, ok := ctx.Value(userInfoKey).(token.User)
+	if !ok {
+		return openapi.Response(401, nil), errors.New("unauthorized: user not found in context")
+	}
+
+	// hidden topics look the same as missing ones
+	visible, err := topicVisible(s.db, suggestion.Topic, user.ID)
+	if err != nil || !visible {
+		return openapi.Response(404, nil), errors.New("topic not found")
+	}
+
+	err = s.policy.checkUser(s.db, s.clock, KeyActionSuggest, user.ID, PolicyTarget{TopicId: suggestion.Topic})
+	if err != nil {
+		return openapi.Response(401, nil), err
+	}
+
+	suggestion.AuthorId = user.ID
+
+	response, err := postSuggestion(s.db, s.clock, suggestion)
+	if err != nil {
+		return openapi.Response(400, nil), err
+	}
+
+	return openapi.Response(200, response), nil
+}
+
+// AcceptSuggestion - make the suggested change in the name of its author
+func (s *SuggestionAPIServiceImpl) AcceptSuggestion(ctx context.Context, suggestionId string, suggestionReview openapi.SuggestionReview) (openapi.ImplResponse, error) {
+	user, ok := ctx.Value(userInfoKey).(token.User)
+	if !ok {
+		return openapi.Response(401, nil), errors.New("unauthorized: user not found in context")
+	}
+
+	suggestion, err := getSuggestion(s.db, suggestionId)
+	if err != nil {
+		return openapi.Response(404, nil), err
+	}
+
+	err = s.checkReviewer(user.ID, suggestion)
+	if err != nil {
+		return openapi.Response(401, nil), err
+	}
+
+	userDetails, err := getUserForTopic(s.db, user.ID, suggestion.Topic)
+	if err != nil {
+		return openapi.Response(401, nil), err
+	}
+
+	err = checkLock(s.db, s.clock, userDetails, suggestion.Topic, false, suggestion.NodeId)
+	if err != nil {
+		return openapi.Response(423, nil), err
+	}
+
+	response, err := acceptSuggestion(s.db, s.clock, suggestionId, user.ID, suggestionReview)
+	if err != nil {
+		return openapi.Response(400, nil), err
+	}
+
+	return openapi.Response(200, response), nil
+}
+
+// RejectSuggestion - turn down a suggestion with a comment
+func (s *SuggestionAPIServiceImpl) RejectSuggestion(ctx context.Context, suggestionId string, suggestionReview openapi.SuggestionReview) (openapi.ImplResponse, error) {
+	user, ok := ctx.Value(userInfoKey).(token.User)
+	if !ok {
+		return openapi.Response(401, nil), errors.New("unauthorized: user not found in context")
+	}
+
+	suggestion, err := getSuggestion(s.db, suggestionId)
+	if err != nil {
+		return openapi.Response(404, nil), err
+	}
+
+	err = s.checkReviewer(user.ID, suggestion)
+	if err != nil {
+		return openapi.Response(401, nil), err
+	}
+
+	response, err := rejectSuggestion(s.db, s.clock, suggestionId, user.ID, suggestionReview)
+	if err != nil {
+		return openapi.Response(400, nil), err
+	}
+
+	return openapi.Response(200, response), nil
+}
+
+// editors of the topic review its suggestions, but not their own
+func (s *SuggestionAPIServiceImpl) checkReviewer(userId string, suggestion openapi.Suggestion) error {
+	if suggestion.AuthorId == userId {
+		return errors.New("unauthorized: users can't review their own suggestions")
+	}
+
+	return s.policy.checkUser(s.db, s.clock, KeyActionReview, userId, PolicyTarget{TopicId: suggestion.Topic})
+}
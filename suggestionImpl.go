@@ -0,0 +1,258 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	openapi "github.com/SpyLime/flowBackend/go"
+	bolt "go.etcd.io/bbolt"
+)
+
+// suggest a change to a node for an editor to review
+func postSuggestion(db *bolt.DB, clock Clock, request openapi.Suggestion) (response openapi.Suggestion, err error) {
+	err = db.Update(func(tx *bolt.Tx) error {
+		response, err = postSuggestionTx(tx, clock, request)
+		return err
+	})
+
+	return
+}
+
+func postSuggestionTx(tx *bolt.Tx, clock Clock, request openapi.Suggestion) (response openapi.Suggestion, err error) {
+	if request.Title == "" && request.Description == "" && request.Link == "" {
+		return response, fmt.Errorf("a suggestion needs a title, description or video")
+	}
+
+	node, err := getNodeRx(tx, request.NodeId.Format(time.RFC3339Nano), request.Topic)
+	if err != nil {
+		return
+	}
+
+	for _, video := range node.Resources {
+		if request.Link != "" && areSameResource(video.Link, request.Link) {
+			return response, fmt.Errorf("this video is already added")
+		}
+	}
+
+	suggestionsBucket, err := tx.CreateBucketIfNotExists([]byte(KeySuggestions))
+	if err != nil {
+		return
+	}
+
+	response = request
+	response.Id = RandomString(8)
+	for suggestionsBucket.Get([]byte(response.Id)) != nil {
+		response.Id = RandomString(8)
+	}
+	response.Status = KeySuggestionPending
+	response.ReviewerId = ""
+	response.Comment = ""
+	response.CreatedAt = clock.Now()
+	response.ReviewedAt = time.Time{}
+
+	err = putSuggestionTx(suggestionsBucket, response)
+	if err != nil {
+		return
+	}
+
+	err = refreshAchievementsTx(tx, clock, response.AuthorId, true)
+
+	return
+}
+
+func putSuggestionTx(suggestionsBucket *bolt.Bucket, suggestion openapi.Suggestion) (err error) {
+	marshal, err := json.Marshal(suggestion)
+	if err != nil {
+		return
+	}
+
+	err = suggestionsBucket.Put([]byte(suggestion.Id), marshal)
+
+	return
+}
+
+func getSuggestion(db *bolt.DB, suggestionId string) (response openapi.Suggestion, err error) {
+	err = db.View(func(tx *bolt.Tx) error {
+		response, err = getSuggestionRx(tx, suggestionId)
+		return err
+	})
+
+	return
+}
+
+func getSuggestionRx(tx *bolt.Tx, suggestionId string) (response openapi.Suggestion, err error) {
+	suggestionsBucket := tx.Bucket([]byte(KeySuggestions))
+	if suggestionsBucket == nil {
+		return response, fmt.Errorf("can't find suggestions bucket")
+	}
+
+	suggestionData := suggestionsBucket.Get([]byte(suggestionId))
+	if suggestionData == nil {
+		return response, fmt.Errorf("can't find suggestion")
+	}
+
+	err = json.Unmarshal(suggestionData, &response)
+
+	return
+}
+
+// getSuggestions returns the suggestions of a topic with the status, pending when it is empty and every one when it is all
+func getSuggestions(db *bolt.DB, topicId, status string) (response []openapi.Suggestion, err error) {
+	err = db.View(func(tx *bolt.Tx) error {
+		response, err = getSuggestionsRx(tx, topicId, status)
+		return err
+	})
+
+	return
+}
+
+func getSuggestionsRx(tx *bolt.Tx, topicId, status string) (response []openapi.Suggestion, err error) {
+	if status == "" {
+		status = KeySuggestionPending
+	}
+
+	response = make([]openapi.Suggestion, 0)
+
+	suggestionsBucket := tx.Bucket([]byte(KeySuggestions))
+	if suggestionsBucket == nil {
+		return
+	}
+
+	err = suggestionsBucket.ForEach(func(k, v []byte) error {
+		var suggestion openapi.Suggestion
+		err := json.Unmarshal(v, &suggestion)
+		if err != nil {
+			return err
+		}
+
+		if suggestion.Topic != topicId || (status != KeyCaseAll && suggestion.Status != status) {
+			return nil
+		}
+
+		response = append(response, suggestion)
+		return nil
+	})
+	if err != nil {
+		return
+	}
+
+	sort.Slice(response, func(i, j int) bool {
+		return response[i].CreatedAt.Before(response[j].CreatedAt)
+	})
+
+	return
+}
+
+// acceptSuggestion makes the change for the author, they are added to the editors and get reputation for it
+func acceptSuggestion(db *bolt.DB, clock Clock, suggestionId, reviewerId string, review openapi.SuggestionReview) (response openapi.Suggestion, err error) {
+	err = db.Update(func(tx *bolt.Tx) error {
+		response, err = acceptSuggestionTx(tx, clock, suggestionId, reviewerId, review)
+		return err
+	})
+
+	return
+}
+
+func acceptSuggestionTx(tx *bolt.Tx, clock Clock, suggestionId, reviewerId string, review openapi.SuggestionReview) (response openapi.Suggestion, err error) {
+	response, err = getSuggestionRx(tx, suggestionId)
+	if err != nil {
+		return
+	}
+
+	if response.Status != KeySuggestionPending {
+		return response, fmt.Errorf("suggestion is already %s", response.Status)
+	}
+
+	author, err := getUserRx(tx, response.AuthorId)
+	if err != nil {
+		return
+	}
+
+	if response.Title != "" || response.Description != "" {
+		_, err = updateNodeTitleTx(tx, openapi.NodeData{
+			Id:          response.NodeId,
+			Topic:       response.Topic,
+			Title:       response.Title,
+			Description: response.Description,
+		}, author)
+		if err != nil {
+			return
+		}
+	}
+
+	if response.Link != "" {
+		_, err = updateNodeVideoEditTx(tx, clock, openapi.NodeData{
+			Id:        response.NodeId,
+			Topic:     response.Topic,
+			Resources: []openapi.LinkData{{Link: response.Link, Votes: 1}},
+		}, author)
+		if err != nil {
+			return
+		}
+	}
+
+	err = updateCreatorReputation(tx, clock, openapi.ReputationEvent{
+		UserId:  response.AuthorId,
+		Source:  KeySourceSuggestion,
+		Topic:   response.Topic,
+		NodeId:  response.NodeId,
+		Link:    canonicalResourceLink(response.Link),
+		VoterId: reviewerId,
+		Delta:   KeyReputationSuggestion,
+	})
+	if err != nil {
+		return
+	}
+
+	response, err = reviewSuggestionTx(tx, clock, response, KeySuggestionAccepted, reviewerId, review)
+
+	return
+}
+
+// rejectSuggestion turns the suggestion down, the comment tells the author why
+func rejectSuggestion(db *bolt.DB, clock Clock, suggestionId, reviewerId string, review openapi.SuggestionReview) (response openapi.Suggestion, err error) {
+	err = db.Update(func(tx *bolt.Tx) error {
+		response, err = rejectSuggestionTx(tx, clock, suggestionId, reviewerId, review)
+		return err
+	})
+
+	return
+}
+
+func rejectSuggestionTx(tx *bolt.Tx, clock Clock, suggestionId, reviewerId string, review openapi.SuggestionReview) (response openapi.Suggestion, err error) {
+	if review.Comment == "" {
+		return response, fmt.Errorf("a rejected suggestion needs a comment")
+	}
+
+	response, err = getSuggestionRx(tx, suggestionId)
+	if err != nil {
+		return
+	}
+
+	if response.Status != KeySuggestionPending {
+		return response, fmt.Errorf("suggestion is already %s", response.Status)
+	}
+
+	response, err = reviewSuggestionTx(tx, clock, response, KeySuggestionRejected, reviewerId, review)
+
+	return
+}
+
+func reviewSuggestionTx(tx *bolt.Tx, clock Clock, suggestion openapi.Suggestion, status, reviewerId string, review openapi.SuggestionReview) (response openapi.Suggestion, err error) {
+	suggestionsBucket := tx.Bucket([]byte(KeySuggestions))
+	if suggestionsBucket == nil {
+		return response, fmt.Errorf("can't find suggestions bucket")
+	}
+
+	response = suggestion
+	response.Status = status
+	response.ReviewerId = reviewerId
+	response.Comment = review.Comment
+	response.ReviewedAt = clock.Now()
+
+	err = putSuggestionTx(suggestionsBucket, response)
+
+	return
+}
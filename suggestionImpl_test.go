@@ -0,0 +1,95 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	openapi "github.com/SpyLime/flowBackend/go"
+	"github.com/go-pkgz/lgr"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSuggestionImpl(t *testing.T) {
+
+	lgr.Printf("INFO TestSuggestionImpl")
+	t.Log("INFO TestSuggestionImpl")
+	clock := TestClock{}
+	db, dbTearDown := OpenTestDB("SuggestionImpl")
+	defer dbTearDown()
+
+	users, topics, nodesAndEdges, err := CreateTestData(db, &clock, 2, 1, 1)
+	require.Nil(t, err)
+
+	nodeId := nodesAndEdges[0].SourceId
+	node, err := getNode(db, nodeId.Format(time.RFC3339Nano), topics[0])
+	require.Nil(t, err)
+
+	author, reviewer := users[0], users[1]
+	if author == node.CreatedBy.Id {
+		author, reviewer = reviewer, author
+	}
+
+	_, err = postSuggestion(db, &clock, openapi.Suggestion{Topic: topics[0], NodeId: nodeId, AuthorId: author})
+	require.NotNil(t, err)
+
+	_, err = postSuggestion(db, &clock, openapi.Suggestion{Topic: topics[0], NodeId: clock.Now().Add(time.Hour), Title: "armbar", AuthorId: author})
+	require.NotNil(t, err)
+
+	titleSuggestion, err := postSuggestion(db, &clock, openapi.Suggestion{Topic: topics[0], NodeId: nodeId, Title: "armbar", Description: "from guard", AuthorId: author})
+	require.Nil(t, err)
+	require.Equal(t, KeySuggestionPending, titleSuggestion.Status)
+
+	clock.Tick()
+	videoSuggestion, err := postSuggestion(db, &clock, openapi.Suggestion{Topic: topics[0], NodeId: nodeId, Link: "https://youtu.be/abcdefghijk", AuthorId: author})
+	require.Nil(t, err)
+
+	pending, err := getSuggestions(db, topics[0], "")
+	require.Nil(t, err)
+	require.Equal(t, 2, len(pending))
+	require.Equal(t, titleSuggestion.Id, pending[0].Id)
+
+	before, err := getUser(db, author)
+	require.Nil(t, err)
+
+	// accepting makes the change in the name of the author
+	accepted, err := acceptSuggestion(db, &clock, titleSuggestion.Id, reviewer, openapi.SuggestionReview{})
+	require.Nil(t, err)
+	require.Equal(t, KeySuggestionAccepted, accepted.Status)
+	require.Equal(t, reviewer, accepted.ReviewerId)
+
+	node, err = getNode(db, nodeId.Format(time.RFC3339Nano), topics[0])
+	require.Nil(t, err)
+	require.Equal(t, "armbar", node.Title)
+	require.Equal(t, "from guard", node.Description)
+	require.Equal(t, author, node.EditedBy[len(node.EditedBy)-1].Id)
+
+	after, err := getUser(db, author)
+	require.Nil(t, err)
+	require.Equal(t, before.Reputation+KeyReputationSuggestion, after.Reputation)
+
+	_, err = acceptSuggestion(db, &clock, titleSuggestion.Id, reviewer, openapi.SuggestionReview{})
+	require.NotNil(t, err)
+
+	// rejecting needs a comment
+	_, err = rejectSuggestion(db, &clock, videoSuggestion.Id, reviewer, openapi.SuggestionReview{})
+	require.NotNil(t, err)
+
+	rejected, err := rejectSuggestion(db, &clock, videoSuggestion.Id, reviewer, openapi.SuggestionReview{Comment: "off topic"})
+	require.Nil(t, err)
+	require.Equal(t, KeySuggestionRejected, rejected.Status)
+	require.Equal(t, "off topic", rejected.Comment)
+
+	node, err = getNode(db, nodeId.Format(time.RFC3339Nano), topics[0])
+	require.Nil(t, err)
+	for _, video := range node.YoutubeLinks {
+		require.NotEqual(t, "https://youtu.be/abcdefghijk", video.Link)
+	}
+
+	pending, err = getSuggestions(db, topics[0], "")
+	require.Nil(t, err)
+	require.Equal(t, 0, len(pending))
+
+	all, err := getSuggestions(db, topics[0], KeyCaseAll)
+	require.Nil(t, err)
+	require.Equal(t, 2, len(all))
+}
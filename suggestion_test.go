@@ -0,0 +1,118 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	openapi "github.com/SpyLime/flowBackend/go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSuggestions(t *testing.T) {
+	clock := TestClock{}
+	db, tearDown := FullStartTestServer("Suggestions", 8088, "")
+	defer tearDown()
+
+	users, topics, nodesAndEdges, err := CreateTestData(db, &clock, 4, 1, 1)
+	require.Nil(t, err)
+
+	info, err := getTopicInfo(db, topics[0])
+	require.Nil(t, err)
+
+	nodeId := nodesAndEdges[0].SourceId
+	node, err := getNode(db, nodeId.Format(time.RFC3339Nano), topics[0])
+	require.Nil(t, err)
+
+	// the owners of the topic and node could edit without reputation
+	var others []string
+	for _, id := range users {
+		if id != info.CreatedBy && id != node.CreatedBy.Id {
+			others = append(others, id)
+		}
+	}
+	author, editor := others[0], others[1]
+
+	err = UpdateUserRoleAndReputation(db, author, false, 0)
+	require.Nil(t, err)
+	err = UpdateUserRoleAndReputation(db, editor, false, KeyReputationEditor)
+	require.Nil(t, err)
+
+	client := &http.Client{}
+
+	// the author can't edit the title so they suggest it
+	SetTestLoginUser(author)
+
+	marshal, err := json.Marshal(openapi.NodeData{Id: nodeId, Topic: topics[0], Title: "armbar"})
+	require.Nil(t, err)
+
+	req, _ := http.NewRequest(http.MethodPut, "http://127.0.0.1:8088/api/v1/node/title", bytes.NewBuffer(marshal))
+
+	resp, err := client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 401, resp.StatusCode)
+
+	marshal, err = json.Marshal(openapi.Suggestion{Topic: topics[0], NodeId: nodeId, Title: "armbar"})
+	require.Nil(t, err)
+
+	req, _ = http.NewRequest(http.MethodPost, "http://127.0.0.1:8088/api/v1/suggestion", bytes.NewBuffer(marshal))
+
+	resp, err = client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 200, resp.StatusCode)
+
+	var suggestion openapi.Suggestion
+	err = json.NewDecoder(resp.Body).Decode(&suggestion)
+	require.Nil(t, err)
+	require.Equal(t, author, suggestion.AuthorId)
+
+	// authors can't accept their own suggestions
+	marshal, err = json.Marshal(openapi.SuggestionReview{})
+	require.Nil(t, err)
+
+	req, _ = http.NewRequest(http.MethodPut, "http://127.0.0.1:8088/api/v1/suggestion/"+suggestion.Id+"/accept", bytes.NewBuffer(marshal))
+
+	resp, err = client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 401, resp.StatusCode)
+
+	// the editor reviews the pending list
+	SetTestLoginUser(editor)
+
+	req, _ = http.NewRequest(http.MethodGet, "http://127.0.0.1:8088/api/v1/suggestion?topicId="+url.QueryEscape(topics[0]), nil)
+
+	resp, err = client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 200, resp.StatusCode)
+
+	var pending []openapi.Suggestion
+	err = json.NewDecoder(resp.Body).Decode(&pending)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(pending))
+
+	req, _ = http.NewRequest(http.MethodPut, "http://127.0.0.1:8088/api/v1/suggestion/"+suggestion.Id+"/accept", bytes.NewBuffer(marshal))
+
+	resp, err = client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 200, resp.StatusCode)
+
+	node, err = getNode(db, nodeId.Format(time.RFC3339Nano), topics[0])
+	require.Nil(t, err)
+	require.Equal(t, "armbar", node.Title)
+	require.Equal(t, author, node.EditedBy[len(node.EditedBy)-1].Id)
+
+	req, _ = http.NewRequest(http.MethodPut, "http://127.0.0.1:8088/api/v1/suggestion/missing/reject", bytes.NewBuffer(marshal))
+
+	resp, err = client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 404, resp.StatusCode)
+}
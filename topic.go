@@ -3,7 +3,7 @@ package main
 import (
 	"context"
 	"errors"
-	"net/http"
+	"time"
 
 	openapi "github.com/SpyLime/flowBackend/go"
 	"github.com/go-pkgz/auth/token"
@@ -14,21 +14,28 @@ import (
 // This service should implement the business logic for every endpoint for the TopicAPI API.
 // Include any external packages or services that will be required by this service.
 type TopicAPIServiceImpl struct {
-	db    *bolt.DB
-	clock Clock
+	db        *bolt.DB
+	clock     Clock
+	policy    Policy
+	playlists PlaylistFetcher // nil only imports pasted lists
 }
 
 // NewTopicAPIService creates a default api service
-func NewTopicAPIServiceImpl(db *bolt.DB, clock Clock) openapi.TopicAPIServicer {
+func NewTopicAPIServiceImpl(db *bolt.DB, clock Clock, policy Policy, playlists PlaylistFetcher) openapi.TopicAPIServicer {
 	return &TopicAPIServiceImpl{
-		db:    db,
-		clock: clock,
+		db:        db,
+		clock:     clock,
+		policy:    policy,
+		playlists: playlists,
 	}
 }
 
 // GetTopics - get all topics
 func (s *TopicAPIServiceImpl) GetTopics(ctx context.Context) (openapi.ImplResponse, error) {
-	response, err := getTopics(s.db)
+	// nobody is logged in when there is no user, they only see public topics
+	user, _ := ctx.Value(userInfoKey).(token.User)
+
+	response, err := getVisibleTopics(s.db, user.ID)
 	if err != nil {
 		return openapi.Response(404, nil), err
 	}
@@ -38,27 +45,45 @@ func (s *TopicAPIServiceImpl) GetTopics(ctx context.Context) (openapi.ImplRespon
 
 // UpdateTopic - Update an existing topic
 func (s *TopicAPIServiceImpl) UpdateTopic(ctx context.Context, topic openapi.Topic) (openapi.ImplResponse, error) {
-	// TODO - update UpdateTopic with the required logic for this service method.
-	// Add api_topic_service.go to the .openapi-generator-ignore to avoid overwriting this service implementation when updating open api generation.
+	// Extract user information from context
+	user, ok := ctx.Value(userInfoKey).(token.User)
+	if !ok {
+		return openapi.Response(401, nil), errors.New("unauthorized: user not found in context")
+	}
 
-	// TODO: Uncomment the next line to return response Response(200, {}) or use other options such as http.Ok ...
-	// return Response(200, nil),nil
+	visible, err := topicVisible(s.db, topic.Title, user.ID)
+	if err != nil || !visible {
+		return openapi.Response(404, nil), errors.New("topic not found")
+	}
 
-	// TODO: Uncomment the next line to return response Response(400, {}) or use other options such as http.Ok ...
-	// return Response(400, nil),nil
+	userDetails, err := getUserForTopic(s.db, user.ID, topic.Title)
+	if err != nil {
+		return openapi.Response(401, nil), err
+	}
 
-	// TODO: Uncomment the next line to return response Response(404, {}) or use other options such as http.Ok ...
-	// return Response(404, nil),nil
+	err = s.policy.check(s.db, s.clock, KeyActionUpdateTopic, userDetails, PolicyTarget{TopicId: topic.Title})
+	if err != nil {
+		return openapi.Response(401, nil), err
+	}
 
-	// TODO: Uncomment the next line to return response Response(405, {}) or use other options such as http.Ok ...
-	// return Response(405, nil),nil
+	// who can see the topic is only changed by admins and those who manage it
+	if topic.Visibility != "" || topic.AllowedUsers != nil || topic.AllowedGroups != nil {
+		err = s.policy.check(s.db, s.clock, KeyActionShareTopic, userDetails, PolicyTarget{TopicId: topic.Title})
+		if err != nil {
+			return openapi.Response(401, nil), err
+		}
+	}
 
-	//this endpoint can't be right in the spec. The only way to update a topic is to change its title. there is no way to easily change a bucket name.
+	//the title is the name of the topic bucket so it can't be changed here.
 	//You must make a new bucket with the name you want and then copy all the contents into the new bucket and then delete the old one.
-	//I don't see any reason to implement this. SysAdmin is the only one that can create topics and if you name it incorrectly then just delete and make a correct one.
-	//Make sure you do it quickly before users add items to the bucket.
+	//SysAdmin is the only one that can create topics and if you name it incorrectly then just delete and make a correct one.
+	//The other details of the topic such as its categories and visibility can be updated.
+	err = updateTopic(s.db, topic)
+	if err != nil {
+		return openapi.Response(404, nil), err
+	}
 
-	return openapi.Response(http.StatusNotImplemented, nil), errors.New("UpdateTopic method not implemented")
+	return openapi.Response(200, nil), nil
 }
 
 // AddTopic - Add a new topic
@@ -74,8 +99,15 @@ func (s *TopicAPIServiceImpl) AddTopic(ctx context.Context, topic openapi.Topic)
 		return openapi.Response(401, nil), err
 	}
 
-	if userDetails.Role != KeyAdmin && userDetails.Reputation < KeyReputationDeleter {
-		return openapi.Response(401, nil), errors.New("unauthorized: user is not an admin or has low reputation(Deleter)")
+	// members of a school make topics for their school so its admins and reputation apply
+	userDetails, err = getUserForOrganization(s.db, user.ID, userDetails.OrganizationId)
+	if err != nil {
+		return openapi.Response(401, nil), err
+	}
+
+	err = s.policy.check(s.db, s.clock, KeyActionAddTopic, userDetails, PolicyTarget{})
+	if err != nil {
+		return openapi.Response(401, nil), err
 	}
 
 	responsePostTopic, err := postTopic(s.db, s.clock, topic, userDetails)
@@ -96,13 +128,15 @@ func (s *TopicAPIServiceImpl) DeleteTopic(ctx context.Context, topicId string) (
 		return openapi.Response(401, nil), errors.New("unauthorized: user not found in context")
 	}
 
-	userDetails, err := getUser(s.db, user.ID)
+	userDetails, err := getUserForTopic(s.db, user.ID, topicId)
 	if err != nil {
 		return openapi.Response(401, nil), err
 	}
 
-	if userDetails.Role != KeyAdmin {
-		return openapi.Response(401, nil), errors.New("unauthorized: user is not an admin email a request for this topic to be deleted")
+	// by default only admins can delete, email a request for the topic to be deleted
+	err = s.policy.check(s.db, s.clock, KeyActionDeleteTopic, userDetails, PolicyTarget{TopicId: topicId})
+	if err != nil {
+		return openapi.Response(401, nil), err
 	}
 
 	err = deleteTopic(s.db, topicId)
@@ -112,3 +146,225 @@ func (s *TopicAPIServiceImpl) DeleteTopic(ctx context.Context, topicId string) (
 
 	return openapi.Response(204, nil), nil
 }
+
+// GetTopicRoles - get the roles people have in a topic
+func (s *TopicAPIServiceImpl) GetTopicRoles(ctx context.Context, topicId string) (openapi.ImplResponse, error) {
+	// nobody is logged in when there is no user
+	user, _ := ctx.Value(userInfoKey).(token.User)
+
+	// hidden topics look the same as missing ones
+	visible, err := topicVisible(s.db, topicId, user.ID)
+	if err != nil || !visible {
+		return openapi.Response(404, nil), errors.New("topic not found")
+	}
+
+	response, err := getTopicRoles(s.db, topicId)
+	if err != nil {
+		return openapi.Response(404, nil), err
+	}
+
+	return openapi.Response(200, response), nil
+}
+
+// UpdateTopicRole - give someone a role in a topic or change it
+func (s *TopicAPIServiceImpl) UpdateTopicRole(ctx context.Context, topicId string, topicRole openapi.TopicRole) (openapi.ImplResponse, error) {
+	err := s.checkTopicManager(ctx, topicId)
+	if err != nil {
+		return openapi.Response(401, nil), err
+	}
+
+	response, err := updateTopicRole(s.db, topicId, topicRole)
+	if err != nil {
+		return openapi.Response(400, nil), err
+	}
+
+	return openapi.Response(200, response), nil
+}
+
+// DeleteTopicRole - remove someone's role in a topic
+func (s *TopicAPIServiceImpl) DeleteTopicRole(ctx context.Context, topicId string, userId string) (openapi.ImplResponse, error) {
+	err := s.checkTopicManager(ctx, topicId)
+	if err != nil {
+		return openapi.Response(401, nil), err
+	}
+
+	err = deleteTopicRole(s.db, topicId, userId)
+	if err != nil {
+		return openapi.Response(400, nil), err
+	}
+
+	return openapi.Response(204, nil), nil
+}
+
+// UpdateTopicLock - lock the topic or one of its nodes
+func (s *TopicAPIServiceImpl) UpdateTopicLock(ctx context.Context, topicId string, lock openapi.Lock) (openapi.ImplResponse, error) {
+	user, ok := ctx.Value(userInfoKey).(token.User)
+	if !ok {
+		return openapi.Response(401, nil), errors.New("unauthorized: user not found in context")
+	}
+
+	// hidden topics look the same as missing ones
+	visible, err := topicVisible(s.db, topicId, user.ID)
+	if err != nil || !visible {
+		return openapi.Response(404, nil), errors.New("topic not found")
+	}
+
+	err = s.policy.checkUser(s.db, s.clock, KeyActionLock, user.ID, PolicyTarget{TopicId: topicId})
+	if err != nil {
+		return openapi.Response(401, nil), err
+	}
+
+	lock.LockedBy = user.ID
+
+	response, err := putLock(s.db, s.clock, topicId, lock)
+	if err != nil {
+		return openapi.Response(400, nil), err
+	}
+
+	return openapi.Response(200, response), nil
+}
+
+// DeleteTopicLock - unlock the topic or one of its nodes
+func (s *TopicAPIServiceImpl) DeleteTopicLock(ctx context.Context, topicId string, nodeId string) (openapi.ImplResponse, error) {
+	user, ok := ctx.Value(userInfoKey).(token.User)
+	if !ok {
+		return openapi.Response(401, nil), errors.New("unauthorized: user not found in context")
+	}
+
+	err := s.policy.checkUser(s.db, s.clock, KeyActionLock, user.ID, PolicyTarget{TopicId: topicId})
+	if err != nil {
+		return openapi.Response(401, nil), err
+	}
+
+	// without a node the lock of the topic is removed
+	var id time.Time
+	if nodeId != "" {
+		id, err = time.Parse(time.RFC3339Nano, nodeId)
+		if err != nil {
+			return openapi.Response(400, nil), err
+		}
+	}
+
+	err = deleteLock(s.db, topicId, id)
+	if err != nil {
+		return openapi.Response(404, nil), err
+	}
+
+	return openapi.Response(204, nil), nil
+}
+
+// GetTopicDeadLinks - get the dead links of a topic
+func (s *TopicAPIServiceImpl) GetTopicDeadLinks(ctx context.Context, topicId string) (openapi.ImplResponse, error) {
+	// nobody is logged in when there is no user
+	user, _ := ctx.Value(userInfoKey).(token.User)
+
+	// hidden topics look the same as missing ones
+	visible, err := topicVisible(s.db, topicId, user.ID)
+	if err != nil || !visible {
+		return openapi.Response(404, nil), errors.New("topic not found")
+	}
+
+	// links hidden by moderation are only shown to moderators and who added them
+	moderator := s.policy.checkUser(s.db, s.clock, KeyActionModerate, user.ID, PolicyTarget{TopicId: topicId}) == nil
+
+	response, err := getDeadLinks(s.db, topicId, user.ID, moderator)
+	if err != nil {
+		return openapi.Response(404, nil), err
+	}
+
+	return openapi.Response(200, response), nil
+}
+
+// GetTopicSharedVideos - get the videos attached to many nodes of a topic
+func (s *TopicAPIServiceImpl) GetTopicSharedVideos(ctx context.Context, topicId string, minNodes int32) (openapi.ImplResponse, error) {
+	// nobody is logged in when there is no user
+	user, _ := ctx.Value(userInfoKey).(token.User)
+
+	// hidden topics look the same as missing ones
+	visible, err := topicVisible(s.db, topicId, user.ID)
+	if err != nil || !visible {
+		return openapi.Response(404, nil), errors.New("topic not found")
+	}
+
+	response, err := getSharedVideos(s.db, topicId, minNodes)
+	if err != nil {
+		return openapi.Response(404, nil), err
+	}
+
+	return openapi.Response(200, response), nil
+}
+
+// ImportTopicPlaylist - import a playlist or a pasted list of videos as a chain of nodes
+func (s *TopicAPIServiceImpl) ImportTopicPlaylist(ctx context.Context, topicId string, request openapi.PlaylistImport) (openapi.ImplResponse, error) {
+	user, ok := ctx.Value(userInfoKey).(token.User)
+	if !ok {
+		return openapi.Response(401, nil), errors.New("unauthorized: user not found in context")
+	}
+
+	// hidden topics look the same as missing ones
+	visible, err := topicVisible(s.db, topicId, user.ID)
+	if err != nil || !visible {
+		return openapi.Response(404, nil), errors.New("topic not found")
+	}
+
+	userDetails, err := getUserForTopic(s.db, user.ID, topicId)
+	if err != nil {
+		return openapi.Response(401, nil), err
+	}
+
+	err = s.policy.check(s.db, s.clock, KeyActionAddNode, userDetails, PolicyTarget{TopicId: topicId})
+	if err != nil {
+		return openapi.Response(401, nil), err
+	}
+
+	err = checkLock(s.db, s.clock, userDetails, topicId, false, request.Source)
+	if err != nil {
+		return openapi.Response(423, nil), err
+	}
+
+	_, err = getNode(s.db, request.Source.Format(time.RFC3339Nano), topicId)
+	if err != nil {
+		return openapi.Response(404, nil), err
+	}
+
+	// the playlist is fetched before the nodes are written so the database isn't held while waiting on the network
+	var playlistId string
+	videos := request.Videos
+	if len(videos) == 0 {
+		if s.playlists == nil {
+			return openapi.Response(400, nil), errors.New("playlists can't be fetched, paste the videos instead")
+		}
+
+		playlistId, err = parsePlaylistId(request.Playlist)
+		if err != nil {
+			return openapi.Response(400, nil), err
+		}
+
+		videos, err = s.playlists.Fetch(ctx, playlistId)
+		if err != nil {
+			return openapi.Response(400, nil), err
+		}
+	}
+
+	response, err := importPlaylist(s.db, s.clock, topicId, request.Source, playlistId, videos, userDetails)
+	if err != nil {
+		return openapi.Response(400, nil), err
+	}
+
+	return openapi.Response(200, response), nil
+}
+
+// only owners of the topic and admins can hand out roles
+func (s *TopicAPIServiceImpl) checkTopicManager(ctx context.Context, topicId string) error {
+	user, ok := ctx.Value(userInfoKey).(token.User)
+	if !ok {
+		return errors.New("unauthorized: user not found in context")
+	}
+
+	userDetails, err := getUserForTopic(s.db, user.ID, topicId)
+	if err != nil {
+		return err
+	}
+
+	return s.policy.check(s.db, s.clock, KeyActionManageRoles, userDetails, PolicyTarget{TopicId: topicId})
+}
@@ -26,14 +26,130 @@ func getTopicsRx(tx *bolt.Tx) (response []openapi.GetTopics200ResponseInner, err
 
 	c := topicsBucket.Cursor()
 	for k, _ := c.First(); k != nil; k, _ = c.Next() {
+		info, err := getTopicInfoRx(tx, string(k))
+		if err != nil {
+			return response, err
+		}
+
 		response = append(response, openapi.GetTopics200ResponseInner{
-			Title: string(k),
+			Title:      info.Title,
+			Categories: info.Categories,
+			Visibility: info.Visibility,
 		})
 	}
 
 	return
 }
 
+func getTopicInfo(db *bolt.DB, topicId string) (info openapi.Topic, err error) {
+	err = db.View(func(tx *bolt.Tx) error {
+		info, err = getTopicInfoRx(tx, topicId)
+		return err
+	})
+
+	return
+}
+
+// getTopicInfoRx returns the details stored with a topic
+//
+// topics made before details were stored only have a title
+func getTopicInfoRx(tx *bolt.Tx, topicId string) (info openapi.Topic, err error) {
+	topicsBucket := tx.Bucket([]byte(KeyTopics))
+	if topicsBucket == nil {
+		return info, fmt.Errorf("can't find topics bucket")
+	}
+
+	topicBucket := topicsBucket.Bucket([]byte(topicId))
+	if topicBucket == nil {
+		return info, fmt.Errorf("can't find topic bucket")
+	}
+
+	infoData := topicBucket.Get([]byte(KeyInfo))
+	if infoData != nil {
+		err = json.Unmarshal(infoData, &info)
+		if err != nil {
+			return
+		}
+	}
+
+	info.Title = topicId
+
+	return
+}
+
+func putTopicInfoTx(tx *bolt.Tx, info openapi.Topic) (err error) {
+	topicsBucket := tx.Bucket([]byte(KeyTopics))
+	if topicsBucket == nil {
+		return fmt.Errorf("can't find topics bucket")
+	}
+
+	topicBucket := topicsBucket.Bucket([]byte(info.Title))
+	if topicBucket == nil {
+		return fmt.Errorf("can't find topic bucket")
+	}
+
+	marshal, err := json.Marshal(info)
+	if err != nil {
+		return
+	}
+
+	return topicBucket.Put([]byte(KeyInfo), marshal)
+}
+
+func updateTopic(db *bolt.DB, topic openapi.Topic) (err error) {
+	err = db.Update(func(tx *bolt.Tx) error {
+		err = updateTopicTx(tx, topic)
+		return err
+	})
+
+	return
+}
+
+// the title is the bucket name so it can't be changed, everything else can.
+// Only what the request has is changed, send an empty list to clear one
+func updateTopicTx(tx *bolt.Tx, topic openapi.Topic) (err error) {
+	info, err := getTopicInfoRx(tx, topic.Title)
+	if err != nil {
+		return
+	}
+
+	err = validateTopicInfoRx(tx, topic)
+	if err != nil {
+		return
+	}
+
+	if topic.Categories != nil {
+		info.Categories = topic.Categories
+	}
+	if topic.Visibility != "" {
+		info.Visibility = topic.Visibility
+	}
+	if topic.AllowedUsers != nil {
+		info.AllowedUsers = topic.AllowedUsers
+	}
+	if topic.AllowedGroups != nil {
+		info.AllowedGroups = topic.AllowedGroups
+	}
+
+	return putTopicInfoTx(tx, info)
+}
+
+// checks that the categories and groups of a topic exist and that its visibility is known
+func validateTopicInfoRx(tx *bolt.Tx, topic openapi.Topic) (err error) {
+	switch topic.Visibility {
+	case "", KeyVisibilityPublic, KeyVisibilityUnlisted, KeyVisibilityPrivate, KeyVisibilityGroup:
+	default:
+		return fmt.Errorf("unknown visibility %s", topic.Visibility)
+	}
+
+	err = categoriesExistRx(tx, topic.Categories)
+	if err != nil {
+		return
+	}
+
+	return groupsExistRx(tx, topic.AllowedGroups)
+}
+
 func postTopic(db *bolt.DB, clock Clock, topic openapi.Topic, user openapi.User) (response openapi.ResponsePostTopic, err error) {
 	err = db.Update(func(tx *bolt.Tx) error {
 		response, err = postTopicTx(tx, clock, topic, user)
@@ -65,7 +181,23 @@ func postTopicTx(tx *bolt.Tx, clock Clock, topic openapi.Topic, user openapi.Use
 		return
 	}
 
-	response.Topic.Title = topic.Title
+	err = validateTopicInfoRx(tx, topic)
+	if err != nil {
+		return
+	}
+
+	topic.CreatedBy = user.Id
+	topic.OrganizationId = user.OrganizationId
+	// roles are handed out by the owner afterwards
+	topic.Roles = nil
+	topic.Lock = openapi.Lock{}
+
+	err = putTopicInfoTx(tx, topic)
+	if err != nil {
+		return
+	}
+
+	response.Topic = topic
 
 	newNode := openapi.NodeData{
 		Topic: topic.Title,
@@ -96,6 +228,16 @@ func postTopicTx(tx *bolt.Tx, clock Clock, topic openapi.Topic, user openapi.Use
 	}
 
 	err = userNodeCreatedTx(tx, user.Id, newNode)
+	if err != nil {
+		return
+	}
+
+	err = refreshAchievementsTx(tx, clock, user.Id, true)
+	if err != nil {
+		return
+	}
+
+	err = addContributionTx(tx, clock.Now(), newNode.Topic, user.Id, openapi.LeaderboardEntry{Nodes: 1}, true)
 
 	return
 }
@@ -137,5 +279,312 @@ func deleteTopicTx(tx *bolt.Tx, topicId string) (err error) {
 
 	// Now delete the topic bucket
 	err = topicsBucket.DeleteBucket([]byte(topicId))
+	if err != nil {
+		return
+	}
+
+	err = deleteTopicLeaderboardTx(tx, topicId)
+	if err != nil {
+		return
+	}
+
+	err = deleteTopicVideoIndexTx(tx, topicId)
+
+	return
+}
+
+func getVisibleTopics(db *bolt.DB, viewerId string) (response []openapi.GetTopics200ResponseInner, err error) {
+	err = db.View(func(tx *bolt.Tx) error {
+		response, err = getVisibleTopicsRx(tx, viewerId)
+		return err
+	})
+
+	return
+}
+
+// returns only the topics that should be listed for the viewer
+//
+// viewerId is empty when nobody is logged in
+func getVisibleTopicsRx(tx *bolt.Tx, viewerId string) (response []openapi.GetTopics200ResponseInner, err error) {
+	topics, err := getTopicsRx(tx)
+	if err != nil {
+		return topics, err
+	}
+
+	hidden, err := hiddenTopicsRx(tx, viewerId)
+	if err != nil {
+		return
+	}
+
+	response = []openapi.GetTopics200ResponseInner{}
+	for _, topic := range topics {
+		if !hidden[topic.Title] {
+			response = append(response, topic)
+		}
+	}
+
+	return
+}
+
+func topicVisible(db *bolt.DB, topicId, viewerId string) (visible bool, err error) {
+	err = db.View(func(tx *bolt.Tx) error {
+		visible, err = topicVisibleRx(tx, topicId, viewerId)
+		return err
+	})
+
+	return
+}
+
+// returns true when the viewer may open the topic directly, unlisted topics can be opened by anyone with the link
+func topicVisibleRx(tx *bolt.Tx, topicId, viewerId string) (visible bool, err error) {
+	info, err := getTopicInfoRx(tx, topicId)
+	if err != nil {
+		return
+	}
+
+	if !inTopicOrganizationRx(tx, info, viewerId) {
+		return false, nil
+	}
+
+	switch info.Visibility {
+	case "", KeyVisibilityPublic, KeyVisibilityUnlisted:
+		return true, nil
+	}
+
+	return hasTopicAccessRx(tx, info, viewerId), nil
+}
+
+// returns the titles of every topic that must not show up in lists, counts or activity for the viewer
+func hiddenTopicsRx(tx *bolt.Tx, viewerId string) (hidden map[string]bool, err error) {
+	hidden = make(map[string]bool)
+
+	// a database without topics has nothing to hide
+	topicsBucket := tx.Bucket([]byte(KeyTopics))
+	if topicsBucket == nil {
+		return
+	}
+
+	c := topicsBucket.Cursor()
+	for k, _ := c.First(); k != nil; k, _ = c.Next() {
+		info, err := getTopicInfoRx(tx, string(k))
+		if err != nil {
+			return hidden, err
+		}
+
+		if !inTopicOrganizationRx(tx, info, viewerId) {
+			hidden[info.Title] = true
+			continue
+		}
+
+		if info.Visibility == "" || info.Visibility == KeyVisibilityPublic {
+			continue
+		}
+
+		if !hasTopicAccessRx(tx, info, viewerId) {
+			hidden[info.Title] = true
+		}
+	}
+
+	return
+}
+
+// topics of an organization are only for its members and global admins
+func inTopicOrganizationRx(tx *bolt.Tx, info openapi.Topic, viewerId string) bool {
+	if info.OrganizationId == "" {
+		return true
+	}
+
+	viewer, err := getUserRx(tx, viewerId)
+	if err != nil {
+		return false
+	}
+
+	return viewer.Role == KeyAdmin || viewer.OrganizationId == info.OrganizationId
+}
+
+// admins, anyone with a role in the topic and the allowed users always have access, group members only when the topic is shared with a group
+func hasTopicAccessRx(tx *bolt.Tx, info openapi.Topic, viewerId string) bool {
+	if viewerId == "" {
+		return false
+	}
+
+	if topicRole(info, viewerId) != "" || contains(info.AllowedUsers, viewerId) {
+		return true
+	}
+
+	viewer, err := getUserRx(tx, viewerId)
+	if err == nil && scopeUserRx(tx, viewer, info.OrganizationId).Role == KeyAdmin {
+		return true
+	}
+
+	if info.Visibility == KeyVisibilityPrivate {
+		return false
+	}
+
+	for _, groupId := range info.AllowedGroups {
+		group, err := getGroupRx(tx, groupId)
+		if err != nil {
+			continue
+		}
+
+		if group.Owner == viewerId || contains(group.Members, viewerId) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// the creator of a topic is always its owner
+func topicRole(info openapi.Topic, userId string) string {
+	if userId == "" {
+		return ""
+	}
+
+	if info.CreatedBy == userId {
+		return KeyTopicOwner
+	}
+
+	for _, role := range info.Roles {
+		if role.UserId == userId {
+			return role.Role
+		}
+	}
+
+	return ""
+}
+
+// owners can do anything in their topic, maintainers everything but manage roles and moderators only moderate
+func topicRoleGrants(role, right string) bool {
+	switch role {
+	case KeyTopicOwner:
+		return true
+	case KeyTopicMaintainer:
+		return right != KeyRightManage
+	case KeyTopicModerator:
+		return right == KeyRightModerate
+	}
+
+	return false
+}
+
+func hasTopicRight(db *bolt.DB, topicId, userId, right string) (allowed bool) {
+	_ = db.View(func(tx *bolt.Tx) error {
+		allowed = hasTopicRightRx(tx, topicId, userId, right)
+		return nil
+	})
+
 	return
 }
+
+func hasTopicRightRx(tx *bolt.Tx, topicId, userId, right string) bool {
+	info, err := getTopicInfoRx(tx, topicId)
+	if err != nil {
+		return false
+	}
+
+	return topicRoleGrants(topicRole(info, userId), right)
+}
+
+func getTopicRoles(db *bolt.DB, topicId string) (response []openapi.TopicRole, err error) {
+	err = db.View(func(tx *bolt.Tx) error {
+		response, err = getTopicRolesRx(tx, topicId)
+		return err
+	})
+
+	return
+}
+
+// the creator is listed first as the owner
+func getTopicRolesRx(tx *bolt.Tx, topicId string) (response []openapi.TopicRole, err error) {
+	info, err := getTopicInfoRx(tx, topicId)
+	if err != nil {
+		return
+	}
+
+	response = make([]openapi.TopicRole, 0, len(info.Roles)+1)
+	if info.CreatedBy != "" {
+		response = append(response, openapi.TopicRole{UserId: info.CreatedBy, Role: KeyTopicOwner})
+	}
+
+	response = append(response, info.Roles...)
+
+	return
+}
+
+func updateTopicRole(db *bolt.DB, topicId string, request openapi.TopicRole) (response []openapi.TopicRole, err error) {
+	err = db.Update(func(tx *bolt.Tx) error {
+		response, err = updateTopicRoleTx(tx, topicId, request)
+		return err
+	})
+
+	return
+}
+
+// a user has at most one role in a topic so this replaces any role they had
+func updateTopicRoleTx(tx *bolt.Tx, topicId string, request openapi.TopicRole) (response []openapi.TopicRole, err error) {
+	switch request.Role {
+	case KeyTopicOwner, KeyTopicMaintainer, KeyTopicModerator:
+	default:
+		return response, fmt.Errorf("unknown topic role %s", request.Role)
+	}
+
+	info, err := getTopicInfoRx(tx, topicId)
+	if err != nil {
+		return
+	}
+
+	if info.CreatedBy == request.UserId {
+		return response, fmt.Errorf("the creator is always an owner")
+	}
+
+	_, err = getUserRx(tx, request.UserId)
+	if err != nil {
+		return
+	}
+
+	info.Roles = removeTopicRole(info.Roles, request.UserId)
+	info.Roles = append(info.Roles, request)
+
+	err = putTopicInfoTx(tx, info)
+	if err != nil {
+		return
+	}
+
+	return getTopicRolesRx(tx, topicId)
+}
+
+func deleteTopicRole(db *bolt.DB, topicId, userId string) (err error) {
+	err = db.Update(func(tx *bolt.Tx) error {
+		err = deleteTopicRoleTx(tx, topicId, userId)
+		return err
+	})
+
+	return
+}
+
+func deleteTopicRoleTx(tx *bolt.Tx, topicId, userId string) (err error) {
+	info, err := getTopicInfoRx(tx, topicId)
+	if err != nil {
+		return
+	}
+
+	if info.CreatedBy == userId {
+		return fmt.Errorf("the creator is always an owner")
+	}
+
+	info.Roles = removeTopicRole(info.Roles, userId)
+
+	return putTopicInfoTx(tx, info)
+}
+
+func removeTopicRole(roles []openapi.TopicRole, userId string) []openapi.TopicRole {
+	kept := make([]openapi.TopicRole, 0, len(roles))
+	for _, role := range roles {
+		if role.UserId != userId {
+			kept = append(kept, role)
+		}
+	}
+
+	return kept
+}
@@ -62,3 +62,184 @@ func TestDeleteTopicImpl(t *testing.T) {
 	require.Equal(t, 0, len(afterDelete))
 
 }
+
+func TestTopicVisibilityImpl(t *testing.T) {
+
+	lgr.Printf("INFO TestTopicVisibilityImpl")
+	t.Log("INFO TestTopicVisibilityImpl")
+	clock := TestClock{}
+	db, dbTearDown := OpenTestDB("TopicVisibilityImpl")
+	defer dbTearDown()
+
+	users, topics, _, err := CreateTestData(db, &clock, 3, 2, 1)
+	require.Nil(t, err)
+
+	info, err := getTopicInfo(db, topics[0])
+	require.Nil(t, err)
+	creator := info.CreatedBy
+	require.NotEmpty(t, creator)
+
+	var member, outsider string
+	for _, id := range users {
+		err = UpdateUserRoleAndReputation(db, id, false, 0)
+		require.Nil(t, err)
+		if id == creator {
+			continue
+		}
+		if member == "" {
+			member = id
+		} else {
+			outsider = id
+		}
+	}
+
+	group, err := postGroup(db, openapi.Group{Name: "class", Members: []string{member}}, creator)
+	require.Nil(t, err)
+
+	err = updateTopic(db, openapi.Topic{Title: topics[0], Visibility: "secret"})
+	require.NotNil(t, err)
+
+	err = updateTopic(db, openapi.Topic{Title: topics[0], Visibility: KeyVisibilityGroup, AllowedGroups: []string{"missing"}})
+	require.NotNil(t, err)
+
+	err = updateTopic(db, openapi.Topic{Title: topics[0], Visibility: KeyVisibilityGroup, AllowedGroups: []string{group.Id}})
+	require.Nil(t, err)
+
+	// a request without the access lists leaves them alone
+	err = updateTopic(db, openapi.Topic{Title: topics[0], Categories: []string{}})
+	require.Nil(t, err)
+
+	info, err = getTopicInfo(db, topics[0])
+	require.Nil(t, err)
+	require.Equal(t, KeyVisibilityGroup, info.Visibility)
+	require.Equal(t, []string{group.Id}, info.AllowedGroups)
+
+	for _, viewer := range []string{creator, member} {
+		visible, err := topicVisible(db, topics[0], viewer)
+		require.Nil(t, err)
+		require.True(t, visible)
+
+		listed, err := getVisibleTopics(db, viewer)
+		require.Nil(t, err)
+		require.Equal(t, 2, len(listed))
+	}
+
+	for _, viewer := range []string{outsider, ""} {
+		visible, err := topicVisible(db, topics[0], viewer)
+		require.Nil(t, err)
+		require.False(t, visible)
+
+		listed, err := getVisibleTopics(db, viewer)
+		require.Nil(t, err)
+		require.Equal(t, 1, len(listed))
+		require.Equal(t, topics[1], listed[0].Title)
+	}
+
+	// the creator made nodes in both topics, the outsider only sees the public one
+	creatorForOutsider, err := getUserForViewer(db, creator, outsider)
+	require.Nil(t, err)
+	for _, created := range creatorForOutsider.Created {
+		require.Equal(t, topics[1], created.Topic)
+	}
+
+	creatorForMember, err := getUserForViewer(db, creator, member)
+	require.Nil(t, err)
+	require.Greater(t, len(creatorForMember.Created), len(creatorForOutsider.Created))
+
+	// unlisted topics can be opened by anyone but are only listed for those with access
+	err = updateTopic(db, openapi.Topic{Title: topics[0], Visibility: KeyVisibilityUnlisted})
+	require.Nil(t, err)
+
+	visible, err := topicVisible(db, topics[0], outsider)
+	require.Nil(t, err)
+	require.True(t, visible)
+
+	listed, err := getVisibleTopics(db, outsider)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(listed))
+
+	listed, err = getVisibleTopics(db, creator)
+	require.Nil(t, err)
+	require.Equal(t, 2, len(listed))
+}
+
+func TestTopicRolesImpl(t *testing.T) {
+
+	lgr.Printf("INFO TestTopicRolesImpl")
+	t.Log("INFO TestTopicRolesImpl")
+	clock := TestClock{}
+	db, dbTearDown := OpenTestDB("TopicRolesImpl")
+	defer dbTearDown()
+
+	users, topics, _, err := CreateTestData(db, &clock, 3, 2, 1)
+	require.Nil(t, err)
+
+	info, err := getTopicInfo(db, topics[0])
+	require.Nil(t, err)
+	creator := info.CreatedBy
+
+	var others []string
+	for _, id := range users {
+		if id != creator {
+			others = append(others, id)
+		}
+	}
+
+	roles, err := getTopicRoles(db, topics[0])
+	require.Nil(t, err)
+	require.Equal(t, []openapi.TopicRole{{UserId: creator, Role: KeyTopicOwner}}, roles)
+
+	_, err = updateTopicRole(db, topics[0], openapi.TopicRole{UserId: others[0], Role: "boss"})
+	require.NotNil(t, err)
+
+	_, err = updateTopicRole(db, topics[0], openapi.TopicRole{UserId: "missing", Role: KeyTopicMaintainer})
+	require.NotNil(t, err)
+
+	_, err = updateTopicRole(db, topics[0], openapi.TopicRole{UserId: creator, Role: KeyTopicModerator})
+	require.NotNil(t, err)
+
+	_, err = updateTopicRole(db, topics[0], openapi.TopicRole{UserId: others[0], Role: KeyTopicModerator})
+	require.Nil(t, err)
+
+	roles, err = updateTopicRole(db, topics[0], openapi.TopicRole{UserId: others[0], Role: KeyTopicMaintainer})
+	require.Nil(t, err)
+	require.Equal(t, 2, len(roles))
+	require.Equal(t, KeyTopicMaintainer, roles[1].Role)
+
+	_, err = updateTopicRole(db, topics[0], openapi.TopicRole{UserId: others[1], Role: KeyTopicModerator})
+	require.Nil(t, err)
+
+	// roles only count in their own topic
+	require.True(t, hasTopicRight(db, topics[0], creator, KeyRightManage))
+	require.True(t, hasTopicRight(db, topics[0], others[0], KeyRightDelete))
+	require.False(t, hasTopicRight(db, topics[0], others[0], KeyRightManage))
+	require.False(t, hasTopicRight(db, topics[1], others[0], KeyRightDelete))
+	require.True(t, hasTopicRight(db, topics[0], others[1], KeyRightModerate))
+	require.False(t, hasTopicRight(db, topics[0], others[1], KeyRightEdit))
+
+	// a role is enough to see a private topic
+	err = updateTopic(db, openapi.Topic{Title: topics[0], Visibility: KeyVisibilityPrivate})
+	require.Nil(t, err)
+
+	err = UpdateUserRoleAndReputation(db, others[1], false, 0)
+	require.Nil(t, err)
+
+	visible, err := topicVisible(db, topics[0], others[1])
+	require.Nil(t, err)
+	require.True(t, visible)
+
+	// updating the topic keeps its roles
+	roles, err = getTopicRoles(db, topics[0])
+	require.Nil(t, err)
+	require.Equal(t, 3, len(roles))
+
+	err = deleteTopicRole(db, topics[0], creator)
+	require.NotNil(t, err)
+
+	err = deleteTopicRole(db, topics[0], others[1])
+	require.Nil(t, err)
+
+	visible, err = topicVisible(db, topics[0], others[1])
+	require.Nil(t, err)
+	require.False(t, visible)
+}
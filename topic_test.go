@@ -2,9 +2,13 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"net/url"
 	"testing"
+	"time"
 
 	openapi "github.com/SpyLime/flowBackend/go"
 	"github.com/stretchr/testify/require"
@@ -111,3 +115,335 @@ func TestAddTopic(t *testing.T) {
 	require.Equal(t, 1, len(nonEmptyTopics))
 
 }
+
+func TestPrivateTopicHidden(t *testing.T) {
+	clock := TestClock{}
+	db, tearDown := FullStartTestServer("PrivateTopicHidden", 8088, "")
+	defer tearDown()
+
+	users, topics, nodesAndEdges, err := CreateTestData(db, &clock, 3, 2, 0)
+	require.Nil(t, err)
+
+	info, err := getTopicInfo(db, topics[0])
+	require.Nil(t, err)
+
+	public, err := getTopicInfo(db, topics[1])
+	require.Nil(t, err)
+
+	// the outsider doesn't own either topic
+	var outsider string
+	for _, id := range users {
+		if id != info.CreatedBy && id != public.CreatedBy {
+			outsider = id
+		}
+	}
+
+	err = UpdateUserRoleAndReputation(db, outsider, false, 0)
+	require.Nil(t, err)
+	SetTestLoginUser(outsider)
+
+	err = updateTopic(db, openapi.Topic{Title: topics[0], Visibility: KeyVisibilityPrivate})
+	require.Nil(t, err)
+
+	client := &http.Client{}
+
+	req, _ := http.NewRequest(http.MethodGet, "http://127.0.0.1:8088/api/v1/topic", nil)
+
+	resp, err := client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 200, resp.StatusCode)
+
+	var data []openapi.Topic
+	decoder := json.NewDecoder(resp.Body)
+	_ = decoder.Decode(&data)
+	require.Equal(t, 1, len(data))
+	require.Equal(t, topics[1], data[0].Title)
+
+	req, _ = http.NewRequest(http.MethodGet, "http://127.0.0.1:8088/api/v1/map/"+topics[0], nil)
+
+	resp, err = client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 404, resp.StatusCode)
+
+	var rootId time.Time
+	for _, nodeIds := range nodesAndEdges {
+		node, err := getNode(db, nodeIds.SourceId.Format(time.RFC3339Nano), topics[0])
+		if err == nil {
+			rootId = node.Id
+		}
+	}
+	require.False(t, rootId.IsZero())
+
+	params := url.Values{}
+	params.Add("nodeId", rootId.Format(time.RFC3339Nano))
+	params.Add("tid", topics[0])
+
+	req, _ = http.NewRequest(http.MethodGet, fmt.Sprintf("%s?%s", "http://127.0.0.1:8088/api/v1/node", params.Encode()), nil)
+
+	resp, err = client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 404, resp.StatusCode)
+
+	// reputation doesn't let the outsider open the topic to everyone
+	err = UpdateUserRoleAndReputation(db, outsider, false, KeyReputationDeleter)
+	require.Nil(t, err)
+
+	marshal, err := json.Marshal(openapi.Topic{Title: topics[0], Visibility: KeyVisibilityPublic, AllowedUsers: []string{outsider}})
+	require.Nil(t, err)
+
+	req, _ = http.NewRequest(http.MethodPut, "http://127.0.0.1:8088/api/v1/topic", bytes.NewBuffer(marshal))
+
+	resp, err = client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 404, resp.StatusCode)
+
+	// nor change who sees a topic they can see, its categories are still theirs to change
+	marshal, err = json.Marshal(openapi.Topic{Title: topics[1], Visibility: KeyVisibilityPrivate})
+	require.Nil(t, err)
+
+	req, _ = http.NewRequest(http.MethodPut, "http://127.0.0.1:8088/api/v1/topic", bytes.NewBuffer(marshal))
+
+	resp, err = client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 401, resp.StatusCode)
+
+	marshal, err = json.Marshal(openapi.Topic{Title: topics[1], Categories: []string{}})
+	require.Nil(t, err)
+
+	req, _ = http.NewRequest(http.MethodPut, "http://127.0.0.1:8088/api/v1/topic", bytes.NewBuffer(marshal))
+
+	resp, err = client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 200, resp.StatusCode)
+
+	info, err = getTopicInfo(db, topics[0])
+	require.Nil(t, err)
+	require.Equal(t, KeyVisibilityPrivate, info.Visibility)
+	require.Empty(t, info.AllowedUsers)
+
+	// the creator still sees everything
+	SetTestLoginUser(info.CreatedBy)
+
+	req, _ = http.NewRequest(http.MethodGet, "http://127.0.0.1:8088/api/v1/map/"+topics[0], nil)
+
+	resp, err = client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 200, resp.StatusCode)
+}
+
+func TestTopicRoles(t *testing.T) {
+	clock := TestClock{}
+	db, tearDown := FullStartTestServer("TopicRoles", 8088, "")
+	defer tearDown()
+
+	users, topics, nodesAndEdges, err := CreateTestData(db, &clock, 3, 2, 1)
+	require.Nil(t, err)
+
+	info, err := getTopicInfo(db, topics[0])
+	require.Nil(t, err)
+
+	var others []string
+	for _, id := range users {
+		err = UpdateUserRoleAndReputation(db, id, false, 0)
+		require.Nil(t, err)
+		if id != info.CreatedBy {
+			others = append(others, id)
+		}
+	}
+
+	client := &http.Client{}
+	roleUrl := "http://127.0.0.1:8088/api/v1/topic/" + topics[0] + "/role"
+
+	// someone without a role can't hand them out
+	SetTestLoginUser(others[0])
+
+	marshal, err := json.Marshal(openapi.TopicRole{UserId: others[0], Role: KeyTopicMaintainer})
+	require.Nil(t, err)
+
+	req, _ := http.NewRequest(http.MethodPut, roleUrl, bytes.NewBuffer(marshal))
+
+	resp, err := client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 401, resp.StatusCode)
+
+	// the creator owns the topic
+	SetTestLoginUser(info.CreatedBy)
+
+	req, _ = http.NewRequest(http.MethodPut, roleUrl, bytes.NewBuffer(marshal))
+
+	resp, err = client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 200, resp.StatusCode)
+
+	var roles []openapi.TopicRole
+	decoder := json.NewDecoder(resp.Body)
+	err = decoder.Decode(&roles)
+	require.Nil(t, err)
+	require.Equal(t, 2, len(roles))
+
+	req, _ = http.NewRequest(http.MethodGet, roleUrl, nil)
+
+	resp, err = client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 200, resp.StatusCode)
+
+	// find the edge made for the node in each topic
+	edgeIds := map[string]string{}
+	for _, nodeIds := range nodesAndEdges {
+		if nodeIds.TargetId.IsZero() {
+			continue
+		}
+		for _, topic := range topics {
+			if _, err := getNode(db, nodeIds.TargetId.Format(time.RFC3339Nano), topic); err == nil {
+				edgeIds[topic] = nodeIds.SourceId.Format(time.RFC3339Nano) + "-" + nodeIds.TargetId.Format(time.RFC3339Nano)
+			}
+		}
+	}
+
+	// the maintainer can now delete an edge without any reputation
+	SetTestLoginUser(others[0])
+
+	params := url.Values{}
+	params.Add("edgeId", edgeIds[topics[0]])
+
+	req, _ = http.NewRequest(http.MethodDelete,
+		"http://127.0.0.1:8088/api/v1/map/"+topics[0]+"/edge?"+params.Encode(), nil)
+
+	resp, err = client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 204, resp.StatusCode)
+
+	// but not in another topic
+	params = url.Values{}
+	params.Add("edgeId", edgeIds[topics[1]])
+
+	req, _ = http.NewRequest(http.MethodDelete,
+		"http://127.0.0.1:8088/api/v1/map/"+topics[1]+"/edge?"+params.Encode(), nil)
+
+	resp, err = client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 401, resp.StatusCode)
+
+	// and maintainers can't hand out roles
+	req, _ = http.NewRequest(http.MethodDelete, roleUrl+"/"+url.PathEscape(others[0]), nil)
+
+	resp, err = client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 401, resp.StatusCode)
+
+	SetTestLoginUser(info.CreatedBy)
+
+	req, _ = http.NewRequest(http.MethodDelete, roleUrl+"/"+url.PathEscape(others[0]), nil)
+
+	resp, err = client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 204, resp.StatusCode)
+}
+
+func TestTopicDeadLinks(t *testing.T) {
+	clock := TestClock{}
+	db, tearDown := FullStartTestServer("TopicDeadLinks", 8088, "")
+	defer tearDown()
+
+	users, topics, nodesAndEdges, err := CreateTestData(db, &clock, 2, 1, 1)
+	require.Nil(t, err)
+
+	adder, err := getUser(db, users[0])
+	require.Nil(t, err)
+
+	nodeId := nodesAndEdges[1].TargetId
+	for _, link := range []string{"https://example.org/gone", "https://example.org/fine"} {
+		_, err = updateNodeVideoEdit(db, &clock, openapi.NodeData{Id: nodeId, Topic: topics[0], Resources: []openapi.LinkData{{Link: link, Votes: 1}}}, adder)
+		require.Nil(t, err)
+	}
+
+	checker := &standInLinkChecker{
+		statuses: map[string]string{"https://example.org/gone": KeyLinkRemoved, "https://example.org/fine": KeyLinkOk},
+		checks:   make(map[string]int),
+	}
+	_, err = NewLinkHealthWorker(db, &clock, checker, DefaultLinkHealthConfig()).CheckLinks(context.Background())
+	require.Nil(t, err)
+
+	SetTestLoginUser(users[1])
+
+	client := &http.Client{}
+	req, _ := http.NewRequest(http.MethodGet, "http://127.0.0.1:8088/api/v1/topic/"+topics[0]+"/deadLinks", nil)
+
+	resp, err := client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 200, resp.StatusCode)
+
+	var report []openapi.DeadLink
+	err = json.NewDecoder(resp.Body).Decode(&report)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(report))
+	require.Equal(t, "https://example.org/gone", report[0].Resource.Link)
+	require.Equal(t, KeyLinkRemoved, report[0].Resource.Status)
+
+	req, _ = http.NewRequest(http.MethodGet, "http://127.0.0.1:8088/api/v1/topic/nothing/deadLinks", nil)
+
+	resp, err = client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 404, resp.StatusCode)
+}
+
+func TestTopicSharedVideos(t *testing.T) {
+	clock := TestClock{}
+	db, tearDown := FullStartTestServer("TopicSharedVideos", 8088, "")
+	defer tearDown()
+
+	users, topics, nodesAndEdges, err := CreateTestData(db, &clock, 2, 1, 2)
+	require.Nil(t, err)
+
+	adder, err := getUser(db, users[0])
+	require.Nil(t, err)
+
+	for _, nodeIds := range nodesAndEdges[1:] {
+		_, err = updateNodeVideoEdit(db, &clock, openapi.NodeData{Id: nodeIds.TargetId, Topic: topics[0], Resources: []openapi.LinkData{{Link: "https://youtu.be/dQw4w9WgXcQ", Votes: 1}}}, adder)
+		require.Nil(t, err)
+	}
+
+	SetTestLoginUser(users[1])
+
+	client := &http.Client{}
+	req, _ := http.NewRequest(http.MethodGet, "http://127.0.0.1:8088/api/v1/topic/"+topics[0]+"/sharedVideos", nil)
+
+	resp, err := client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 200, resp.StatusCode)
+
+	var report []openapi.SharedVideo
+	err = json.NewDecoder(resp.Body).Decode(&report)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(report))
+	require.Equal(t, 2, len(report[0].Nodes))
+
+	req, _ = http.NewRequest(http.MethodGet, "http://127.0.0.1:8088/api/v1/topic/"+topics[0]+"/sharedVideos?minNodes=3", nil)
+
+	resp, err = client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 200, resp.StatusCode)
+
+	report = nil
+	err = json.NewDecoder(resp.Body).Decode(&report)
+	require.Nil(t, err)
+	require.Empty(t, report)
+}
@@ -198,6 +198,13 @@ func TwitterCallbackHandler(w http.ResponseWriter, r *http.Request, config Serve
 		// Continue anyway, as this is not critical
 	}
 
+	// Banned users don't get a token so they stay logged out
+	err = checkSanction(db, clock, user.ID, true)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
 	// Create a JWT token for the user using the existing function
 	tokenString, err := createUserToken(user, config.SecretKey)
 	if err != nil {
@@ -15,15 +15,19 @@ import (
 // This service should implement the business logic for every endpoint for the UserAPI API.
 // Include any external packages or services that will be required by this service.
 type UserAPIServiceImpl struct {
-	db    *bolt.DB
-	clock Clock
+	db     *bolt.DB
+	clock  Clock
+	policy Policy
+	watch  WatchConfig
 }
 
 // NewUserAPIService creates a default api service
-func NewUserAPIServiceImpl(db *bolt.DB, clock Clock) openapi.UserAPIServicer {
+func NewUserAPIServiceImpl(db *bolt.DB, clock Clock, policy Policy, watch WatchConfig) openapi.UserAPIServicer {
 	return &UserAPIServiceImpl{
-		db:    db,
-		clock: clock,
+		db:     db,
+		clock:  clock,
+		policy: policy,
+		watch:  watch,
 	}
 }
 
@@ -98,11 +102,18 @@ func (s *UserAPIServiceImpl) UpdateUser(ctx context.Context, User openapi.User)
 
 // GetUserByName - Get user by user name
 func (s *UserAPIServiceImpl) GetUserByName(ctx context.Context, userId string) (openapi.ImplResponse, error) {
-	response, err := getUser(s.db, userId)
+	// nobody is logged in when there is no user, activity in topics they can't see is removed
+	user, _ := ctx.Value(userInfoKey).(token.User)
+
+	response, err := getUserForViewer(s.db, userId, user.ID)
 	if err != nil {
 		return openapi.Response(400, nil), err
 	}
 
+	// the level follows the reputation even when it was set without going through a refresh
+	response.Level, response.NextLevelReputation = reputationLevel(response.Reputation)
+	response.Capabilities = s.policy.capabilities(response.Reputation)
+
 	return openapi.Response(200, response), nil
 
 }
@@ -132,6 +143,128 @@ func (s *UserAPIServiceImpl) DeleteUser(ctx context.Context, userId string) (ope
 	return openapi.Response(400, nil), err
 }
 
+// GetUserReputation - get why the reputation of the user changed, newest first
+func (s *UserAPIServiceImpl) GetUserReputation(ctx context.Context, userId string) (openapi.ImplResponse, error) {
+	user, ok := ctx.Value(userInfoKey).(token.User)
+	if !ok {
+		return openapi.Response(401, nil), errors.New("unauthorized: user not found in context")
+	}
+
+	userDetails, err := getUser(s.db, user.ID)
+	if err != nil {
+		return openapi.Response(401, nil), err
+	}
+
+	// only admins see who voted
+	isAdmin := userDetails.Role == KeyAdmin
+	if !isAdmin && userId != user.ID {
+		return openapi.Response(401, nil), errors.New("unauthorized: users can only see their own reputation history")
+	}
+
+	response, err := getReputationHistory(s.db, userId, isAdmin)
+	if err != nil {
+		return openapi.Response(404, nil), err
+	}
+
+	return openapi.Response(200, response), nil
+}
+
+// GetUserNotifications - get the notifications of the user, newest first
+func (s *UserAPIServiceImpl) GetUserNotifications(ctx context.Context, userId string) (openapi.ImplResponse, error) {
+	user, ok := ctx.Value(userInfoKey).(token.User)
+	if !ok {
+		return openapi.Response(401, nil), errors.New("unauthorized: user not found in context")
+	}
+
+	if userId != user.ID {
+		return openapi.Response(401, nil), errors.New("unauthorized: users can only see their own notifications")
+	}
+
+	response, err := getNotifications(s.db, userId)
+	if err != nil {
+		return openapi.Response(400, nil), err
+	}
+
+	return openapi.Response(200, response), nil
+}
+
+// UpdateUserNotificationsRead - mark every notification of the user as read
+func (s *UserAPIServiceImpl) UpdateUserNotificationsRead(ctx context.Context, userId string) (openapi.ImplResponse, error) {
+	user, ok := ctx.Value(userInfoKey).(token.User)
+	if !ok {
+		return openapi.Response(401, nil), errors.New("unauthorized: user not found in context")
+	}
+
+	if userId != user.ID {
+		return openapi.Response(401, nil), errors.New("unauthorized: users can only read their own notifications")
+	}
+
+	err := markNotificationsRead(s.db, userId)
+	if err != nil {
+		return openapi.Response(400, nil), err
+	}
+
+	return openapi.Response(204, nil), nil
+}
+
+// GetUserWatches - get the watch progress of the user, the last watched first
+func (s *UserAPIServiceImpl) GetUserWatches(ctx context.Context, userId string) (openapi.ImplResponse, error) {
+	user, ok := ctx.Value(userInfoKey).(token.User)
+	if !ok {
+		return openapi.Response(401, nil), errors.New("unauthorized: user not found in context")
+	}
+
+	if userId != user.ID {
+		return openapi.Response(401, nil), errors.New("unauthorized: users can only see their own watches")
+	}
+
+	response, err := getWatches(s.db, userId)
+	if err != nil {
+		return openapi.Response(400, nil), err
+	}
+
+	return openapi.Response(200, response), nil
+}
+
+// UpdateUserWatch - record how far the user got into a video
+func (s *UserAPIServiceImpl) UpdateUserWatch(ctx context.Context, userId string, progress openapi.WatchProgress) (openapi.ImplResponse, error) {
+	user, ok := ctx.Value(userInfoKey).(token.User)
+	if !ok {
+		return openapi.Response(401, nil), errors.New("unauthorized: user not found in context")
+	}
+
+	if userId != user.ID {
+		return openapi.Response(401, nil), errors.New("unauthorized: users can only record their own watches")
+	}
+
+	response, err := putWatchProgress(s.db, s.clock, userId, progress, s.watch.FinishAt)
+	if err != nil {
+		return openapi.Response(400, nil), err
+	}
+
+	return openapi.Response(200, response), nil
+}
+
+// GetLeaderboard - get the top contributors globally or in a topic over a time window
+func (s *UserAPIServiceImpl) GetLeaderboard(ctx context.Context, topicId string, window string, limit int32) (openapi.ImplResponse, error) {
+	// nobody is logged in when there is no user, they only see public topics
+	user, _ := ctx.Value(userInfoKey).(token.User)
+
+	if topicId != "" {
+		visible, err := topicVisible(s.db, topicId, user.ID)
+		if err != nil || !visible {
+			return openapi.Response(404, nil), errors.New("topic not found")
+		}
+	}
+
+	response, err := getLeaderboard(s.db, s.clock, topicId, window, limit)
+	if err != nil {
+		return openapi.Response(400, nil), err
+	}
+
+	return openapi.Response(200, response), nil
+}
+
 // AuthUser - return authenticated user details
 func (s *UserAPIServiceImpl) AuthUser(ctx context.Context) (openapi.ImplResponse, error) {
 	// For the OpenAPI implementation, we'll just return a response indicating the user is not authenticated
@@ -134,6 +134,116 @@ func getUserRx(tx *bolt.Tx, userId string) (response openapi.User, err error) {
 	return
 }
 
+func getUserForViewer(db *bolt.DB, userId, viewerId string) (response openapi.User, err error) {
+	err = db.View(func(tx *bolt.Tx) error {
+		response, err = getUserForViewerRx(tx, userId, viewerId)
+		return err
+	})
+
+	return
+}
+
+// returns the user with any activity in topics hidden from the viewer removed
+func getUserForViewerRx(tx *bolt.Tx, userId, viewerId string) (response openapi.User, err error) {
+	response, err = getUserRx(tx, userId)
+	if err != nil {
+		return
+	}
+
+	hidden, err := hiddenTopicsRx(tx, viewerId)
+	if err != nil || len(hidden) == 0 {
+		return
+	}
+
+	response.BattleTestedUp = filterHiddenActivity(response.BattleTestedUp, hidden)
+	response.BattleTestedDown = filterHiddenActivity(response.BattleTestedDown, hidden)
+	response.FreshUp = filterHiddenActivity(response.FreshUp, hidden)
+	response.FreshDown = filterHiddenActivity(response.FreshDown, hidden)
+	response.Edited = filterHiddenActivity(response.Edited, hidden)
+	response.Created = filterHiddenActivity(response.Created, hidden)
+
+	// links don't know their topic so drop any link that is used in a hidden topic
+	hiddenLinks, err := topicLinksRx(tx, hidden)
+	if err != nil {
+		return
+	}
+
+	linked := make([]openapi.LinkData, 0, len(response.Linked))
+	for _, link := range response.Linked {
+		if !hiddenLinks[link.Link] {
+			linked = append(linked, link)
+		}
+	}
+	response.Linked = linked
+
+	response.VideoUp = filterHiddenLinks(response.VideoUp, hiddenLinks)
+	response.VideoDown = filterHiddenLinks(response.VideoDown, hiddenLinks)
+
+	videoVotes := make([]openapi.VideoVote, 0, len(response.VideoVotes))
+	for _, vote := range response.VideoVotes {
+		if !hidden[vote.Topic] {
+			videoVotes = append(videoVotes, vote)
+		}
+	}
+	response.VideoVotes = videoVotes
+
+	return
+}
+
+func filterHiddenActivity(list []openapi.ResponseUserInfoInner, hidden map[string]bool) []openapi.ResponseUserInfoInner {
+	filtered := make([]openapi.ResponseUserInfoInner, 0, len(list))
+	for _, item := range list {
+		if !hidden[item.Topic] {
+			filtered = append(filtered, item)
+		}
+	}
+
+	return filtered
+}
+
+func filterHiddenLinks(list []string, hiddenLinks map[string]bool) []string {
+	filtered := make([]string, 0, len(list))
+	for _, link := range list {
+		if !hiddenLinks[link] {
+			filtered = append(filtered, link)
+		}
+	}
+
+	return filtered
+}
+
+// returns every video link used by a node in the topics
+func topicLinksRx(tx *bolt.Tx, topics map[string]bool) (links map[string]bool, err error) {
+	links = make(map[string]bool)
+
+	for topicId := range topics {
+		topicBucket := tx.Bucket([]byte(KeyTopics)).Bucket([]byte(topicId))
+		if topicBucket == nil {
+			continue
+		}
+
+		nodesBucket := topicBucket.Bucket([]byte(KeyNodes))
+		if nodesBucket == nil {
+			continue
+		}
+
+		c := nodesBucket.Cursor()
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			var node openapi.NodeData
+			err = json.Unmarshal(v, &node)
+			if err != nil {
+				return
+			}
+
+			for _, link := range node.Resources {
+				links[link.Link] = true
+			}
+		}
+	}
+
+	return
+}
+
 func postUser(db *bolt.DB, user openapi.User) (userId string, err error) {
 	err = db.Update(func(tx *bolt.Tx) error {
 		userId, err = postUserTx(tx, user)
@@ -187,6 +297,26 @@ func deleteUserTx(tx *bolt.Tx, userId string) (err error) {
 	}
 
 	err = usersBucket.Delete([]byte(userId))
+	if err != nil {
+		return
+	}
+
+	err = deleteReputationHistoryTx(tx, userId)
+	if err != nil {
+		return
+	}
+
+	err = deleteNotificationsTx(tx, userId)
+	if err != nil {
+		return
+	}
+
+	err = deleteAchievementsTx(tx, userId)
+	if err != nil {
+		return
+	}
+
+	err = deleteWatchesTx(tx, userId)
 
 	return
 
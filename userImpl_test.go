@@ -27,6 +27,10 @@ func TestPostGetUser(t *testing.T) {
 
 	require.NotEqual(t, response.Username, user.Username)
 
+	// a database without topics hides nothing
+	_, err = getUserForViewer(db, userId, "")
+	require.Nil(t, err)
+
 }
 
 func TestUpdateUserImpl(t *testing.T) {
@@ -137,3 +137,280 @@ func TestDeleteUser(t *testing.T) {
 	require.NotNil(t, err)
 
 }
+
+func TestUserReputation(t *testing.T) {
+	clock := TestClock{}
+	db, tearDown := FullStartTestServer("userReputation", 8088, "")
+	defer tearDown()
+
+	users, _, _, err := CreateTestData(db, &clock, 3, 0, 0)
+	require.Nil(t, err)
+
+	admin, member, other := users[0], users[1], users[2]
+	err = UpdateUserRoleAndReputation(db, member, false, 0)
+	require.Nil(t, err)
+	err = UpdateUserRoleAndReputation(db, other, false, 0)
+	require.Nil(t, err)
+
+	client := &http.Client{}
+	reputationURL := "http://127.0.0.1:8088/api/v1/user/" + url.PathEscape(member) + "/reputation"
+
+	// only admins adjust reputation
+	SetTestLoginUser(other)
+
+	marshal, err := json.Marshal(openapi.ReputationAdjustment{Delta: 10, Reason: "helped at the open mat"})
+	require.Nil(t, err)
+
+	req, _ := http.NewRequest(http.MethodPut, "http://127.0.0.1:8088/api/v1/admin/user/"+url.PathEscape(member)+"/reputation", bytes.NewBuffer(marshal))
+
+	resp, err := client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 401, resp.StatusCode)
+
+	SetTestLoginUser(admin)
+
+	req, _ = http.NewRequest(http.MethodPut, "http://127.0.0.1:8088/api/v1/admin/user/"+url.PathEscape(member)+"/reputation", bytes.NewBuffer(marshal))
+
+	resp, err = client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 200, resp.StatusCode)
+
+	// other users can't see the history
+	SetTestLoginUser(other)
+
+	req, _ = http.NewRequest(http.MethodGet, reputationURL, nil)
+
+	resp, err = client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 401, resp.StatusCode)
+
+	SetTestLoginUser(member)
+
+	resp, err = client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 200, resp.StatusCode)
+
+	var history openapi.ReputationHistory
+	err = json.NewDecoder(resp.Body).Decode(&history)
+	require.Nil(t, err)
+	require.Equal(t, int32(10), history.Reputation)
+	require.Equal(t, 1, len(history.Events))
+	require.Equal(t, "helped at the open mat", history.Events[0].Reason)
+	require.Empty(t, history.Events[0].VoterId)
+
+	SetTestLoginUser(admin)
+
+	resp, err = client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 200, resp.StatusCode)
+
+	err = json.NewDecoder(resp.Body).Decode(&history)
+	require.Nil(t, err)
+	require.Equal(t, admin, history.Events[0].VoterId)
+}
+
+func TestUserNotifications(t *testing.T) {
+	clock := TestClock{}
+	db, tearDown := FullStartTestServer("userNotifications", 8088, "")
+	defer tearDown()
+
+	users, _, _, err := CreateTestData(db, &clock, 2, 0, 0)
+	require.Nil(t, err)
+
+	admin, member := users[0], users[1]
+	err = UpdateUserRoleAndReputation(db, member, false, 0)
+	require.Nil(t, err)
+
+	_, err = adjustReputation(db, &clock, member, admin, openapi.ReputationAdjustment{Delta: KeyReputationEditor, Reason: "good maps"})
+	require.Nil(t, err)
+
+	client := &http.Client{}
+
+	// the level, next threshold and what the reputation allows are shown to everyone
+	req, _ := http.NewRequest(http.MethodGet, "http://127.0.0.1:8088/api/v1/user/"+url.PathEscape(member), nil)
+
+	resp, err := client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 200, resp.StatusCode)
+
+	var user openapi.User
+	err = json.NewDecoder(resp.Body).Decode(&user)
+	require.Nil(t, err)
+	require.Equal(t, KeyLevelEditor, user.Level)
+	require.Equal(t, int32(KeyReputationDeleter), user.NextLevelReputation)
+	require.Contains(t, user.Capabilities, KeyActionReview)
+	require.NotContains(t, user.Capabilities, KeyActionAddTopic)
+	require.NotContains(t, user.Capabilities, KeyActionSanction)
+
+	notificationsURL := "http://127.0.0.1:8088/api/v1/user/" + url.PathEscape(member) + "/notifications"
+
+	// notifications are private, even from admins
+	SetTestLoginUser(admin)
+
+	req, _ = http.NewRequest(http.MethodGet, notificationsURL, nil)
+
+	resp, err = client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 401, resp.StatusCode)
+
+	SetTestLoginUser(member)
+
+	resp, err = client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 200, resp.StatusCode)
+
+	var notifications []openapi.Notification
+	err = json.NewDecoder(resp.Body).Decode(&notifications)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(notifications))
+	require.Equal(t, KeyLevelEditor, notifications[0].Level)
+	require.False(t, notifications[0].Read)
+
+	req, _ = http.NewRequest(http.MethodPut, notificationsURL+"/read", nil)
+
+	resp, err = client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 204, resp.StatusCode)
+
+	notifications, err = getNotifications(db, member)
+	require.Nil(t, err)
+	require.True(t, notifications[0].Read)
+}
+
+func TestUserWatches(t *testing.T) {
+	clock := TestClock{}
+	db, tearDown := FullStartTestServer("userWatches", 8088, "")
+	defer tearDown()
+
+	users, topics, nodesAndEdges, err := CreateTestData(db, &clock, 2, 1, 1)
+	require.Nil(t, err)
+
+	adder, err := getUser(db, users[0])
+	require.Nil(t, err)
+
+	_, err = updateNodeVideoEdit(db, &clock, openapi.NodeData{Id: nodesAndEdges[1].TargetId, Topic: topics[0], Resources: []openapi.LinkData{{Link: "https://www.youtube.com/watch?v=dQw4w9WgXcQ", Votes: 1}}}, adder)
+	require.Nil(t, err)
+
+	client := &http.Client{}
+	watchesURL := "http://127.0.0.1:8088/api/v1/user/" + url.PathEscape(users[1]) + "/watches"
+
+	marshal, err := json.Marshal(openapi.WatchProgress{Link: "https://www.youtube.com/watch?v=dQw4w9WgXcQ", Percent: 92.5, Position: 300})
+	require.Nil(t, err)
+
+	// watches are private, even from admins
+	SetTestLoginUser(users[0])
+
+	req, _ := http.NewRequest(http.MethodPut, watchesURL, bytes.NewBuffer(marshal))
+
+	resp, err := client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 401, resp.StatusCode)
+
+	SetTestLoginUser(users[1])
+
+	req, _ = http.NewRequest(http.MethodPut, watchesURL, bytes.NewBuffer(marshal))
+
+	resp, err = client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 200, resp.StatusCode)
+
+	var progress openapi.WatchProgress
+	err = json.NewDecoder(resp.Body).Decode(&progress)
+	require.Nil(t, err)
+	require.True(t, progress.Finished)
+
+	req, _ = http.NewRequest(http.MethodPut, watchesURL, bytes.NewBufferString(`{"link":"https://www.youtube.com/watch?v=dQw4w9WgXcQ","percent":150}`))
+
+	resp, err = client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 400, resp.StatusCode)
+
+	req, _ = http.NewRequest(http.MethodGet, watchesURL, nil)
+
+	resp, err = client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 200, resp.StatusCode)
+
+	var watches []openapi.WatchProgress
+	err = json.NewDecoder(resp.Body).Decode(&watches)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(watches))
+	require.Equal(t, int32(300), watches[0].Position)
+}
+
+func TestLeaderboard(t *testing.T) {
+	clock := TestClock{}
+	db, tearDown := FullStartTestServer("leaderboard", 8088, "")
+	defer tearDown()
+
+	users, topics, _, err := CreateTestData(db, &clock, 2, 1, 2)
+	require.Nil(t, err)
+
+	info, err := getTopicInfo(db, topics[0])
+	require.Nil(t, err)
+
+	creator, other := users[0], users[1]
+	if other == info.CreatedBy {
+		creator, other = other, creator
+	}
+	err = UpdateUserRoleAndReputation(db, other, false, 0)
+	require.Nil(t, err)
+
+	client := &http.Client{}
+	leaderboardURL := "http://127.0.0.1:8088/api/v1/leaderboard?window=" + KeyWindowWeek + "&topicId=" + url.QueryEscape(topics[0])
+
+	SetTestLoginUser(other)
+
+	req, _ := http.NewRequest(http.MethodGet, leaderboardURL, nil)
+
+	resp, err := client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 200, resp.StatusCode)
+
+	var board openapi.Leaderboard
+	err = json.NewDecoder(resp.Body).Decode(&board)
+	require.Nil(t, err)
+	require.Equal(t, KeyWindowWeek, board.Window)
+	require.Equal(t, 1, len(board.Entries))
+	require.Equal(t, creator, board.Entries[0].UserId)
+	require.Equal(t, int32(3), board.Entries[0].Nodes)
+
+	req, _ = http.NewRequest(http.MethodGet, "http://127.0.0.1:8088/api/v1/leaderboard?window=forever", nil)
+
+	resp, err = client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 400, resp.StatusCode)
+
+	// private topics have no leaderboard for those who can't see them
+	err = updateTopic(db, openapi.Topic{Title: topics[0], Visibility: KeyVisibilityPrivate})
+	require.Nil(t, err)
+
+	req, _ = http.NewRequest(http.MethodGet, leaderboardURL, nil)
+
+	resp, err = client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 404, resp.StatusCode)
+
+	SetTestLoginUser(creator)
+
+	resp, err = client.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 200, resp.StatusCode)
+}
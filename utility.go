@@ -18,11 +18,127 @@ const (
 	KeyTopics                = "topics"
 	KeyNodes                 = "nodes"
 	KeyEdges                 = "edges"
+	KeyInfo                  = "info"
+	KeyCategories            = "categories"
+	KeyGroups                = "groups"
+	KeyOrganizations         = "organizations"
+	KeyReputation            = "reputation"
+	KeyCredentials           = "credentials"
+	KeySchoolProvider        = "school"
+	KeyVisibilityPublic      = "public"
+	KeyVisibilityUnlisted    = "unlisted"
+	KeyVisibilityPrivate     = "private"
+	KeyVisibilityGroup       = "group"
+	KeyTopicOwner            = "owner"
+	KeyTopicMaintainer       = "maintainer"
+	KeyTopicModerator        = "moderator"
+	KeyRightEdit             = "edit"
+	KeyRightDelete           = "delete"
+	KeyRightEdge             = "edge"
+	KeyRightModerate         = "moderate"
+	KeyRightManage           = "manage"
+	KeyModeration            = "moderation"
+	KeyModerationIndex       = "moderationIndex"
+	KeyCaseOpen              = "open"
+	KeyCaseResolved          = "resolved"
+	KeyCaseDismissed         = "dismissed"
+	KeyCaseAll               = "all"
+	KeyModerationAssign      = "assign"
+	KeyModerationResolve     = "resolve"
+	KeyModerationDismiss     = "dismiss"
+	KeyModerationReopen      = "reopen"
+	KeyReasonSpam            = "spam"
+	KeyReasonOffensive       = "offensive"
+	KeyReasonIncorrect       = "incorrect"
+	KeyReasonCopyright       = "copyright"
+	KeyReasonOther           = "other"
+	KeyReasonHidden          = "hidden"
+	KeySanctions             = "sanctions"
+	KeySanctionSuspend       = "suspend"
+	KeySanctionBan           = "ban"
+	KeyAudit                 = "audit"
+	KeyAuditLift             = "lift"
+	KeyAuditExpire           = "expire"
+	KeySuggestions           = "suggestions"
+	KeySuggestionPending     = "pending"
+	KeySuggestionAccepted    = "accepted"
+	KeySuggestionRejected    = "rejected"
+	KeyRateVote              = "vote"
+	KeyRateEdit              = "edit"
+	KeyRateCreate            = "create"
+	KeyVotes                 = "votes"
+	KeyVoteBattle            = "battle"
+	KeyVoteFresh             = "fresh"
+	KeyVoteVideo             = "video"
+	KeyPatternMutual         = "mutual"
+	KeyPatternNewAccount     = "newAccount"
+	KeyPatternBurst          = "burst"
+	KeyAuditVoid             = "void"
+	KeyAuditAdjust           = "adjust"
+	KeyReputationHistory     = "reputationHistory"
+	KeySourceSuggestion      = "suggestion"
+	KeySourceAdmin           = "admin"
+	KeySourceVoid            = "void"
+	KeyNotifications         = "notifications"
+	KeyAchievements          = "achievements"
+	KeyWatches               = "watches"
+	KeyNotificationLevel     = "level"
+	KeyNotificationBadge     = "badge"
+	KeyLevelNewcomer         = "newcomer"
+	KeyLevelContributor      = "contributor"
+	KeyLevelEditor           = "editor"
+	KeyLevelDeleter          = "deleter"
+	KeyLeaderboards          = "leaderboards"
+	KeyLeaderboardGlobal     = "global"
+	KeyLeaderboardAll        = "all"
+	KeyLeaderboardDays       = "days"
+	KeyLeaderboardVideos     = "videos"
+	KeyWindowAll             = "all"
+	KeyWindowMonth           = "30d"
+	KeyWindowWeek            = "7d"
+	KeyMigrations            = "migrations"
+	KeyResourceVideo         = "video"
+	KeyResourceArticle       = "article"
+	KeyResourcePdf           = "pdf"
+	KeyResourceExercise      = "exercise"
+	KeyResourceBook          = "book"
+	KeyProviderYouTube       = "youtube"
+	KeyProviderVimeo         = "vimeo"
+	KeyProviderPeerTube      = "peertube"
+	KeyMetadata              = "metadata"
+	KeyOutboundUserAgent     = "FlowLearning/1.0 (+https://github.com/SpyLime/flowBackend)"
+	KeyLinkOk                = "ok"
+	KeyLinkRemoved           = "removed"
+	KeyLinkPrivate           = "private"
+	KeyLinkRegionBlocked     = "regionBlocked"
+	KeyLinkUnreachable       = "unreachable"
+	KeyLinkUnchecked         = "unchecked"
+	KeyReasonDeadLink        = "deadLink"
+	KeyVideoIndex            = "videoIndex"
+	KeyRankingWilson         = "wilson"
+	KeyRankingHot            = "hot"
+	KeyRankingVotes          = "votes"
+	KeyRankingNew            = "new"
 	KeyUser                  = 0
 	KeyAdmin                 = 1
 	KeyReputationDeleter     = 200
 	KeyReputationEditor      = 100
 	KeyReputationContributor = 50
+	KeyReputationSuggestion  = 2
+	KeyRingVotes             = 3                  // up votes each way before a pair is a ring
+	KeyNewAccountVotes       = 3                  // first up votes of a new account going to one creator
+	KeyNewAccountAge         = 7 * 24 * time.Hour // accounts younger than this when they first vote are new
+	KeyBurstVotes            = 5                  // votes on one node or video within the window
+	KeyBurstWindow           = 10 * time.Minute
+	KeyLeaderboardNodeScore  = 2  // points a created node adds to the score
+	KeyLeaderboardVideoScore = 5  // points a video that got up voted adds to the score
+	KeyLeaderboardKeepDays   = 30 // days of daily totals kept for the windows
+	KeyLeaderboardLimit      = 10
+	KeyLeaderboardMaxLimit   = 100
+	KeyRelatedNodesLimit     = 10
+	KeyPlaylistImportLimit   = 200
+	KeyRankingConfidence     = 1.96                // z of the 95% Wilson interval
+	KeyRankingHalfLife       = 30 * 24 * time.Hour // hot scores halve every half life
 )
 
 // Define a custom type for context keys to avoid collisions
@@ -44,3 +160,16 @@ func RandomString(n int) string {
 
 	return string(ret)
 }
+
+// RandomPassword is like RandomString but uses letters as well so it is safe to hand out as a password
+func RandomPassword(n int) string {
+	letters := "abcdefghijkmnpqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+	ret := make([]byte, n)
+	for i := 0; i < n; i++ {
+		num, _ := rand.Int(rand.Reader, big.NewInt(int64(len(letters))))
+		ret[i] = letters[num.Int64()]
+	}
+
+	return string(ret)
+}
@@ -0,0 +1,347 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	openapi "github.com/SpyLime/flowBackend/go"
+	bolt "go.etcd.io/bbolt"
+)
+
+// videoIdentity is the same for every way a video is linked and cut, it is empty for anything that isn't a video at a provider
+func videoIdentity(resource openapi.LinkData) string {
+	if resource.Provider == "" || resource.VideoId == "" {
+		return ""
+	}
+
+	if resource.Provider == KeyProviderYouTube {
+		return VideoRef{Id: resource.VideoId}.Canonical()
+	}
+
+	return resource.Link
+}
+
+// indexVideoTx records that the node has the video, the value is the link the node has it with
+func indexVideoTx(tx *bolt.Tx, topicId, nodeId string, resource openapi.LinkData) (err error) {
+	key := videoIdentity(resource)
+	if key == "" {
+		return
+	}
+
+	indexBucket, err := tx.CreateBucketIfNotExists([]byte(KeyVideoIndex))
+	if err != nil {
+		return
+	}
+
+	topicBucket, err := indexBucket.CreateBucketIfNotExists([]byte(topicId))
+	if err != nil {
+		return
+	}
+
+	videoBucket, err := topicBucket.CreateBucketIfNotExists([]byte(key))
+	if err != nil {
+		return
+	}
+
+	return videoBucket.Put([]byte(nodeId), []byte(resource.Link))
+}
+
+// unindexVideoTx forgets that the node has the video unless another segment of it is still on the node
+func unindexVideoTx(tx *bolt.Tx, topicId string, node openapi.NodeData, resource openapi.LinkData) (err error) {
+	key := videoIdentity(resource)
+	if key == "" {
+		return
+	}
+
+	for _, kept := range node.Resources {
+		if videoIdentity(kept) == key {
+			return
+		}
+	}
+
+	topicBucket := videoIndexTopicRx(tx, topicId)
+	if topicBucket == nil || topicBucket.Bucket([]byte(key)) == nil {
+		return
+	}
+
+	videoBucket := topicBucket.Bucket([]byte(key))
+	err = videoBucket.Delete([]byte(node.Id.Format(time.RFC3339Nano)))
+	if err != nil {
+		return
+	}
+
+	if k, _ := videoBucket.Cursor().First(); k == nil {
+		err = topicBucket.DeleteBucket([]byte(key))
+	}
+
+	return
+}
+
+// unindexNodeTx forgets every video of a deleted node
+func unindexNodeTx(tx *bolt.Tx, topicId, nodeId string) (err error) {
+	topicBucket := videoIndexTopicRx(tx, topicId)
+	if topicBucket == nil {
+		return
+	}
+
+	var empty [][]byte
+	err = topicBucket.ForEach(func(key, _ []byte) error {
+		videoBucket := topicBucket.Bucket(key)
+		if videoBucket == nil {
+			return nil
+		}
+
+		err := videoBucket.Delete([]byte(nodeId))
+		if err != nil {
+			return err
+		}
+
+		if k, _ := videoBucket.Cursor().First(); k == nil {
+			empty = append(empty, key)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return
+	}
+
+	// bolt doesn't allow deleting buckets while going over them
+	for _, key := range empty {
+		err = topicBucket.DeleteBucket(key)
+		if err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+func deleteTopicVideoIndexTx(tx *bolt.Tx, topicId string) (err error) {
+	indexBucket := tx.Bucket([]byte(KeyVideoIndex))
+	if indexBucket == nil || indexBucket.Bucket([]byte(topicId)) == nil {
+		return
+	}
+
+	return indexBucket.DeleteBucket([]byte(topicId))
+}
+
+func videoIndexTopicRx(tx *bolt.Tx, topicId string) *bolt.Bucket {
+	indexBucket := tx.Bucket([]byte(KeyVideoIndex))
+	if indexBucket == nil {
+		return nil
+	}
+
+	return indexBucket.Bucket([]byte(topicId))
+}
+
+// videoNodesRx returns the nodes of the topic that have the video
+func videoNodesRx(tx *bolt.Tx, topicId, key string) (nodeIds []string) {
+	topicBucket := videoIndexTopicRx(tx, topicId)
+	if topicBucket == nil || topicBucket.Bucket([]byte(key)) == nil {
+		return
+	}
+
+	_ = topicBucket.Bucket([]byte(key)).ForEach(func(nodeId, _ []byte) error {
+		nodeIds = append(nodeIds, string(nodeId))
+		return nil
+	})
+
+	return
+}
+
+// nodeReferenceRx returns the id and title of a node, hidden nodes aren't pointed to
+func nodeReferenceRx(tx *bolt.Tx, topicId, nodeId string) (reference openapi.NodeReference, node openapi.NodeData, ok bool) {
+	node, err := getNodeRx(tx, nodeId, topicId)
+	if err != nil || node.IsHidden {
+		return
+	}
+
+	return openapi.NodeReference{Id: node.Id, Title: node.Title}, node, true
+}
+
+// duplicateNodesRx returns the other nodes of the topic that have the video
+func duplicateNodesRx(tx *bolt.Tx, topicId, nodeId string, resource openapi.LinkData) (duplicates []openapi.NodeReference) {
+	key := videoIdentity(resource)
+	if key == "" {
+		return
+	}
+
+	for _, other := range videoNodesRx(tx, topicId, key) {
+		if other == nodeId {
+			continue
+		}
+
+		if reference, _, ok := nodeReferenceRx(tx, topicId, other); ok {
+			duplicates = append(duplicates, reference)
+		}
+	}
+
+	return
+}
+
+func getRelatedNodes(db *bolt.DB, topicId string, node openapi.NodeData) (response []openapi.NodeReference, err error) {
+	err = db.View(func(tx *bolt.Tx) error {
+		response = relatedNodesRx(tx, topicId, node)
+		return nil
+	})
+
+	return
+}
+
+// relatedNodesRx returns the nodes of the topic that share videos with the node, the most shared first
+func relatedNodesRx(tx *bolt.Tx, topicId string, node openapi.NodeData) (response []openapi.NodeReference) {
+	nodeId := node.Id.Format(time.RFC3339Nano)
+
+	shared := make(map[string]int32)
+	seen := make(map[string]bool)
+	for _, resource := range node.Resources {
+		key := videoIdentity(resource)
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		for _, other := range videoNodesRx(tx, topicId, key) {
+			if other != nodeId {
+				shared[other]++
+			}
+		}
+	}
+
+	for other, count := range shared {
+		if reference, _, ok := nodeReferenceRx(tx, topicId, other); ok {
+			reference.SharedVideos = count
+			response = append(response, reference)
+		}
+	}
+
+	sort.Slice(response, func(i, j int) bool {
+		if response[i].SharedVideos != response[j].SharedVideos {
+			return response[i].SharedVideos > response[j].SharedVideos
+		}
+		return response[i].Id.Before(response[j].Id)
+	})
+
+	if len(response) > KeyRelatedNodesLimit {
+		response = response[:KeyRelatedNodesLimit]
+	}
+
+	return
+}
+
+func getSharedVideos(db *bolt.DB, topicId string, minNodes int32) (response []openapi.SharedVideo, err error) {
+	err = db.View(func(tx *bolt.Tx) error {
+		response, err = getSharedVideosRx(tx, topicId, minNodes)
+		return err
+	})
+
+	return
+}
+
+// getSharedVideosRx returns the videos of the topic on at least minNodes nodes, the most attached first
+func getSharedVideosRx(tx *bolt.Tx, topicId string, minNodes int32) (response []openapi.SharedVideo, err error) {
+	topicsBucket := tx.Bucket([]byte(KeyTopics))
+	if topicsBucket == nil || topicsBucket.Bucket([]byte(topicId)) == nil {
+		return nil, fmt.Errorf("can't find topic bucket")
+	}
+
+	if minNodes < 1 {
+		minNodes = 2
+	}
+
+	response = make([]openapi.SharedVideo, 0)
+
+	topicBucket := videoIndexTopicRx(tx, topicId)
+	if topicBucket == nil {
+		return
+	}
+
+	err = topicBucket.ForEach(func(key, _ []byte) error {
+		video := openapi.SharedVideo{Video: string(key), Nodes: make([]openapi.NodeReference, 0)}
+		for _, nodeId := range videoNodesRx(tx, topicId, string(key)) {
+			reference, node, ok := nodeReferenceRx(tx, topicId, nodeId)
+			if !ok {
+				continue
+			}
+			video.Nodes = append(video.Nodes, reference)
+
+			for _, resource := range node.Resources {
+				if video.Title == "" && videoIdentity(resource) == video.Video {
+					video.Title = resource.Title
+				}
+			}
+		}
+
+		if len(video.Nodes) >= int(minNodes) {
+			response = append(response, video)
+		}
+
+		return nil
+	})
+
+	sort.SliceStable(response, func(i, j int) bool {
+		return len(response[i].Nodes) > len(response[j].Nodes)
+	})
+
+	return
+}
+
+// buildVideoIndexTx indexes the videos of every node, it is the migration for databases from before the index
+func buildVideoIndexTx(tx *bolt.Tx) (err error) {
+	if tx.Bucket([]byte(KeyVideoIndex)) != nil {
+		err = tx.DeleteBucket([]byte(KeyVideoIndex))
+		if err != nil {
+			return
+		}
+	}
+
+	topicsBucket := tx.Bucket([]byte(KeyTopics))
+	if topicsBucket == nil {
+		return
+	}
+
+	var topicIds []string
+	err = topicsBucket.ForEach(func(topicId, v []byte) error {
+		if v == nil {
+			topicIds = append(topicIds, string(topicId))
+		}
+		return nil
+	})
+	if err != nil {
+		return
+	}
+
+	for _, topicId := range topicIds {
+		nodesBucket := topicsBucket.Bucket([]byte(topicId)).Bucket([]byte(KeyNodes))
+		if nodesBucket == nil {
+			continue
+		}
+
+		var nodeIds []string
+		err = nodesBucket.ForEach(func(nodeId, _ []byte) error {
+			nodeIds = append(nodeIds, string(nodeId))
+			return nil
+		})
+		if err != nil {
+			return
+		}
+
+		for _, nodeId := range nodeIds {
+			node, err := getNodeRx(tx, nodeId, topicId)
+			if err != nil {
+				return err
+			}
+
+			for _, resource := range node.Resources {
+				err = indexVideoTx(tx, topicId, nodeId, resource)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	openapi "github.com/SpyLime/flowBackend/go"
+	"github.com/go-pkgz/auth/token"
+	"github.com/go-pkgz/lgr"
+	"github.com/stretchr/testify/require"
+	bolt "go.etcd.io/bbolt"
+)
+
+func TestVideoIndexImpl(t *testing.T) {
+
+	lgr.Printf("INFO TestVideoIndexImpl")
+	t.Log("INFO TestVideoIndexImpl")
+	clock := TestClock{}
+	db, dbTearDown := OpenTestDB("VideoIndexImpl")
+	defer dbTearDown()
+
+	users, topics, nodesAndEdges, err := CreateTestData(db, &clock, 2, 1, 3)
+	require.Nil(t, err)
+
+	adder, err := getUser(db, users[0])
+	require.Nil(t, err)
+
+	first, second, third := nodesAndEdges[1].TargetId, nodesAndEdges[2].TargetId, nodesAndEdges[3].TargetId
+	lecture := "https://www.youtube.com/watch?v=dQw4w9WgXcQ"
+
+	duplicates, err := updateNodeVideoEdit(db, &clock, openapi.NodeData{Id: first, Topic: topics[0], Resources: []openapi.LinkData{{Link: lecture, Votes: 1}}}, adder)
+	require.Nil(t, err)
+	require.Empty(t, duplicates)
+
+	// a segment of the same video elsewhere in the topic is still added with a warning
+	duplicates, err = updateNodeVideoEdit(db, &clock, openapi.NodeData{Id: second, Topic: topics[0], Resources: []openapi.LinkData{{Link: "https://youtu.be/dQw4w9WgXcQ?t=60&end=300", Votes: 1}}}, adder)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(duplicates))
+	require.Equal(t, first, duplicates[0].Id)
+
+	for _, nodeId := range []time.Time{second, third} {
+		_, err = updateNodeVideoEdit(db, &clock, openapi.NodeData{Id: nodeId, Topic: topics[0], Resources: []openapi.LinkData{{Link: "https://vimeo.com/76979871", Votes: 1}}}, adder)
+		require.Nil(t, err)
+	}
+
+	// articles aren't videos
+	for _, nodeId := range []time.Time{first, third} {
+		duplicates, err = updateNodeVideoEdit(db, &clock, openapi.NodeData{Id: nodeId, Topic: topics[0], Resources: []openapi.LinkData{{Link: "https://example.org/blog/ninjas", Votes: 1}}}, adder)
+		require.Nil(t, err)
+		require.Empty(t, duplicates)
+	}
+
+	shared, err := getSharedVideos(db, topics[0], 0)
+	require.Nil(t, err)
+	require.Equal(t, 2, len(shared))
+	require.Equal(t, "https://vimeo.com/76979871", shared[0].Video)
+	require.Equal(t, lecture, shared[1].Video)
+	require.Equal(t, 2, len(shared[1].Nodes))
+
+	shared, err = getSharedVideos(db, topics[0], 3)
+	require.Nil(t, err)
+	require.Empty(t, shared)
+
+	// related nodes come with the node
+	service := NewNodeAPIServiceImpl(db, &clock, DefaultPolicy(), DefaultModerationConfig(), NewMetadataService(db, &clock, nil, DefaultMetadataConfig()), DefaultWatchConfig())
+	ctx := context.WithValue(context.Background(), userInfoKey, token.User{ID: users[1]})
+
+	response, err := service.GetNode(ctx, second.Format(time.RFC3339Nano), topics[0], "")
+	require.Nil(t, err)
+	related := response.Body.(openapi.NodeData).RelatedNodes
+	require.Equal(t, 2, len(related))
+	require.Equal(t, int32(1), related[0].SharedVideos)
+
+	// a video only leaves the index when the last of its segments leaves the node
+	_, err = updateNodeVideoEdit(db, &clock, openapi.NodeData{Id: second, Topic: topics[0], Resources: []openapi.LinkData{{Link: lecture, Votes: 1}}}, adder)
+	require.Nil(t, err)
+
+	_, err = updateNodeVideoEdit(db, &clock, openapi.NodeData{Id: second, Topic: topics[0], Resources: []openapi.LinkData{{Link: lecture, Votes: -1}}}, adder)
+	require.Nil(t, err)
+
+	node, err := getNode(db, second.Format(time.RFC3339Nano), topics[0])
+	require.Nil(t, err)
+	related, err = getRelatedNodes(db, topics[0], node)
+	require.Nil(t, err)
+	require.Equal(t, 2, len(related))
+
+	_, err = updateNodeVideoEdit(db, &clock, openapi.NodeData{Id: second, Topic: topics[0], Resources: []openapi.LinkData{{Link: "https://www.youtube.com/watch?v=dQw4w9WgXcQ&t=60&end=300", Votes: -1}}}, adder)
+	require.Nil(t, err)
+
+	node, err = getNode(db, second.Format(time.RFC3339Nano), topics[0])
+	require.Nil(t, err)
+	related, err = getRelatedNodes(db, topics[0], node)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(related))
+	require.Equal(t, third, related[0].Id)
+
+	// deleted nodes leave the index
+	err = deleteNode(db, third.Format(time.RFC3339Nano), topics[0])
+	require.Nil(t, err)
+
+	shared, err = getSharedVideos(db, topics[0], 1)
+	require.Nil(t, err)
+	require.Equal(t, 2, len(shared))
+	for _, video := range shared {
+		require.Equal(t, 1, len(video.Nodes))
+	}
+
+	// databases from before the index have it built once
+	err = db.Update(func(tx *bolt.Tx) error {
+		return tx.DeleteBucket([]byte(KeyVideoIndex))
+	})
+	require.Nil(t, err)
+
+	err = db.Update(buildVideoIndexTx)
+	require.Nil(t, err)
+
+	rebuilt, err := getSharedVideos(db, topics[0], 1)
+	require.Nil(t, err)
+	require.Equal(t, shared, rebuilt)
+
+	err = deleteTopic(db, topics[0])
+	require.Nil(t, err)
+
+	err = db.View(func(tx *bolt.Tx) error {
+		require.Nil(t, videoIndexTopicRx(tx, topics[0]))
+		return nil
+	})
+	require.Nil(t, err)
+}
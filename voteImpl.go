@@ -0,0 +1,421 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	openapi "github.com/SpyLime/flowBackend/go"
+	bolt "go.etcd.io/bbolt"
+)
+
+// voteReputationTx gives the creator the reputation of the vote and keeps the vote so vote rings can be found later,
+// the voter has to be saved before
+func voteReputationTx(tx *bolt.Tx, clock Clock, record openapi.VoteRecord) (err error) {
+	err = recordVoteTx(tx, clock, record)
+	if err != nil {
+		return
+	}
+
+	err = updateCreatorReputation(tx, clock, openapi.ReputationEvent{
+		UserId:  record.CreatorId,
+		Source:  record.Kind,
+		Topic:   record.Topic,
+		NodeId:  record.NodeId,
+		Link:    record.Link,
+		VoterId: record.VoterId,
+		Delta:   record.Value,
+	})
+	if err != nil {
+		return
+	}
+
+	// voting counts towards the streak of the voter
+	return refreshAchievementsTx(tx, clock, record.VoterId, true)
+}
+
+func recordVoteTx(tx *bolt.Tx, clock Clock, record openapi.VoteRecord) (err error) {
+	votesBucket, err := tx.CreateBucketIfNotExists([]byte(KeyVotes))
+	if err != nil {
+		return
+	}
+
+	// the sequence keeps the votes in the order they happened
+	sequence, err := votesBucket.NextSequence()
+	if err != nil {
+		return
+	}
+
+	record.Id = strconv.FormatUint(sequence, 10)
+	record.CreatedAt = clock.Now()
+
+	return putVoteRecordTx(votesBucket, sequence, record)
+}
+
+func putVoteRecordTx(votesBucket *bolt.Bucket, sequence uint64, record openapi.VoteRecord) (err error) {
+	marshal, err := json.Marshal(record)
+	if err != nil {
+		return
+	}
+
+	err = votesBucket.Put([]byte(fmt.Sprintf("%020d", sequence)), marshal)
+
+	return
+}
+
+// getVoteRecordsRx returns the votes of a topic or of every topic when topicId is empty, oldest first
+func getVoteRecordsRx(tx *bolt.Tx, topicId string, withVoided bool) (response []openapi.VoteRecord, err error) {
+	response = make([]openapi.VoteRecord, 0)
+
+	votesBucket := tx.Bucket([]byte(KeyVotes))
+	if votesBucket == nil {
+		return
+	}
+
+	err = votesBucket.ForEach(func(k, v []byte) error {
+		var record openapi.VoteRecord
+		err := json.Unmarshal(v, &record)
+		if err != nil {
+			return err
+		}
+
+		if (topicId == "" || record.Topic == topicId) && (withVoided || !record.Voided) {
+			response = append(response, record)
+		}
+
+		return nil
+	})
+
+	return
+}
+
+// getVotePatterns finds votes that look like vote rings or sockpuppets, the ones that gained the most reputation first
+func getVotePatterns(db *bolt.DB, topicId string) (response []openapi.VotePattern, err error) {
+	err = db.View(func(tx *bolt.Tx) error {
+		response, err = getVotePatternsRx(tx, topicId)
+		return err
+	})
+
+	return
+}
+
+func getVotePatternsRx(tx *bolt.Tx, topicId string) (response []openapi.VotePattern, err error) {
+	records, err := getVoteRecordsRx(tx, topicId, false)
+	if err != nil {
+		return
+	}
+
+	response = make([]openapi.VotePattern, 0)
+	response = append(response, findMutualVotes(records)...)
+
+	newAccounts, err := findNewAccountVotesRx(tx, records)
+	if err != nil {
+		return
+	}
+	response = append(response, newAccounts...)
+	response = append(response, findVoteBursts(records)...)
+
+	sort.SliceStable(response, func(i, j int) bool {
+		return response[i].Reputation > response[j].Reputation
+	})
+
+	return
+}
+
+// pairs of users who give most of their up votes to each other
+func findMutualVotes(records []openapi.VoteRecord) (response []openapi.VotePattern) {
+	upVotes := make(map[string]map[string][]openapi.VoteRecord)
+	totalUp := make(map[string]int)
+	for _, record := range records {
+		if record.Value <= 0 {
+			continue
+		}
+
+		if upVotes[record.VoterId] == nil {
+			upVotes[record.VoterId] = make(map[string][]openapi.VoteRecord)
+		}
+		upVotes[record.VoterId][record.CreatorId] = append(upVotes[record.VoterId][record.CreatorId], record)
+		totalUp[record.VoterId]++
+	}
+
+	voters := make([]string, 0, len(upVotes))
+	for voter := range upVotes {
+		voters = append(voters, voter)
+	}
+	sort.Strings(voters)
+
+	for _, a := range voters {
+		for _, b := range voters {
+			if a >= b {
+				continue
+			}
+
+			aToB, bToA := upVotes[a][b], upVotes[b][a]
+			if len(aToB) < KeyRingVotes || len(bToA) < KeyRingVotes {
+				continue
+			}
+
+			if len(aToB)*2 < totalUp[a] || len(bToA)*2 < totalUp[b] {
+				continue
+			}
+
+			evidence := append(append([]openapi.VoteRecord{}, aToB...), bToA...)
+			sortVoteRecords(evidence)
+
+			creatorId := b
+			if voteValue(bToA) > voteValue(aToB) {
+				creatorId = a
+			}
+
+			response = append(response, openapi.VotePattern{
+				Kind:        KeyPatternMutual,
+				Users:       []string{a, b},
+				CreatorId:   creatorId,
+				Description: fmt.Sprintf("%s gave %d of their %d up votes to %s, who gave %d of their %d back", a, len(aToB), totalUp[a], b, len(bToA), totalUp[b]),
+				Reputation:  voteValue(evidence),
+				Evidence:    evidence,
+			})
+		}
+	}
+
+	return
+}
+
+// new accounts whose first votes are all up votes for the same creator
+func findNewAccountVotesRx(tx *bolt.Tx, records []openapi.VoteRecord) (response []openapi.VotePattern, err error) {
+	byVoter := make(map[string][]openapi.VoteRecord)
+	voters := make([]string, 0)
+	for _, record := range records {
+		if _, ok := byVoter[record.VoterId]; !ok {
+			voters = append(voters, record.VoterId)
+		}
+		byVoter[record.VoterId] = append(byVoter[record.VoterId], record)
+	}
+
+	for _, voterId := range voters {
+		votes := byVoter[voterId]
+		if len(votes) < KeyNewAccountVotes {
+			continue
+		}
+
+		voter, err := getUserRx(tx, voterId)
+		if err != nil || voter.CreatedAt.IsZero() {
+			// deleted users and accounts from before sign ups were dated can't be told apart
+			continue
+		}
+
+		if votes[0].CreatedAt.Sub(voter.CreatedAt) > KeyNewAccountAge {
+			continue
+		}
+
+		// the run of up votes for the first creator they voted for
+		creatorId := votes[0].CreatorId
+		run := 0
+		for run < len(votes) && votes[run].CreatorId == creatorId && votes[run].Value > 0 {
+			run++
+		}
+
+		if run < KeyNewAccountVotes {
+			continue
+		}
+
+		evidence := votes[:run]
+		response = append(response, openapi.VotePattern{
+			Kind:        KeyPatternNewAccount,
+			Users:       []string{voterId},
+			CreatorId:   creatorId,
+			Description: fmt.Sprintf("%s was made %s before their first vote and their first %d votes were up votes for %s", voterId, votes[0].CreatedAt.Sub(voter.CreatedAt).Round(time.Minute), run, creatorId),
+			Reputation:  voteValue(evidence),
+			Evidence:    evidence,
+		})
+	}
+
+	return
+}
+
+// many votes on one node or video in a short time
+func findVoteBursts(records []openapi.VoteRecord) (response []openapi.VotePattern) {
+	byTarget := make(map[string][]openapi.VoteRecord)
+	targets := make([]string, 0)
+	for _, record := range records {
+		target := record.Topic + "|" + record.NodeId.Format(time.RFC3339Nano) + "|" + record.Link
+		if _, ok := byTarget[target]; !ok {
+			targets = append(targets, target)
+		}
+		byTarget[target] = append(byTarget[target], record)
+	}
+
+	for _, target := range targets {
+		votes := byTarget[target]
+
+		// mark every vote that is in a window with enough votes, a run of marked votes is one burst
+		inBurst := make([]bool, len(votes))
+		start := 0
+		for end := range votes {
+			for votes[end].CreatedAt.Sub(votes[start].CreatedAt) > KeyBurstWindow {
+				start++
+			}
+
+			if end-start+1 >= KeyBurstVotes {
+				for i := start; i <= end; i++ {
+					inBurst[i] = true
+				}
+			}
+		}
+
+		for i := 0; i < len(votes); i++ {
+			if !inBurst[i] {
+				continue
+			}
+
+			j := i
+			for j < len(votes) && inBurst[j] {
+				j++
+			}
+
+			evidence := votes[i:j]
+			response = append(response, openapi.VotePattern{
+				Kind:        KeyPatternBurst,
+				Users:       voteVoters(evidence),
+				CreatorId:   evidence[0].CreatorId,
+				Description: fmt.Sprintf("%d votes on %s in %s", len(evidence), voteTarget(evidence[0]), evidence[len(evidence)-1].CreatedAt.Sub(evidence[0].CreatedAt)),
+				Reputation:  voteValue(evidence),
+				Evidence:    evidence,
+			})
+
+			i = j
+		}
+	}
+
+	return
+}
+
+func sortVoteRecords(records []openapi.VoteRecord) {
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].CreatedAt.Before(records[j].CreatedAt)
+	})
+}
+
+func voteValue(records []openapi.VoteRecord) (value int32) {
+	for _, record := range records {
+		value += record.Value
+	}
+
+	return
+}
+
+func voteVoters(records []openapi.VoteRecord) (voters []string) {
+	seen := make(map[string]bool)
+	for _, record := range records {
+		if !seen[record.VoterId] {
+			seen[record.VoterId] = true
+			voters = append(voters, record.VoterId)
+		}
+	}
+
+	return
+}
+
+func voteTarget(record openapi.VoteRecord) string {
+	if record.Link != "" {
+		return fmt.Sprintf("the video %s in %s", record.Link, record.Topic)
+	}
+
+	return fmt.Sprintf("the node %s in %s", record.NodeId.Format(time.RFC3339Nano), record.Topic)
+}
+
+// voidVotes takes back the reputation the votes gave their creators, votes already voided are left out of the response
+func voidVotes(db *bolt.DB, clock Clock, actorId string, request openapi.VoteVoid) (response []openapi.VoteRecord, err error) {
+	err = db.Update(func(tx *bolt.Tx) error {
+		response, err = voidVotesTx(tx, clock, actorId, request)
+		return err
+	})
+
+	return
+}
+
+func voidVotesTx(tx *bolt.Tx, clock Clock, actorId string, request openapi.VoteVoid) (response []openapi.VoteRecord, err error) {
+	if request.Reason == "" {
+		return response, fmt.Errorf("voiding votes needs a reason")
+	}
+
+	votesBucket := tx.Bucket([]byte(KeyVotes))
+	if votesBucket == nil {
+		return response, fmt.Errorf("can't find votes bucket")
+	}
+
+	response = make([]openapi.VoteRecord, 0)
+	creators := make([]string, 0)
+	taken := make(map[string]int32)
+
+	for _, voteId := range request.VoteIds {
+		sequence, err := strconv.ParseUint(voteId, 10, 64)
+		if err != nil {
+			return response, fmt.Errorf("invalid vote id %s", voteId)
+		}
+
+		voteData := votesBucket.Get([]byte(fmt.Sprintf("%020d", sequence)))
+		if voteData == nil {
+			return response, fmt.Errorf("can't find vote %s", voteId)
+		}
+
+		var record openapi.VoteRecord
+		err = json.Unmarshal(voteData, &record)
+		if err != nil {
+			return response, err
+		}
+
+		if record.Voided {
+			continue
+		}
+
+		event := openapi.ReputationEvent{
+			UserId:  record.CreatorId,
+			Source:  KeySourceVoid,
+			Topic:   record.Topic,
+			NodeId:  record.NodeId,
+			Link:    record.Link,
+			VoterId: record.VoterId,
+			Delta:   -record.Value,
+			Reason:  request.Reason,
+		}
+
+		// the organization of a deleted topic is gone with it, what the vote gave is taken back from the global reputation
+		if _, infoErr := getTopicInfoRx(tx, record.Topic); infoErr != nil {
+			err = changeReputationTx(tx, clock, event)
+		} else {
+			err = updateCreatorReputation(tx, clock, event)
+		}
+		if err != nil {
+			return response, err
+		}
+
+		record.Voided = true
+		err = putVoteRecordTx(votesBucket, sequence, record)
+		if err != nil {
+			return response, err
+		}
+
+		if _, ok := taken[record.CreatorId]; !ok {
+			creators = append(creators, record.CreatorId)
+		}
+		taken[record.CreatorId] += record.Value
+		response = append(response, record)
+	}
+
+	for _, creatorId := range creators {
+		err = addAuditEntryTx(tx, clock, openapi.AuditEntry{
+			Action:  KeyAuditVoid,
+			UserId:  creatorId,
+			ActorId: actorId,
+			Reason:  fmt.Sprintf("%s (%d reputation taken back)", request.Reason, taken[creatorId]),
+		})
+		if err != nil {
+			return
+		}
+	}
+
+	return
+}
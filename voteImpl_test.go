@@ -0,0 +1,179 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	openapi "github.com/SpyLime/flowBackend/go"
+	"github.com/go-pkgz/lgr"
+	"github.com/stretchr/testify/require"
+	bolt "go.etcd.io/bbolt"
+)
+
+func TestVotePatternsImpl(t *testing.T) {
+
+	lgr.Printf("INFO TestVotePatternsImpl")
+	t.Log("INFO TestVotePatternsImpl")
+	clock := TestClock{}
+	db, dbTearDown := OpenTestDB("VotePatternsImpl")
+	defer dbTearDown()
+
+	users, topics, nodesAndEdges, err := CreateTestData(db, &clock, 4, 1, 2)
+	require.Nil(t, err)
+
+	info, err := getTopicInfo(db, topics[0])
+	require.Nil(t, err)
+
+	creator := info.CreatedBy
+	var others []string
+	for _, id := range users {
+		if id != creator {
+			others = append(others, id)
+		}
+	}
+	newcomer, ringA, ringB := others[0], others[1], others[2]
+	nodeId := nodesAndEdges[1].TargetId
+
+	// the newcomer signs up right before voting
+	err = db.Update(func(tx *bolt.Tx) error {
+		usersBucket, user, err := getUserAndBucketRx(tx, newcomer)
+		if err != nil {
+			return err
+		}
+
+		user.CreatedAt = clock.Now()
+		marshal, err := json.Marshal(user)
+		if err != nil {
+			return err
+		}
+
+		return usersBucket.Put([]byte(newcomer), marshal)
+	})
+	require.Nil(t, err)
+
+	// votes that give reputation are recorded
+	clock.TickOne(time.Hour)
+	_, err = updateNodeBattleVote(db, &clock, openapi.NodeData{Id: nodeId, Topic: topics[0], BattleTested: 1}, newcomer)
+	require.Nil(t, err)
+
+	vote := func(record openapi.VoteRecord) {
+		clock.TickOne(time.Hour)
+		err := db.Update(func(tx *bolt.Tx) error {
+			return voteReputationTx(tx, &clock, record)
+		})
+		require.Nil(t, err)
+	}
+
+	vote(openapi.VoteRecord{VoterId: newcomer, CreatorId: creator, Topic: topics[0], NodeId: nodesAndEdges[0].SourceId, Kind: KeyVoteFresh, Value: 1})
+	vote(openapi.VoteRecord{VoterId: newcomer, CreatorId: creator, Topic: topics[0], NodeId: nodesAndEdges[2].SourceId, Kind: KeyVoteFresh, Value: 1})
+
+	ringBefore, err := getUser(db, ringB)
+	require.Nil(t, err)
+
+	for i := 0; i < KeyRingVotes; i++ {
+		vote(openapi.VoteRecord{VoterId: ringA, CreatorId: ringB, Topic: topics[0], NodeId: nodesAndEdges[1].SourceId, Kind: KeyVoteBattle, Value: 1})
+		vote(openapi.VoteRecord{VoterId: ringB, CreatorId: ringA, Topic: topics[0], NodeId: nodesAndEdges[2].SourceId, Kind: KeyVoteBattle, Value: 1})
+	}
+
+	ringAfter, err := getUser(db, ringB)
+	require.Nil(t, err)
+	require.Equal(t, ringBefore.Reputation+KeyRingVotes, ringAfter.Reputation)
+
+	// a pile of down votes on one node within minutes
+	clock.TickOne(time.Hour)
+	for i := 0; i < KeyBurstVotes; i++ {
+		clock.TickOne(time.Minute)
+		err = db.Update(func(tx *bolt.Tx) error {
+			return voteReputationTx(tx, &clock, openapi.VoteRecord{VoterId: others[i%3], CreatorId: creator, Topic: topics[0], NodeId: nodesAndEdges[2].SourceId, Kind: KeyVoteBattle, Value: -1})
+		})
+		require.Nil(t, err)
+	}
+
+	patterns, err := getVotePatterns(db, topics[0])
+	require.Nil(t, err)
+
+	found := make(map[string]openapi.VotePattern)
+	for _, pattern := range patterns {
+		lgr.Printf("INFO %s - %s", pattern.Kind, pattern.Description)
+		found[pattern.Kind] = pattern
+	}
+	require.Equal(t, 3, len(found))
+
+	require.Equal(t, []string{newcomer}, found[KeyPatternNewAccount].Users)
+	require.Equal(t, creator, found[KeyPatternNewAccount].CreatorId)
+	require.Equal(t, 3, len(found[KeyPatternNewAccount].Evidence))
+	require.Equal(t, KeyVoteBattle, found[KeyPatternNewAccount].Evidence[0].Kind)
+
+	require.ElementsMatch(t, []string{ringA, ringB}, found[KeyPatternMutual].Users)
+	require.Equal(t, int32(2*KeyRingVotes), found[KeyPatternMutual].Reputation)
+
+	require.Equal(t, KeyBurstVotes, len(found[KeyPatternBurst].Evidence))
+	require.Equal(t, int32(-KeyBurstVotes), found[KeyPatternBurst].Reputation)
+
+	patterns, err = getVotePatterns(db, "missing")
+	require.Nil(t, err)
+	require.Equal(t, 0, len(patterns))
+
+	// voiding the ring takes back what it gained
+	var voteIds []string
+	for _, record := range found[KeyPatternMutual].Evidence {
+		voteIds = append(voteIds, record.Id)
+	}
+
+	_, err = voidVotes(db, &clock, creator, openapi.VoteVoid{VoteIds: voteIds})
+	require.NotNil(t, err)
+
+	_, err = voidVotes(db, &clock, creator, openapi.VoteVoid{VoteIds: []string{"999"}, Reason: "ring"})
+	require.NotNil(t, err)
+
+	voided, err := voidVotes(db, &clock, creator, openapi.VoteVoid{VoteIds: voteIds, Reason: "ring"})
+	require.Nil(t, err)
+	require.Equal(t, 2*KeyRingVotes, len(voided))
+	require.True(t, voided[0].Voided)
+
+	ringAfter, err = getUser(db, ringB)
+	require.Nil(t, err)
+	require.Equal(t, ringBefore.Reputation, ringAfter.Reputation)
+
+	audit, err := getAuditEntries(db, ringB)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(audit))
+	require.Equal(t, KeyAuditVoid, audit[0].Action)
+
+	voided, err = voidVotes(db, &clock, creator, openapi.VoteVoid{VoteIds: voteIds, Reason: "ring"})
+	require.Nil(t, err)
+	require.Equal(t, 0, len(voided))
+
+	patterns, err = getVotePatterns(db, topics[0])
+	require.Nil(t, err)
+	for _, pattern := range patterns {
+		require.NotEqual(t, KeyPatternMutual, pattern.Kind)
+	}
+
+	// votes in a deleted topic are taken back from the global reputation
+	ringBefore, err = getUser(db, ringA)
+	require.Nil(t, err)
+
+	vote(openapi.VoteRecord{VoterId: newcomer, CreatorId: ringA, Topic: topics[0], NodeId: nodesAndEdges[1].SourceId, Kind: KeyVoteBattle, Value: 1})
+
+	var lastId string
+	err = db.View(func(tx *bolt.Tx) error {
+		k, _ := tx.Bucket([]byte(KeyVotes)).Cursor().Last()
+		lastId = strings.TrimLeft(string(k), "0")
+		return nil
+	})
+	require.Nil(t, err)
+
+	err = deleteTopic(db, topics[0])
+	require.Nil(t, err)
+
+	voided, err = voidVotes(db, &clock, creator, openapi.VoteVoid{VoteIds: []string{lastId}, Reason: "deleted"})
+	require.Nil(t, err)
+	require.Equal(t, 1, len(voided))
+
+	ringAfter, err = getUser(db, ringA)
+	require.Nil(t, err)
+	require.Equal(t, ringBefore.Reputation, ringAfter.Reputation)
+}
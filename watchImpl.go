@@ -0,0 +1,273 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	openapi "github.com/SpyLime/flowBackend/go"
+	bolt "go.etcd.io/bbolt"
+)
+
+// every user has their own bucket of watch progress by link, like their notifications
+func putWatchProgress(db *bolt.DB, clock Clock, userId string, progress openapi.WatchProgress, finishAt float32) (response openapi.WatchProgress, err error) {
+	err = db.Update(func(tx *bolt.Tx) error {
+		response, err = putWatchProgressTx(tx, clock, userId, progress, finishAt)
+		return err
+	})
+
+	return
+}
+
+// putWatchProgressTx keeps the latest progress, a finished video stays finished when it is watched again.
+// Only links some node the user can see has are kept and under their canonical form so they match the resources of nodes
+func putWatchProgressTx(tx *bolt.Tx, clock Clock, userId string, progress openapi.WatchProgress, finishAt float32) (response openapi.WatchProgress, err error) {
+	progress.Link = strings.TrimSpace(progress.Link)
+	if progress.Link == "" {
+		return response, fmt.Errorf("no link to record")
+	}
+
+	resource, err := resourceData(openapi.LinkData{Link: progress.Link})
+	if err != nil {
+		return
+	}
+	progress.Link = resource.Link
+
+	if !resourceOnNodeRx(tx, resource, userId) {
+		return response, fmt.Errorf("no node has %s", progress.Link)
+	}
+
+	if progress.Percent < 0 || progress.Percent > 100 {
+		return response, fmt.Errorf("percent must be between 0 and 100")
+	}
+
+	if progress.Position < 0 {
+		return response, fmt.Errorf("position can't be negative")
+	}
+
+	watchesBucket, err := tx.CreateBucketIfNotExists([]byte(KeyWatches))
+	if err != nil {
+		return
+	}
+
+	userBucket, err := watchesBucket.CreateBucketIfNotExists([]byte(userId))
+	if err != nil {
+		return
+	}
+
+	var previous openapi.WatchProgress
+	if data := userBucket.Get([]byte(progress.Link)); data != nil {
+		err = json.Unmarshal(data, &previous)
+		if err != nil {
+			return
+		}
+	}
+
+	progress.Finished = previous.Finished || progress.Percent >= finishAt
+	progress.UpdatedAt = clock.Now()
+
+	marshal, err := json.Marshal(progress)
+	if err != nil {
+		return
+	}
+
+	err = userBucket.Put([]byte(progress.Link), marshal)
+
+	return progress, err
+}
+
+// resourceOnNodeRx finds videos through the video index and reads the nodes of every topic for anything else.
+// Topics hidden from the viewer are skipped so nobody learns what the topics of other organizations have
+func resourceOnNodeRx(tx *bolt.Tx, resource openapi.LinkData, viewerId string) bool {
+	topicsBucket := tx.Bucket([]byte(KeyTopics))
+	if topicsBucket == nil {
+		return false
+	}
+
+	hidden, err := hiddenTopicsRx(tx, viewerId)
+	if err != nil {
+		return false
+	}
+
+	key := videoIdentity(resource)
+	found := false
+	_ = topicsBucket.ForEach(func(topicId, v []byte) error {
+		if v != nil || found || hidden[string(topicId)] {
+			return nil
+		}
+
+		if key != "" {
+			for _, nodeId := range videoNodesRx(tx, string(topicId), key) {
+				node, err := getNodeRx(tx, nodeId, string(topicId))
+				if err == nil && findVideo(node.Resources, resource.Link) >= 0 {
+					found = true
+					return nil
+				}
+			}
+			return nil
+		}
+
+		nodesBucket := topicsBucket.Bucket(topicId).Bucket([]byte(KeyNodes))
+		if nodesBucket == nil {
+			return nil
+		}
+
+		return nodesBucket.ForEach(func(_, data []byte) error {
+			var node openapi.NodeData
+			if data == nil || found || json.Unmarshal(data, &node) != nil {
+				return nil
+			}
+
+			found = findVideo(node.Resources, resource.Link) >= 0
+			return nil
+		})
+	})
+
+	return found
+}
+
+// getWatches returns the watch progress of the user, the last watched first
+func getWatches(db *bolt.DB, userId string) (response []openapi.WatchProgress, err error) {
+	err = db.View(func(tx *bolt.Tx) error {
+		watches, err := watchesByLinkRx(tx, userId)
+		if err != nil {
+			return err
+		}
+
+		response = make([]openapi.WatchProgress, 0, len(watches))
+		for _, progress := range watches {
+			response = append(response, progress)
+		}
+
+		return nil
+	})
+
+	sort.Slice(response, func(i, j int) bool {
+		if !response[i].UpdatedAt.Equal(response[j].UpdatedAt) {
+			return response[i].UpdatedAt.After(response[j].UpdatedAt)
+		}
+		return response[i].Link < response[j].Link
+	})
+
+	return
+}
+
+func getWatchesByLink(db *bolt.DB, userId string) (response map[string]openapi.WatchProgress, err error) {
+	err = db.View(func(tx *bolt.Tx) error {
+		response, err = watchesByLinkRx(tx, userId)
+		return err
+	})
+
+	return
+}
+
+func watchesByLinkRx(tx *bolt.Tx, userId string) (response map[string]openapi.WatchProgress, err error) {
+	response = make(map[string]openapi.WatchProgress)
+
+	watchesBucket := tx.Bucket([]byte(KeyWatches))
+	if watchesBucket == nil {
+		return
+	}
+
+	userBucket := watchesBucket.Bucket([]byte(userId))
+	if userBucket == nil {
+		return
+	}
+
+	err = userBucket.ForEach(func(link, data []byte) error {
+		var progress openapi.WatchProgress
+		err := json.Unmarshal(data, &progress)
+		if err != nil {
+			return err
+		}
+
+		response[string(link)] = progress
+		return nil
+	})
+
+	return
+}
+
+func deleteWatchesTx(tx *bolt.Tx, userId string) (err error) {
+	watchesBucket := tx.Bucket([]byte(KeyWatches))
+	if watchesBucket == nil || watchesBucket.Bucket([]byte(userId)) == nil {
+		return
+	}
+
+	return watchesBucket.DeleteBucket([]byte(userId))
+}
+
+// applyWatches shows the viewer how far they got into each resource of the node and how much of the node they completed
+func applyWatches(node openapi.NodeData, watches map[string]openapi.WatchProgress, completeAt float32) openapi.NodeData {
+	resources := make([]openapi.LinkData, len(node.Resources))
+	for i, resource := range node.Resources {
+		progress := watches[resource.Link]
+		resource.Progress = progress.Percent
+		resource.LastPosition = progress.Position
+		resource.Finished = progress.Finished
+		resources[i] = resource
+	}
+
+	node.Resources = resources
+	node.YoutubeLinks = youTubeResources(node.Resources)
+	node.Completion = nodeCompletion(node.Resources, watches, completeAt)
+
+	return node
+}
+
+// nodeCompletion counts the finished videos of the node, hidden ones aren't asked for
+func nodeCompletion(resources []openapi.LinkData, watches map[string]openapi.WatchProgress, completeAt float32) (completion openapi.Completion) {
+	for _, resource := range resources {
+		if resource.Type != KeyResourceVideo || resource.IsHidden {
+			continue
+		}
+
+		completion.Videos++
+		if watches[resource.Link].Finished {
+			completion.Finished++
+		}
+	}
+
+	completion.Complete = completion.Videos > 0 && float32(completion.Finished)*100 >= completeAt*float32(completion.Videos)
+
+	return
+}
+
+// getMapCompletion returns how much of every node of the topic the user completed by node id
+func getMapCompletion(db *bolt.DB, topicId, userId string, completeAt float32) (response map[string]openapi.Completion, err error) {
+	err = db.View(func(tx *bolt.Tx) error {
+		topicsBucket := tx.Bucket([]byte(KeyTopics))
+		if topicsBucket == nil || topicsBucket.Bucket([]byte(topicId)) == nil {
+			return fmt.Errorf("can't find topic bucket")
+		}
+
+		nodesBucket := topicsBucket.Bucket([]byte(topicId)).Bucket([]byte(KeyNodes))
+		if nodesBucket == nil {
+			return fmt.Errorf("can't find nodes bucket")
+		}
+
+		watches, err := watchesByLinkRx(tx, userId)
+		if err != nil {
+			return err
+		}
+
+		response = make(map[string]openapi.Completion)
+		return nodesBucket.ForEach(func(nodeId, data []byte) error {
+			if data == nil {
+				return nil
+			}
+
+			var node openapi.NodeData
+			err := json.Unmarshal(data, &node)
+			if err != nil {
+				return err
+			}
+
+			response[string(nodeId)] = nodeCompletion(node.Resources, watches, completeAt)
+			return nil
+		})
+	})
+
+	return
+}
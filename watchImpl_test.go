@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	openapi "github.com/SpyLime/flowBackend/go"
+	"github.com/go-pkgz/auth/token"
+	"github.com/go-pkgz/lgr"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchImpl(t *testing.T) {
+
+	lgr.Printf("INFO TestWatchImpl")
+	t.Log("INFO TestWatchImpl")
+	clock := TestClock{}
+	db, dbTearDown := OpenTestDB("WatchImpl")
+	defer dbTearDown()
+
+	users, topics, nodesAndEdges, err := CreateTestData(db, &clock, 2, 1, 1)
+	require.Nil(t, err)
+
+	adder, err := getUser(db, users[0])
+	require.Nil(t, err)
+
+	nodeId := nodesAndEdges[1].TargetId
+	lecture := "https://www.youtube.com/watch?v=dQw4w9WgXcQ"
+	drill := "https://www.youtube.com/watch?v=9bZkp7q19f0"
+	article := "https://example.org/blog/ninjas"
+	for _, link := range []string{lecture, drill, article} {
+		_, err = updateNodeVideoEdit(db, &clock, openapi.NodeData{Id: nodeId, Topic: topics[0], Resources: []openapi.LinkData{{Link: link, Votes: 1}}}, adder)
+		require.Nil(t, err)
+	}
+
+	finishAt := DefaultWatchConfig().FinishAt
+
+	progress, err := putWatchProgress(db, &clock, users[1], openapi.WatchProgress{Link: lecture, Percent: 50, Position: 120}, finishAt)
+	require.Nil(t, err)
+	require.False(t, progress.Finished)
+	require.Equal(t, clock.Now(), progress.UpdatedAt)
+
+	// a finished video stays finished when it is watched again
+	progress, err = putWatchProgress(db, &clock, users[1], openapi.WatchProgress{Link: lecture, Percent: 95, Position: 200}, finishAt)
+	require.Nil(t, err)
+	require.True(t, progress.Finished)
+
+	clock.TickOne(time.Minute)
+	progress, err = putWatchProgress(db, &clock, users[1], openapi.WatchProgress{Link: lecture, Percent: 10, Position: 20}, finishAt)
+	require.Nil(t, err)
+	require.True(t, progress.Finished)
+	require.Equal(t, float32(10), progress.Percent)
+
+	for _, wrong := range []openapi.WatchProgress{{Link: " "}, {Link: drill, Percent: 101}, {Link: drill, Percent: -1}, {Link: drill, Position: -5}, {Link: "https://youtu.be/aaaaaaaaaaa"}, {Link: "https://example.org/elsewhere"}} {
+		_, err = putWatchProgress(db, &clock, users[1], wrong, finishAt)
+		require.NotNil(t, err)
+	}
+
+	// links are kept as the node has them however they are written
+	clock.TickOne(time.Minute)
+	progress, err = putWatchProgress(db, &clock, users[1], openapi.WatchProgress{Link: "https://youtu.be/9bZkp7q19f0", Percent: 40, Position: 60}, finishAt)
+	require.Nil(t, err)
+	require.Equal(t, drill, progress.Link)
+
+	watches, err := getWatches(db, users[1])
+	require.Nil(t, err)
+	require.Equal(t, 2, len(watches))
+	require.Equal(t, drill, watches[0].Link)
+
+	watches, err = getWatches(db, users[0])
+	require.Nil(t, err)
+	require.Empty(t, watches)
+
+	// only videos count towards completing the node
+	service := NewNodeAPIServiceImpl(db, &clock, DefaultPolicy(), DefaultModerationConfig(), NewMetadataService(db, &clock, nil, DefaultMetadataConfig()), DefaultWatchConfig())
+	ctx := context.WithValue(context.Background(), userInfoKey, token.User{ID: users[1]})
+
+	response, err := service.GetNode(ctx, nodeId.Format(time.RFC3339Nano), topics[0], KeyRankingNew)
+	require.Nil(t, err)
+	node := response.Body.(openapi.NodeData)
+	require.Equal(t, openapi.Completion{Finished: 1, Videos: 2}, node.Completion)
+	for _, resource := range node.Resources {
+		if resource.Link == drill {
+			require.Equal(t, float32(40), resource.Progress)
+			require.Equal(t, int32(60), resource.LastPosition)
+			require.False(t, resource.Finished)
+		}
+	}
+	require.Equal(t, 2, len(node.YoutubeLinks))
+
+	// a lower share completes the node sooner
+	config := WatchConfig{FinishAt: finishAt, CompleteAt: 50}
+	service = NewNodeAPIServiceImpl(db, &clock, DefaultPolicy(), DefaultModerationConfig(), NewMetadataService(db, &clock, nil, DefaultMetadataConfig()), config)
+	response, err = service.GetNode(ctx, nodeId.Format(time.RFC3339Nano), topics[0], "")
+	require.Nil(t, err)
+	require.True(t, response.Body.(openapi.NodeData).Completion.Complete)
+
+	// the map shows the same completion for every node
+	_, err = putWatchProgress(db, &clock, users[1], openapi.WatchProgress{Link: drill, Percent: 100}, finishAt)
+	require.Nil(t, err)
+
+	mapService := NewMapAPIServiceImpl(db, &clock, DefaultPolicy(), DefaultWatchConfig())
+	response, err = mapService.GetMapById(ctx, topics[0])
+	require.Nil(t, err)
+	for _, flowNode := range response.Body.(openapi.MapData).Nodes {
+		if flowNode.Id.Equal(nodeId) {
+			require.Equal(t, openapi.Completion{Finished: 2, Videos: 2, Complete: true}, flowNode.Data.Completion)
+		} else {
+			require.Zero(t, flowNode.Data.Completion.Videos)
+		}
+	}
+
+	// nobody logged in sees no completion
+	response, err = service.GetNode(context.Background(), nodeId.Format(time.RFC3339Nano), topics[0], "")
+	require.Nil(t, err)
+	require.Zero(t, response.Body.(openapi.NodeData).Completion)
+
+	// other resources are found on their nodes too
+	progress, err = putWatchProgress(db, &clock, users[0], openapi.WatchProgress{Link: article, Percent: 100}, finishAt)
+	require.Nil(t, err)
+	require.True(t, progress.Finished)
+
+	err = deleteUser(db, users[1])
+	require.Nil(t, err)
+
+	watches, err = getWatches(db, users[1])
+	require.Nil(t, err)
+	require.Empty(t, watches)
+}
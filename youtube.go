@@ -0,0 +1,183 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	openapi "github.com/SpyLime/flowBackend/go"
+)
+
+// VideoRef is what a YouTube link points to, the same video can be linked in many ways.
+// A start alone is only where a shared link starts playing, with an end it makes a segment of the video and segments of one video are different links
+type VideoRef struct {
+	Id       string
+	Start    int32 // seconds into the video
+	End      int32 // seconds into the video, 0 plays to the end
+	Playlist string
+}
+
+var youTubeIdPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{11}$`)
+
+var youTubePlaylistPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{12,64}$`)
+
+// 1h2m3s, 2m, 45s or just 45
+var youTubeTimePattern = regexp.MustCompile(`^(?:(\d+)h)?(?:(\d+)m)?(?:(\d+)s?)?$`)
+
+var youTubeHosts = map[string]bool{
+	"youtube.com":              true,
+	"www.youtube.com":          true,
+	"m.youtube.com":            true,
+	"music.youtube.com":        true,
+	"youtube-nocookie.com":     true,
+	"www.youtube-nocookie.com": true,
+}
+
+func isYouTubeHost(host string) bool {
+	return host == "youtu.be" || host == "www.youtu.be" || youTubeHosts[host]
+}
+
+// parseYouTubeLink pulls the video, segment and playlist out of any of the ways YouTube links a video:
+// youtu.be/ID, /watch?v=ID, /shorts/ID, /embed/ID, /live/ID and /v/ID on any YouTube host, with or without a scheme
+func parseYouTubeLink(link string) (ref VideoRef, err error) {
+	parsed, err := parseResourceURL(link)
+	if err != nil {
+		return
+	}
+
+	host := strings.ToLower(parsed.Hostname())
+	segments := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+	query := parsed.Query()
+
+	switch {
+	case host == "youtu.be" || host == "www.youtu.be":
+		ref.Id = segments[0]
+	case !isYouTubeHost(host):
+		return ref, fmt.Errorf("not a YouTube link %s", link)
+	case segments[0] == "watch":
+		ref.Id = query.Get("v")
+	case len(segments) > 1 && (segments[0] == "shorts" || segments[0] == "embed" || segments[0] == "live" || segments[0] == "v"):
+		ref.Id = segments[1]
+	}
+
+	if !youTubeIdPattern.MatchString(ref.Id) {
+		return VideoRef{}, fmt.Errorf("can't find the video in %s", link)
+	}
+
+	start := query.Get("t")
+	if start == "" {
+		start = query.Get("start")
+	}
+	ref.Start = parseYouTubeTime(start)
+	ref.End = parseYouTubeTime(query.Get("end"))
+	ref.Playlist = query.Get("list")
+
+	return
+}
+
+// parsePlaylistId takes the list out of any YouTube link that has one, a bare id is taken as it is
+func parsePlaylistId(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if youTubePlaylistPattern.MatchString(value) {
+		return value, nil
+	}
+
+	parsed, err := parseResourceURL(value)
+	if err == nil && isYouTubeHost(strings.ToLower(parsed.Hostname())) && youTubePlaylistPattern.MatchString(parsed.Query().Get("list")) {
+		return parsed.Query().Get("list"), nil
+	}
+
+	return "", fmt.Errorf("can't find the playlist in %s", value)
+}
+
+// parseYouTubeTime turns a start time into seconds, anything it can't read starts at the beginning
+func parseYouTubeTime(value string) int32 {
+	match := youTubeTimePattern.FindStringSubmatch(value)
+	if value == "" || match == nil {
+		return 0
+	}
+
+	var seconds int32
+	for i, unit := range []int32{3600, 60, 1} {
+		if match[i+1] == "" {
+			continue
+		}
+
+		n, err := strconv.ParseInt(match[i+1], 10, 32)
+		if err != nil {
+			return 0
+		}
+		seconds += int32(n) * unit
+	}
+
+	return seconds
+}
+
+// Canonical is the one link every way of linking the video or segment is stored as, the playlist and a start without an end are kept apart
+func (ref VideoRef) Canonical() string {
+	link := "https://www.youtube.com/watch?v=" + ref.Id
+	if ref.End == 0 {
+		return link
+	}
+
+	if ref.Start > 0 {
+		link += fmt.Sprintf("&t=%d", ref.Start)
+	}
+
+	return link + fmt.Sprintf("&end=%d", ref.End)
+}
+
+// Embed is the link a player loads, it starts where the link does and only plays the segment
+func (ref VideoRef) Embed() string {
+	link := "https://www.youtube.com/embed/" + ref.Id
+	params := url.Values{}
+	if ref.Start > 0 {
+		params.Set("start", strconv.Itoa(int(ref.Start)))
+	}
+	if ref.End > 0 {
+		params.Set("end", strconv.Itoa(int(ref.End)))
+	}
+	if len(params) > 0 {
+		link += "?" + params.Encode()
+	}
+
+	return link
+}
+
+// videoLinkData stores the link in its canonical form with the video, start, segment and playlist it had,
+// a start or end given with the link wins over the one in it
+func videoLinkData(link openapi.LinkData) (openapi.LinkData, error) {
+	ref, err := parseYouTubeLink(link.Link)
+	if err != nil {
+		link.Link = strings.TrimSpace(link.Link)
+		return link, nil
+	}
+
+	if link.Start != 0 {
+		ref.Start = link.Start
+	}
+	if link.End != 0 {
+		ref.End = link.End
+	}
+	if link.Playlist != "" {
+		ref.Playlist = link.Playlist
+	}
+
+	if ref.Start < 0 || ref.End < 0 {
+		return link, fmt.Errorf("a segment can't start or end before the video")
+	}
+	if ref.End > 0 && ref.End <= ref.Start {
+		return link, fmt.Errorf("a segment must end after it starts")
+	}
+
+	link.Link = ref.Canonical()
+	link.EmbedUrl = ref.Embed()
+	link.VideoId = ref.Id
+	link.Start = ref.Start
+	link.End = ref.End
+	link.Playlist = ref.Playlist
+
+	return link, nil
+}
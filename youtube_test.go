@@ -0,0 +1,377 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	openapi "github.com/SpyLime/flowBackend/go"
+	"github.com/go-pkgz/lgr"
+	"github.com/stretchr/testify/require"
+	bolt "go.etcd.io/bbolt"
+)
+
+func TestParseYouTubeLink(t *testing.T) {
+	tests := []struct {
+		link string
+		ref  VideoRef
+	}{
+		{"https://www.youtube.com/watch?v=dQw4w9WgXcQ", VideoRef{Id: "dQw4w9WgXcQ"}},
+		{"www.youtube.com/watch?v=dQw4w9WgXcQ&t=30", VideoRef{Id: "dQw4w9WgXcQ", Start: 30}},
+		{"https://youtu.be/dQw4w9WgXcQ?t=1m5s", VideoRef{Id: "dQw4w9WgXcQ", Start: 65}},
+		{"https://m.youtube.com/watch?feature=share&v=dQw4w9WgXcQ&list=PL123", VideoRef{Id: "dQw4w9WgXcQ", Playlist: "PL123"}},
+		{"https://www.youtube.com/shorts/dQw4w9WgXcQ", VideoRef{Id: "dQw4w9WgXcQ"}},
+		{"https://www.youtube-nocookie.com/embed/dQw4w9WgXcQ?start=90", VideoRef{Id: "dQw4w9WgXcQ", Start: 90}},
+		{" https://YOUTUBE.com/live/dQw4w9WgXcQ?t=1h ", VideoRef{Id: "dQw4w9WgXcQ", Start: 3600}},
+		{"https://www.youtube.com/watch?v=dQw4w9WgXcQ&t=soon", VideoRef{Id: "dQw4w9WgXcQ"}},
+		{"https://youtu.be/dQw4w9WgXcQ?si=uRr8UmZ0sgNl4BA0&t=397", VideoRef{Id: "dQw4w9WgXcQ", Start: 397}},
+	}
+
+	for _, test := range tests {
+		ref, err := parseYouTubeLink(test.link)
+		require.Nil(t, err, test.link)
+		require.Equal(t, test.ref, ref, test.link)
+		require.Equal(t, "https://www.youtube.com/watch?v=dQw4w9WgXcQ", ref.Canonical())
+	}
+
+	ref, err := parseYouTubeLink("https://www.youtube.com/embed/dQw4w9WgXcQ?start=720&end=1080")
+	require.Nil(t, err)
+	require.Equal(t, VideoRef{Id: "dQw4w9WgXcQ", Start: 720, End: 1080}, ref)
+
+	ref = VideoRef{Id: "dQw4w9WgXcQ", Start: 720, Playlist: "PL123"}
+	require.Equal(t, "https://www.youtube.com/watch?v=dQw4w9WgXcQ", ref.Canonical())
+	require.Equal(t, "https://www.youtube.com/embed/dQw4w9WgXcQ?start=720", ref.Embed())
+
+	ref.End = 1080
+	require.Equal(t, "https://www.youtube.com/watch?v=dQw4w9WgXcQ&t=720&end=1080", ref.Canonical())
+	require.Equal(t, "https://www.youtube.com/embed/dQw4w9WgXcQ?end=1080&start=720", ref.Embed())
+
+	for _, link := range []string{"", "www.youtube.com", "https://www.youtube.com/watch?v=abc123", "https://vimeo.com/dQw4w9WgXcQ", "https://www.youtube.com/channel/dQw4w9WgXcQ"} {
+		_, err := parseYouTubeLink(link)
+		require.NotNil(t, err, link)
+	}
+
+	require.True(t, areSameResource("https://youtu.be/dQw4w9WgXcQ", "youtube.com/shorts/dQw4w9WgXcQ"))
+	require.False(t, areSameResource("https://youtu.be/dQw4w9WgXcQ", "https://youtu.be/aaaaaaaaaaa"))
+
+	// sharing from a point in the video is still the video, a segment needs an end
+	require.True(t, areSameResource("https://youtu.be/dQw4w9WgXcQ", "https://www.youtube.com/watch?v=dQw4w9WgXcQ&t=30"))
+	require.True(t, areSameResource("https://youtu.be/dQw4w9WgXcQ?t=12m&end=1080", "youtube.com/embed/dQw4w9WgXcQ?start=720&end=1080"))
+	require.False(t, areSameResource("https://youtu.be/dQw4w9WgXcQ", "https://youtu.be/dQw4w9WgXcQ?t=720&end=1080"))
+
+	// anything else is only the same when it is written the same
+	require.True(t, areSameResource("www.youtube.com", " www.youtube.com"))
+	require.False(t, areSameResource("www.youtube.com", "www.youtube.com/test"))
+}
+
+func TestCanonicalVideoLinksImpl(t *testing.T) {
+
+	lgr.Printf("INFO TestCanonicalVideoLinksImpl")
+	t.Log("INFO TestCanonicalVideoLinksImpl")
+	clock := TestClock{}
+	db, dbTearDown := OpenTestDB("CanonicalVideoLinksImpl")
+	defer dbTearDown()
+
+	users, topics, nodesAndEdges, err := CreateTestData(db, &clock, 2, 1, 1)
+	require.Nil(t, err)
+
+	adder, err := getUser(db, users[0])
+	require.Nil(t, err)
+
+	nodeId := nodesAndEdges[1].TargetId
+
+	// however a video is linked it is the same video
+	_, err = updateNodeVideoEdit(db, &clock, openapi.NodeData{Id: nodeId, Topic: topics[0], YoutubeLinks: []openapi.LinkData{{Link: "https://youtu.be/dQw4w9WgXcQ?t=42", Votes: 1}}}, adder)
+	require.Nil(t, err)
+
+	_, err = updateNodeVideoEdit(db, &clock, openapi.NodeData{Id: nodeId, Topic: topics[0], YoutubeLinks: []openapi.LinkData{{Link: "https://www.youtube.com/shorts/dQw4w9WgXcQ", Votes: 1}}}, adder)
+	require.NotNil(t, err)
+
+	node, err := getNode(db, nodeId.Format(time.RFC3339Nano), topics[0])
+	require.Nil(t, err)
+	require.Equal(t, 1, len(node.YoutubeLinks))
+	require.Equal(t, "https://www.youtube.com/watch?v=dQw4w9WgXcQ", node.YoutubeLinks[0].Link)
+	require.Equal(t, "dQw4w9WgXcQ", node.YoutubeLinks[0].VideoId)
+	require.Equal(t, int32(42), node.YoutubeLinks[0].Start)
+
+	request := openapi.NodeData{Id: nodeId, Topic: topics[0], YoutubeLinks: []openapi.LinkData{{Link: "m.youtube.com/watch?v=dQw4w9WgXcQ", Votes: 1}}}
+	votes, err := updateNodeVideoVote(db, &clock, request, users[1])
+	require.Nil(t, err)
+	require.Equal(t, int32(1), votes)
+
+	// the caller's link isn't changed
+	require.Equal(t, "m.youtube.com/watch?v=dQw4w9WgXcQ", request.YoutubeLinks[0].Link)
+
+	voter, err := getUser(db, users[1])
+	require.Nil(t, err)
+	require.Equal(t, []string{"https://www.youtube.com/watch?v=dQw4w9WgXcQ"}, voter.VideoUp)
+
+	// links stored before they were canonical are merged, their votes are added up
+	err = db.Update(func(tx *bolt.Tx) error {
+		nodesBucket, nodeData, err := nodeDataFinderTx(tx, topics[0], nodeId.Format(time.RFC3339Nano))
+		if err != nil {
+			return err
+		}
+
+		var node openapi.NodeData
+		err = json.Unmarshal(nodeData, &node)
+		if err != nil {
+			return err
+		}
+
+		node.YoutubeLinks = append(node.YoutubeLinks,
+			openapi.LinkData{Link: "youtu.be/dQw4w9WgXcQ", Votes: 2},
+			openapi.LinkData{Link: "https://www.youtube.com/embed/aaaaaaaaaaa?start=5", Votes: 3})
+
+		marshal, err := json.Marshal(node)
+		if err != nil {
+			return err
+		}
+
+		err = nodesBucket.Put([]byte(nodeId.Format(time.RFC3339Nano)), marshal)
+		if err != nil {
+			return err
+		}
+
+		usersBucket, user, err := getUserAndBucketRx(tx, users[1])
+		if err != nil {
+			return err
+		}
+
+		user.VideoUp = append(user.VideoUp, "youtu.be/dQw4w9WgXcQ", "youtu.be/aaaaaaaaaaa")
+		marshal, err = json.Marshal(user)
+		if err != nil {
+			return err
+		}
+
+		return usersBucket.Put([]byte(users[1]), marshal)
+	})
+	require.Nil(t, err)
+
+	err = runMigrations(db, &clock)
+	require.Nil(t, err)
+
+	node, err = getNode(db, nodeId.Format(time.RFC3339Nano), topics[0])
+	require.Nil(t, err)
+	require.Equal(t, 2, len(node.YoutubeLinks))
+	require.Equal(t, int32(3), node.YoutubeLinks[0].Votes)
+	require.Equal(t, "https://www.youtube.com/watch?v=aaaaaaaaaaa", node.YoutubeLinks[1].Link)
+	require.Equal(t, int32(5), node.YoutubeLinks[1].Start)
+	require.Equal(t, "https://www.youtube.com/embed/aaaaaaaaaaa?start=5", node.YoutubeLinks[1].EmbedUrl)
+
+	voter, err = getUser(db, users[1])
+	require.Nil(t, err)
+	require.Equal(t, []string{"https://www.youtube.com/watch?v=dQw4w9WgXcQ", "https://www.youtube.com/watch?v=aaaaaaaaaaa"}, voter.VideoUp)
+
+	// migrations only run once
+	err = db.Update(func(tx *bolt.Tx) error {
+		usersBucket, user, err := getUserAndBucketRx(tx, users[1])
+		if err != nil {
+			return err
+		}
+
+		user.VideoUp = append(user.VideoUp, "youtu.be/bbbbbbbbbbb")
+		marshal, err := json.Marshal(user)
+		if err != nil {
+			return err
+		}
+
+		return usersBucket.Put([]byte(users[1]), marshal)
+	})
+	require.Nil(t, err)
+
+	err = runMigrations(db, &clock)
+	require.Nil(t, err)
+
+	voter, err = getUser(db, users[1])
+	require.Nil(t, err)
+	require.Equal(t, "youtu.be/bbbbbbbbbbb", voter.VideoUp[2])
+}
+
+func TestVideoSegmentsImpl(t *testing.T) {
+
+	lgr.Printf("INFO TestVideoSegmentsImpl")
+	t.Log("INFO TestVideoSegmentsImpl")
+	clock := TestClock{}
+	db, dbTearDown := OpenTestDB("VideoSegmentsImpl")
+	defer dbTearDown()
+
+	users, topics, nodesAndEdges, err := CreateTestData(db, &clock, 2, 1, 1)
+	require.Nil(t, err)
+
+	adder, err := getUser(db, users[0])
+	require.Nil(t, err)
+
+	nodeId := nodesAndEdges[1].TargetId
+	lecture := "https://www.youtube.com/watch?v=dQw4w9WgXcQ"
+
+	// two segments of one lecture live on the same node
+	_, err = updateNodeVideoEdit(db, &clock, openapi.NodeData{Id: nodeId, Topic: topics[0], YoutubeLinks: []openapi.LinkData{{Link: lecture, Start: 720, End: 1080, Votes: 1}}}, adder)
+	require.Nil(t, err)
+
+	_, err = updateNodeVideoEdit(db, &clock, openapi.NodeData{Id: nodeId, Topic: topics[0], YoutubeLinks: []openapi.LinkData{{Link: "https://youtu.be/dQw4w9WgXcQ?t=30m&end=2100", Votes: 1}}}, adder)
+	require.Nil(t, err)
+
+	_, err = updateNodeVideoEdit(db, &clock, openapi.NodeData{Id: nodeId, Topic: topics[0], YoutubeLinks: []openapi.LinkData{{Link: "https://www.youtube.com/embed/dQw4w9WgXcQ?start=720&end=1080", Votes: 1}}}, adder)
+	require.NotNil(t, err)
+
+	for _, segment := range []openapi.LinkData{{Start: 600, End: 600}, {Start: 600, End: 300}, {Start: -1}} {
+		segment.Link = lecture
+		segment.Votes = 1
+		_, err = updateNodeVideoEdit(db, &clock, openapi.NodeData{Id: nodeId, Topic: topics[0], YoutubeLinks: []openapi.LinkData{segment}}, adder)
+		require.NotNil(t, err)
+	}
+
+	node, err := getNode(db, nodeId.Format(time.RFC3339Nano), topics[0])
+	require.Nil(t, err)
+	require.Equal(t, 2, len(node.YoutubeLinks))
+	require.Equal(t, "https://www.youtube.com/watch?v=dQw4w9WgXcQ&t=720&end=1080", node.YoutubeLinks[0].Link)
+	require.Equal(t, "https://www.youtube.com/embed/dQw4w9WgXcQ?end=1080&start=720", node.YoutubeLinks[0].EmbedUrl)
+	require.Equal(t, int32(1800), node.YoutubeLinks[1].Start)
+	require.Equal(t, int32(2100), node.YoutubeLinks[1].End)
+
+	// each segment is voted on by itself
+	votes, err := updateNodeVideoVote(db, &clock, openapi.NodeData{Id: nodeId, Topic: topics[0], YoutubeLinks: []openapi.LinkData{{Link: lecture, Start: 720, End: 1080, Votes: 1}}}, users[1])
+	require.Nil(t, err)
+	require.Equal(t, int32(1), votes)
+
+	votes, err = updateNodeVideoVote(db, &clock, openapi.NodeData{Id: nodeId, Topic: topics[0], YoutubeLinks: []openapi.LinkData{{Link: node.YoutubeLinks[1].Link, Votes: -1}}}, users[1])
+	require.Nil(t, err)
+	require.Equal(t, int32(-1), votes)
+
+	_, err = updateNodeVideoVote(db, &clock, openapi.NodeData{Id: nodeId, Topic: topics[0], YoutubeLinks: []openapi.LinkData{{Link: lecture, Votes: 1}}}, users[1])
+	require.NotNil(t, err)
+
+	node, err = getNode(db, nodeId.Format(time.RFC3339Nano), topics[0])
+	require.Nil(t, err)
+	require.Equal(t, int32(1), node.YoutubeLinks[0].Votes)
+	require.Equal(t, int32(-1), node.YoutubeLinks[1].Votes)
+
+	voter, err := getUser(db, users[1])
+	require.Nil(t, err)
+	require.Equal(t, []string{node.YoutubeLinks[0].Link}, voter.VideoUp)
+	require.Equal(t, []string{node.YoutubeLinks[1].Link}, voter.VideoDown)
+
+	// removing one segment leaves the other
+	_, err = updateNodeVideoEdit(db, &clock, openapi.NodeData{Id: nodeId, Topic: topics[0], YoutubeLinks: []openapi.LinkData{{Link: node.YoutubeLinks[0].Link, Votes: -1}}}, adder)
+	require.Nil(t, err)
+
+	node, err = getNode(db, nodeId.Format(time.RFC3339Nano), topics[0])
+	require.Nil(t, err)
+	require.Equal(t, 1, len(node.YoutubeLinks))
+	require.Equal(t, "https://www.youtube.com/watch?v=dQw4w9WgXcQ&t=1800&end=2100", node.YoutubeLinks[0].Link)
+}
+
+func TestFoldStartTimesImpl(t *testing.T) {
+
+	lgr.Printf("INFO TestFoldStartTimesImpl")
+	t.Log("INFO TestFoldStartTimesImpl")
+	clock := TestClock{}
+	db, dbTearDown := OpenTestDB("FoldStartTimesImpl")
+	defer dbTearDown()
+
+	users, topics, nodesAndEdges, err := CreateTestData(db, &clock, 2, 1, 1)
+	require.Nil(t, err)
+
+	nodeId := nodesAndEdges[1].TargetId
+	video := "https://www.youtube.com/watch?v=dQw4w9WgXcQ"
+	shared := video + "&t=42"
+	segment := video + "&t=42&end=300"
+
+	// links stored while a start alone was a segment
+	err = db.Update(func(tx *bolt.Tx) error {
+		nodesBucket, nodeData, err := nodeDataFinderTx(tx, topics[0], nodeId.Format(time.RFC3339Nano))
+		if err != nil {
+			return err
+		}
+
+		var node openapi.NodeData
+		err = json.Unmarshal(nodeData, &node)
+		if err != nil {
+			return err
+		}
+
+		node.Resources = []openapi.LinkData{
+			{Link: video, Provider: KeyProviderYouTube, Votes: 2},
+			{Link: shared, Provider: KeyProviderYouTube, Votes: 1},
+			{Link: segment, Provider: KeyProviderYouTube, Votes: 1}}
+
+		marshal, err := json.Marshal(node)
+		if err != nil {
+			return err
+		}
+
+		err = nodesBucket.Put([]byte(nodeId.Format(time.RFC3339Nano)), marshal)
+		if err != nil {
+			return err
+		}
+
+		usersBucket, user, err := getUserAndBucketRx(tx, users[1])
+		if err != nil {
+			return err
+		}
+
+		user.VideoUp = []string{shared, video}
+		user.VideoVotes = []openapi.VideoVote{
+			{Topic: topics[0], NodeId: nodeId, Link: shared, Value: 1},
+			{Topic: topics[0], NodeId: nodeId, Link: video, Value: 1}}
+		marshal, err = json.Marshal(user)
+		if err != nil {
+			return err
+		}
+
+		err = usersBucket.Put([]byte(users[1]), marshal)
+		if err != nil {
+			return err
+		}
+
+		watchesBucket, err := tx.CreateBucketIfNotExists([]byte(KeyWatches))
+		if err != nil {
+			return err
+		}
+
+		userBucket, err := watchesBucket.CreateBucketIfNotExists([]byte(users[1]))
+		if err != nil {
+			return err
+		}
+
+		for _, progress := range []openapi.WatchProgress{
+			{Link: shared, Percent: 100, Finished: true, UpdatedAt: clock.Now()},
+			{Link: video, Percent: 20, Position: 60, UpdatedAt: clock.Now().Add(time.Hour)}} {
+			marshal, err = json.Marshal(progress)
+			if err != nil {
+				return err
+			}
+
+			err = userBucket.Put([]byte(progress.Link), marshal)
+			if err != nil {
+				return err
+			}
+		}
+
+		return foldStartTimesTx(tx)
+	})
+	require.Nil(t, err)
+
+	// the shared link is the video again, the segment stays by itself
+	node, err := getNode(db, nodeId.Format(time.RFC3339Nano), topics[0])
+	require.Nil(t, err)
+	require.Equal(t, 2, len(node.Resources))
+	require.Equal(t, video, node.Resources[0].Link)
+	require.Equal(t, int32(3), node.Resources[0].Votes)
+	require.Equal(t, int32(1), node.Resources[0].Up)
+	require.Equal(t, segment, node.Resources[1].Link)
+
+	voter, err := getUser(db, users[1])
+	require.Nil(t, err)
+	require.Equal(t, []string{video}, voter.VideoUp)
+	require.Equal(t, 1, len(voter.VideoVotes))
+
+	// the latest progress is kept and the video stays finished
+	watches, err := getWatchesByLink(db, users[1])
+	require.Nil(t, err)
+	require.Equal(t, 1, len(watches))
+	require.Equal(t, video, watches[video].Link)
+	require.Equal(t, int32(60), watches[video].Position)
+	require.True(t, watches[video].Finished)
+}